@@ -47,7 +47,11 @@ type ApplicationSpec struct {
 	// Project is the ArgoCD project to provision in.
 	Project string `json:"project"`
 	// Source defines where to get the application configuration from.
-	Source ApplicationSource `json:"source"`
+	// This is omitted when Sources is used.
+	Source ApplicationSource `json:"source,omitzero"`
+	// Sources defines multiple sources, for example a Helm chart and
+	// a Git repository containing its values files.
+	Sources []ApplicationSource `json:"sources,omitempty"`
 	// Destination defines where to provision the application.
 	Destination ApplicationDestination `json:"destination"`
 	// SyncPolicy defines how to keep the application in sync.
@@ -70,6 +74,9 @@ type ApplicationSource struct {
 	TargetRevision string `json:"targetRevision"`
 	// Helm defines helm parameters.
 	Helm *ApplicationSourceHelm `json:"helm,omitempty"`
+	// Ref names a source in a multi-source application so its files can
+	// be referenced by other sources as "$ref/path".
+	Ref string `json:"ref,omitempty"`
 }
 
 type ApplicationSourceHelm struct {
@@ -78,9 +85,14 @@ type ApplicationSourceHelm struct {
 	ReleaseName string `json:"releaseName,omitempty"`
 	// Values is a verbatim values file to pass to helm.
 	Values string `json:"values,omitempty"`
+	// ValueFiles are values files to pass to helm, these may reference
+	// files in other sources with a "$ref/" prefix.
+	ValueFiles []string `json:"valueFiles,omitempty"`
 	// Parameters are a set of key value pairs to pass to helm
 	// via the --set flag.
 	Parameters []HelmParameter `json:"parameters,omitempty"`
+	// SkipCrds stops helm from installing CRDs bundled with the chart.
+	SkipCrds bool `json:"skipCrds,omitempty"`
 }
 
 type HelmParameter struct {
@@ -115,6 +127,24 @@ type ApplicationSyncPolicy struct {
 	Automated *ApplicationSyncAutomation `json:"automated,omitempty"`
 	// ManagedNamespaceMetadata gives labels and annotations for namespaces created with SyncOption `CreateNamespace=true`
 	ManagedNamespaceMetadata *NamespaceMetadata `json:"managedNamespaceMetadata,omitempty"`
+	// Retry, if set, retries failed synchronizations.
+	Retry *ApplicationSyncRetry `json:"retry,omitempty"`
+}
+
+type ApplicationSyncRetry struct {
+	// Limit is the maximum number of attempts, a negative value means unlimited.
+	Limit int64 `json:"limit,omitempty"`
+	// Backoff controls the time between attempts.
+	Backoff *ApplicationSyncBackoff `json:"backoff,omitempty"`
+}
+
+type ApplicationSyncBackoff struct {
+	// Duration is the initial time between attempts e.g. "5s".
+	Duration string `json:"duration,omitempty"`
+	// Factor multiplies the duration after each attempt.
+	Factor *int64 `json:"factor,omitempty"`
+	// MaxDuration is the maximum time between attempts e.g. "3m".
+	MaxDuration string `json:"maxDuration,omitempty"`
 }
 
 type ApplicationSyncAutomation struct {
@@ -136,6 +166,8 @@ type ApplicationIgnoreDifference struct {
 	Kind string `json:"kind"`
 	// JSONPointers is a list of JSON pointers to ignore in diffs.
 	JSONPointers []string `json:"jsonPointers"`
+	// JQPathExpressions is a list of JQ path expressions to ignore in diffs.
+	JQPathExpressions []string `json:"jqPathExpressions,omitempty"`
 }
 
 // ApplicationStatus defines the status of the project.
@@ -144,6 +176,10 @@ type ApplicationStatus struct {
 	Health *ApplicationHealth `json:"health"`
 	// Sync defines the application's synchronization status.
 	Sync *ApplicationSync `json:"sync"`
+	// Resources defines the status of each resource managed by the application.
+	Resources []ApplicationResourceStatus `json:"resources,omitempty"`
+	// OperationState defines the state of the last sync operation.
+	OperationState *ApplicationOperationState `json:"operationState,omitempty"`
 }
 
 type ApplicationHealthStatus string
@@ -156,11 +192,23 @@ const (
 	// Degraded is when things are osensibly working, but not fully healthy
 	// yet.
 	Degraded ApplicationHealthStatus = "Degraded"
+
+	// Progressing is when resources are not yet healthy, but are expected
+	// to become so e.g. deployments are scaling up.
+	Progressing ApplicationHealthStatus = "Progressing"
+
+	// Suspended is when resources are paused e.g. a suspended cron job.
+	Suspended ApplicationHealthStatus = "Suspended"
+
+	// Missing is when resources don't exist in the cluster yet.
+	Missing ApplicationHealthStatus = "Missing"
 )
 
 type ApplicationHealth struct {
 	// Status reports the health status.
 	Status ApplicationHealthStatus `json:"status"`
+	// Message is a human readable explanation of the status.
+	Message string `json:"message,omitempty"`
 }
 
 type ApplicationSyncStatus string
@@ -171,6 +219,9 @@ const (
 	// also not synced, which is broken.
 	Synced ApplicationSyncStatus = "Synced"
 
+	// OutOfSync is when the live state differs from the desired state.
+	OutOfSync ApplicationSyncStatus = "OutOfSync"
+
 	// Unknown means Argos not done anything yet.
 	Unknown ApplicationSyncStatus = "Unknown"
 )
@@ -179,3 +230,33 @@ type ApplicationSync struct {
 	// Status reports te sync status.
 	Status ApplicationSyncStatus `json:"status"`
 }
+
+type ApplicationResourceStatus struct {
+	// Group is the resource API group.
+	Group string `json:"group,omitempty"`
+	// Version is the resource API version.
+	Version string `json:"version,omitempty"`
+	// Kind is the resource kind.
+	Kind string `json:"kind,omitempty"`
+	// Namespace is the resource namespace, if namespaced.
+	Namespace string `json:"namespace,omitempty"`
+	// Name is the resource name.
+	Name string `json:"name,omitempty"`
+	// Status reports the resource's sync status.
+	Status ApplicationSyncStatus `json:"status,omitempty"`
+	// Health reports the resource's health, if it has any.
+	Health *ApplicationHealth `json:"health,omitempty"`
+}
+
+type ApplicationOperationPhase string
+
+type ApplicationOperationState struct {
+	// Phase is the current phase of the operation e.g. Running, Failed.
+	Phase ApplicationOperationPhase `json:"phase"`
+	// Message is a human readable message about the operation.
+	Message string `json:"message,omitempty"`
+	// StartedAt is when the operation started.
+	StartedAt metav1.Time `json:"startedAt"`
+	// FinishedAt is when the operation completed, if it has.
+	FinishedAt *metav1.Time `json:"finishedAt,omitempty"`
+}
@@ -93,6 +93,11 @@ func (in *ApplicationIgnoreDifference) DeepCopyInto(out *ApplicationIgnoreDiffer
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.JQPathExpressions != nil {
+		in, out := &in.JQPathExpressions, &out.JQPathExpressions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -139,6 +144,48 @@ func (in *ApplicationList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplicationOperationState) DeepCopyInto(out *ApplicationOperationState) {
+	*out = *in
+	in.StartedAt.DeepCopyInto(&out.StartedAt)
+	if in.FinishedAt != nil {
+		in, out := &in.FinishedAt, &out.FinishedAt
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationOperationState.
+func (in *ApplicationOperationState) DeepCopy() *ApplicationOperationState {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplicationOperationState)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplicationResourceStatus) DeepCopyInto(out *ApplicationResourceStatus) {
+	*out = *in
+	if in.Health != nil {
+		in, out := &in.Health, &out.Health
+		*out = new(ApplicationHealth)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationResourceStatus.
+func (in *ApplicationResourceStatus) DeepCopy() *ApplicationResourceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplicationResourceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ApplicationSource) DeepCopyInto(out *ApplicationSource) {
 	*out = *in
@@ -163,6 +210,11 @@ func (in *ApplicationSource) DeepCopy() *ApplicationSource {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ApplicationSourceHelm) DeepCopyInto(out *ApplicationSourceHelm) {
 	*out = *in
+	if in.ValueFiles != nil {
+		in, out := &in.ValueFiles, &out.ValueFiles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.Parameters != nil {
 		in, out := &in.Parameters, &out.Parameters
 		*out = make([]HelmParameter, len(*in))
@@ -185,6 +237,13 @@ func (in *ApplicationSourceHelm) DeepCopy() *ApplicationSourceHelm {
 func (in *ApplicationSpec) DeepCopyInto(out *ApplicationSpec) {
 	*out = *in
 	in.Source.DeepCopyInto(&out.Source)
+	if in.Sources != nil {
+		in, out := &in.Sources, &out.Sources
+		*out = make([]ApplicationSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	out.Destination = in.Destination
 	in.SyncPolicy.DeepCopyInto(&out.SyncPolicy)
 	if in.IgnoreDifferences != nil {
@@ -220,6 +279,18 @@ func (in *ApplicationStatus) DeepCopyInto(out *ApplicationStatus) {
 		*out = new(ApplicationSync)
 		**out = **in
 	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]ApplicationResourceStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.OperationState != nil {
+		in, out := &in.OperationState, &out.OperationState
+		*out = new(ApplicationOperationState)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -249,6 +320,27 @@ func (in *ApplicationSync) DeepCopy() *ApplicationSync {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplicationSyncBackoff) DeepCopyInto(out *ApplicationSyncBackoff) {
+	*out = *in
+	if in.Factor != nil {
+		in, out := &in.Factor, &out.Factor
+		*out = new(int64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationSyncBackoff.
+func (in *ApplicationSyncBackoff) DeepCopy() *ApplicationSyncBackoff {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplicationSyncBackoff)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ApplicationSyncAutomation) DeepCopyInto(out *ApplicationSyncAutomation) {
 	*out = *in
@@ -283,6 +375,11 @@ func (in *ApplicationSyncPolicy) DeepCopyInto(out *ApplicationSyncPolicy) {
 		*out = new(NamespaceMetadata)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Retry != nil {
+		in, out := &in.Retry, &out.Retry
+		*out = new(ApplicationSyncRetry)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -296,6 +393,27 @@ func (in *ApplicationSyncPolicy) DeepCopy() *ApplicationSyncPolicy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplicationSyncRetry) DeepCopyInto(out *ApplicationSyncRetry) {
+	*out = *in
+	if in.Backoff != nil {
+		in, out := &in.Backoff, &out.Backoff
+		*out = new(ApplicationSyncBackoff)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationSyncRetry.
+func (in *ApplicationSyncRetry) DeepCopy() *ApplicationSyncRetry {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplicationSyncRetry)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HelmParameter) DeepCopyInto(out *HelmParameter) {
 	*out = *in
@@ -31,7 +31,7 @@ func (r *ManagedResource) ResourceLabels() (labels.Set, error) {
 }
 
 func (r *ManagedResource) Paused() bool {
-	return false
+	return r.Spec.Pause
 }
 
 func (r *ManagedResource) StatusConditionRead(t unikornv1.ConditionType) (*metav1.Condition, error) {
@@ -41,3 +41,7 @@ func (r *ManagedResource) StatusConditionRead(t unikornv1.ConditionType) (*metav
 func (r *ManagedResource) SetProvisioningCondition(status corev1.ConditionStatus, reason unikornv1.ProvisioningConditionReason, message string) {
 	unikornv1.UpdateCondition(&r.Status.Conditions, unikornv1.ConditionAvailable, status, string(reason), message)
 }
+
+func (r *ManagedResource) SetHealthCondition(status corev1.ConditionStatus, reason unikornv1.HealthConditionReason, message string) {
+	unikornv1.UpdateCondition(&r.Status.Conditions, unikornv1.ConditionHealthy, status, string(reason), message)
+}
@@ -34,9 +34,14 @@ type ManagedResourceList struct {
 type ManagedResource struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              ManagedResourceSpec   `json:"spec"`
 	Status            ManagedResourceStatus `json:"status"`
 }
 
+type ManagedResourceSpec struct {
+	Pause bool `json:"pause,omitempty"`
+}
+
 type ManagedResourceStatus struct {
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
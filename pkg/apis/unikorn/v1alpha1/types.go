@@ -51,6 +51,35 @@ func (v *SemanticVersion) Equal(o *SemanticVersion) bool {
 	return v.Version.Equal(&o.Version)
 }
 
+func (v *SemanticVersion) LessThan(o *SemanticVersion) bool {
+	return v.Compare(o) < 0
+}
+
+func (v *SemanticVersion) LessThanOrEqual(o *SemanticVersion) bool {
+	return v.Compare(o) <= 0
+}
+
+func (v *SemanticVersion) GreaterThan(o *SemanticVersion) bool {
+	return v.Compare(o) > 0
+}
+
+func (v *SemanticVersion) GreaterThanOrEqual(o *SemanticVersion) bool {
+	return v.Compare(o) >= 0
+}
+
+// Satisfies checks the version against a constraint e.g. ">= 1.2.0, < 2.0.0", see
+// https://github.com/Masterminds/semver for the full range syntax.  Note that
+// pre-release versions only satisfy constraints that themselves reference a
+// pre-release, so "1.3.0-rc.1" does not satisfy ">= 1.2.0".
+func (v *SemanticVersion) Satisfies(constraint string) (bool, error) {
+	constraints, err := semver.NewConstraint(constraint)
+	if err != nil {
+		return false, err
+	}
+
+	return constraints.Check(&v.Version), nil
+}
+
 func (v *SemanticVersion) UnmarshalJSON(b []byte) error {
 	return json.Unmarshal(b, &v.Version)
 }
@@ -309,6 +338,9 @@ const (
 	// indicate we have finished deprovisioning and the Kubernetes
 	// garbage collector can remove the resource.
 	ConditionReasonDeprovisioned ProvisioningConditionReason = "Deprovisioned"
+	// ConditionReasonPaused is used by a condition to indicate reconciliation
+	// has been paused, the status is left as it was when paused.
+	ConditionReasonPaused ProvisioningConditionReason = "Paused"
 )
 
 // Failure reasons for ConditionAvailable. These are surfaced to the user and
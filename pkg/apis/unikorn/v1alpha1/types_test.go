@@ -88,6 +88,83 @@ func TestSemanticVersion(t *testing.T) {
 	require.Equal(t, jsonSemver, string(marshalled))
 }
 
+// mustSemanticVersion parses a semantic version or fails the test.
+func mustSemanticVersion(t *testing.T, s string) *v1alpha1.SemanticVersion {
+	t.Helper()
+
+	out := &v1alpha1.SemanticVersion{}
+	require.NoError(t, out.UnmarshalJSON([]byte(`"`+s+`"`)))
+
+	return out
+}
+
+func TestSemanticVersionCompare(t *testing.T) {
+	t.Parallel()
+
+	// Versions in strictly ascending order, including pre-release ordering
+	// as defined by https://semver.org/#spec-item-11.
+	ordered := []string{
+		"1.0.0-alpha",
+		"1.0.0-alpha.1",
+		"1.0.0-alpha.beta",
+		"1.0.0-beta",
+		"1.0.0-beta.2",
+		"1.0.0-beta.11",
+		"1.0.0-rc.1",
+		"1.0.0",
+		"v1.0.1",
+		"1.2.0",
+		"2.0.0",
+	}
+
+	for i := range ordered {
+		for j := range ordered {
+			a := mustSemanticVersion(t, ordered[i])
+			b := mustSemanticVersion(t, ordered[j])
+
+			require.Equal(t, i < j, a.LessThan(b), ordered[i], ordered[j])
+			require.Equal(t, i <= j, a.LessThanOrEqual(b), ordered[i], ordered[j])
+			require.Equal(t, i > j, a.GreaterThan(b), ordered[i], ordered[j])
+			require.Equal(t, i >= j, a.GreaterThanOrEqual(b), ordered[i], ordered[j])
+		}
+	}
+
+	// Build metadata and the "v" prefix don't affect precedence.
+	require.Zero(t, mustSemanticVersion(t, "v1.0.0+build.1").Compare(mustSemanticVersion(t, "1.0.0")))
+}
+
+func TestSemanticVersionSatisfies(t *testing.T) {
+	t.Parallel()
+
+	version := mustSemanticVersion(t, "1.2.3")
+
+	ok, err := version.Satisfies(">= 1.2.0")
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	ok, err = version.Satisfies("^1.3")
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	ok, err = version.Satisfies("~1.2.0 || >= 3.0.0")
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	// Pre-releases are only matched by constraints that include a pre-release.
+	prerelease := mustSemanticVersion(t, "1.3.0-rc.1")
+
+	ok, err = prerelease.Satisfies(">= 1.2.0")
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	ok, err = prerelease.Satisfies(">= 1.2.0-0")
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	_, err = version.Satisfies("not a constraint")
+	require.Error(t, err)
+}
+
 func TestConstraints(t *testing.T) {
 	t.Parallel()
 
@@ -28,6 +28,8 @@ import (
 	"maps"
 	"net/url"
 	"reflect"
+	"slices"
+	"strconv"
 	"strings"
 
 	argoprojv1 "github.com/unikorn-cloud/core/pkg/apis/argoproj/v1alpha1"
@@ -40,6 +42,7 @@ import (
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/utils/ptr"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -49,6 +52,27 @@ import (
 
 const (
 	namespace = "argocd"
+
+	// defaultProject is the project applications are created in when
+	// not specified.
+	defaultProject = "default"
+
+	// VaultPathAnnotation is used by the argocd-vault-plugin to locate the
+	// secret used to fill in placeholders.
+	VaultPathAnnotation = "avp.kubernetes.io/path"
+
+	// SyncWaveAnnotation is used by ArgoCD to order synchronization of
+	// resources, including applications in an app-of-apps.
+	SyncWaveAnnotation = "argocd.argoproj.io/sync-wave"
+
+	// applicationNameMaxLength bounds application names.  While resource
+	// names may be up to 253 characters, ArgoCD uses the application name
+	// as a tracking label value, which is limited to 63.
+	applicationNameMaxLength = 63
+
+	// applicationNameHashLength is the number of hex characters of the
+	// identifier hash appended to application names.
+	applicationNameHashLength = 16
 )
 
 var (
@@ -60,6 +84,14 @@ var (
 
 type Options struct {
 	K8SAPITester util.K8SAPITester
+
+	// CredentialStore, if set, persists cluster credentials externally.
+	// Cluster secrets then contain a reference to the credentials, and
+	// placeholders in place of the credentials themselves, for the
+	// argocd-vault-plugin to substitute.  Credentials must therefore
+	// be stored with the keys caData, certData and keyData.  By default
+	// credentials are stored in the cluster secret.
+	CredentialStore cd.CredentialStore
 }
 
 // Driver implements a CD driver for ArgoCD.  Applications are fairly
@@ -101,6 +133,42 @@ func clusterName(id *cd.ResourceIdentifier) string {
 	return name
 }
 
+// ApplicationName generates a deterministic application name from an application
+// identifier.  The name is made up of the identifier name, truncated if necessary,
+// and a hash of the name and labels, so identifiers that only differ by their
+// labels, or by characters lost to truncation, do not collide.  The result is
+// always a valid Kubernetes name no longer than 63 characters.
+func ApplicationName(id *cd.ResourceIdentifier) string {
+	labels := slices.Clone(id.Labels)
+
+	slices.SortFunc(labels, func(a, b cd.ResourceIdentifierLabel) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	hasher := sha256.New()
+	hasher.Write([]byte(id.Name))
+
+	for _, label := range labels {
+		// Use a separator that is invalid in both label names and values
+		// so different label sets cannot hash the same input.
+		hasher.Write([]byte("\x00" + label.Name + "=" + label.Value))
+	}
+
+	hash := fmt.Sprintf("%x", hasher.Sum(nil))[:applicationNameHashLength]
+
+	prefix := id.Name
+
+	if maxPrefixLength := applicationNameMaxLength - applicationNameHashLength - 1; len(prefix) > maxPrefixLength {
+		prefix = strings.TrimRight(prefix[:maxPrefixLength], "-.")
+	}
+
+	if prefix == "" {
+		return "application-" + hash
+	}
+
+	return prefix + "-" + hash
+}
+
 // applicationLabels gets a set of labels from an application identifier.
 func applicationLabels(id *cd.ResourceIdentifier) labels.Set {
 	labels := labels.Set{
@@ -151,18 +219,43 @@ func convertApplicationID(in *argoprojv1.Application) *cd.ResourceIdentifier {
 }
 
 func convertApplication(in *argoprojv1.Application) *cd.HelmApplication {
+	source := &in.Spec.Source
+
+	// The primary source is first for multi-source applications.
+	if len(in.Spec.Sources) > 0 {
+		source = &in.Spec.Sources[0]
+	}
+
 	out := &cd.HelmApplication{
-		Repo:  in.Spec.Source.RepoURL,
-		Chart: in.Spec.Source.Chart,
-		Path:  in.Spec.Source.Path,
+		Repo:  source.RepoURL,
+		Chart: source.Chart,
+		Path:  source.Path,
 	}
 
-	if in.Spec.Source.Chart != "" {
-		out.Version = in.Spec.Source.TargetRevision
+	if source.Chart != "" {
+		out.Version = source.TargetRevision
 	}
 
-	if in.Spec.Source.Path != "" {
-		out.Branch = in.Spec.Source.TargetRevision
+	if source.Path != "" {
+		out.Branch = source.TargetRevision
+	}
+
+	if value, ok := in.Annotations[SyncWaveAnnotation]; ok {
+		if wave, err := strconv.Atoi(value); err == nil {
+			out.SyncWave = &wave
+		}
+	}
+
+	if len(in.Spec.Sources) > 1 {
+		for _, s := range in.Spec.Sources[1:] {
+			out.AdditionalSources = append(out.AdditionalSources, cd.ApplicationSource{
+				Repo:    s.RepoURL,
+				Chart:   s.Chart,
+				Path:    s.Path,
+				Version: s.TargetRevision,
+				Ref:     s.Ref,
+			})
+		}
 	}
 
 	return out
@@ -209,6 +302,108 @@ func (d *Driver) GetHealthStatus(ctx context.Context, id *cd.ResourceIdentifier)
 	return cd.HealthStatusHealthy, nil
 }
 
+// convertHealth converts from an ArgoCD health status, returning an explanation
+// when not healthy.
+func convertHealth(in *argoprojv1.ApplicationHealth) (cd.HealthStatus, string) {
+	if in == nil || in.Status == "" || in.Status == argoprojv1.ApplicationHealthStatus(argoprojv1.Unknown) {
+		return cd.HealthStatusUnknown, ""
+	}
+
+	// Not all resources give a message, so fall back to the status e.g.
+	// "Progressing" or "Missing" which is better than nothing.
+	message := in.Message
+	if message == "" {
+		message = string(in.Status)
+	}
+
+	switch in.Status {
+	case argoprojv1.Healthy:
+		return cd.HealthStatusHealthy, ""
+	case argoprojv1.Progressing, argoprojv1.Missing:
+		// Missing resources are yet to be created by a sync, so
+		// are expected to become healthy.
+		return cd.HealthStatusProgressing, message
+	case argoprojv1.Suspended:
+		return cd.HealthStatusSuspended, message
+	}
+
+	return cd.HealthStatusDegraded, message
+}
+
+// GetApplicationHealth returns a summary of the application's health,
+// synchronization status and last operation for diagnostic purposes.
+func (d *Driver) GetApplicationHealth(ctx context.Context, id *cd.ResourceIdentifier) (*cd.ApplicationHealth, error) {
+	application, err := d.GetHelmApplication(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &application.Status
+
+	health, message := convertHealth(status.Health)
+
+	out := &cd.ApplicationHealth{
+		Health:     health,
+		Message:    message,
+		SyncStatus: convertSyncStatus(status.Sync),
+	}
+
+	if len(status.Resources) > 0 {
+		out.Resources = make([]cd.ApplicationResourceHealth, len(status.Resources))
+
+		for i := range status.Resources {
+			resource := &status.Resources[i]
+
+			health, message := convertHealth(resource.Health)
+
+			out.Resources[i] = cd.ApplicationResourceHealth{
+				Group:      resource.Group,
+				Kind:       resource.Kind,
+				Namespace:  resource.Namespace,
+				Name:       resource.Name,
+				SyncStatus: convertSyncStatusCode(resource.Status),
+				Health:     health,
+				Message:    message,
+			}
+		}
+	}
+
+	if operation := status.OperationState; operation != nil {
+		out.LastOperation = &cd.ApplicationOperation{
+			Phase:     string(operation.Phase),
+			Message:   operation.Message,
+			StartedAt: operation.StartedAt.Time,
+		}
+
+		if operation.FinishedAt != nil {
+			out.LastOperation.FinishedAt = &operation.FinishedAt.Time
+		}
+	}
+
+	return out, nil
+}
+
+// convertSyncStatus translates from an ArgoCD sync status to a generic one.
+func convertSyncStatus(in *argoprojv1.ApplicationSync) cd.SyncStatus {
+	if in == nil {
+		return cd.SyncStatusUnknown
+	}
+
+	return convertSyncStatusCode(in.Status)
+}
+
+// convertSyncStatusCode translates from an ArgoCD sync status code to a generic one.
+func convertSyncStatusCode(in argoprojv1.ApplicationSyncStatus) cd.SyncStatus {
+	switch in {
+	case argoprojv1.Synced:
+		return cd.SyncStatusSynced
+	case argoprojv1.OutOfSync:
+		return cd.SyncStatusOutOfSync
+	}
+
+	return cd.SyncStatusUnknown
+}
+
 // ListHelmApplications gets all applications that match the resource identifier.
 func (d *Driver) ListHelmApplications(ctx context.Context, id *cd.ResourceIdentifier) (map[*cd.ResourceIdentifier]*cd.HelmApplication, error) {
 	options := &client.ListOptions{
@@ -277,6 +472,21 @@ func generateApplication(id *cd.ResourceIdentifier, app *cd.HelmApplication) (*a
 		ReleaseName: app.Release,
 		Parameters:  parameters,
 		Values:      values,
+		ValueFiles:  app.ValueFiles,
+		SkipCrds:    app.SkipCRDs,
+	}
+
+	repo, err := cd.CanonicalRepoURL(app.Repo)
+	if err != nil {
+		return nil, err
+	}
+
+	path := app.Path
+
+	if path != "" {
+		if path, err = cd.CanonicalPath(path); err != nil {
+			return nil, err
+		}
 	}
 
 	destinationName := "in-cluster"
@@ -291,18 +501,24 @@ func generateApplication(id *cd.ResourceIdentifier, app *cd.HelmApplication) (*a
 		version = app.Branch
 	}
 
+	project := app.Project
+
+	if project == "" {
+		project = defaultProject
+	}
+
 	application := &argoprojv1.Application{
 		ObjectMeta: metav1.ObjectMeta{
-			GenerateName: id.Name + "-",
-			Namespace:    namespace,
-			Labels:       applicationLabels(id),
+			Name:      ApplicationName(id),
+			Namespace: namespace,
+			Labels:    applicationLabels(id),
 		},
 		Spec: argoprojv1.ApplicationSpec{
-			Project: "default",
+			Project: project,
 			Source: argoprojv1.ApplicationSource{
-				RepoURL:        app.Repo,
+				RepoURL:        repo,
 				Chart:          app.Chart,
-				Path:           app.Path,
+				Path:           path,
 				TargetRevision: version,
 			},
 			Destination: argoprojv1.ApplicationDestination{
@@ -318,10 +534,35 @@ func generateApplication(id *cd.ResourceIdentifier, app *cd.HelmApplication) (*a
 		},
 	}
 
+	if app.SyncWave != nil {
+		application.Annotations = map[string]string{
+			SyncWaveAnnotation: strconv.Itoa(*app.SyncWave),
+		}
+	}
+
 	if !reflect.ValueOf(*helm).IsZero() {
 		application.Spec.Source.Helm = helm
 	}
 
+	// Multi-source applications use the primary source first, followed by
+	// any others e.g. Git repositories referenced by values files.
+	if len(app.AdditionalSources) > 0 {
+		sources := make([]argoprojv1.ApplicationSource, 0, len(app.AdditionalSources)+1)
+		sources = append(sources, application.Spec.Source)
+
+		for i := range app.AdditionalSources {
+			source, err := generateSource(&app.AdditionalSources[i])
+			if err != nil {
+				return nil, err
+			}
+
+			sources = append(sources, *source)
+		}
+
+		application.Spec.Source = argoprojv1.ApplicationSource{}
+		application.Spec.Sources = sources
+	}
+
 	if app.CreateNamespace {
 		application.Spec.SyncPolicy.SyncOptions = append(application.Spec.SyncPolicy.SyncOptions, argoprojv1.CreateNamespace)
 	}
@@ -337,23 +578,82 @@ func generateApplication(id *cd.ResourceIdentifier, app *cd.HelmApplication) (*a
 		application.Spec.SyncPolicy.SyncOptions = append(application.Spec.SyncPolicy.SyncOptions, argoprojv1.ServerSideApply)
 	}
 
+	if app.Retry != nil {
+		application.Spec.SyncPolicy.Retry = generateRetry(app.Retry)
+	}
+
 	for _, field := range app.IgnoreDifferences {
 		application.Spec.IgnoreDifferences = append(application.Spec.IgnoreDifferences, argoprojv1.ApplicationIgnoreDifference{
-			Group:        field.Group,
-			Kind:         field.Kind,
-			JSONPointers: field.JSONPointers,
+			Group:             field.Group,
+			Kind:              field.Kind,
+			JSONPointers:      field.JSONPointers,
+			JQPathExpressions: field.JQPathExpressions,
 		})
 	}
 
 	return application, nil
 }
 
+// generateSource translates an additional source into an ArgoCD source.
+func generateSource(in *cd.ApplicationSource) (*argoprojv1.ApplicationSource, error) {
+	repo, err := cd.CanonicalRepoURL(in.Repo)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &argoprojv1.ApplicationSource{
+		RepoURL:        repo,
+		Chart:          in.Chart,
+		TargetRevision: in.Version,
+		Ref:            in.Ref,
+	}
+
+	if in.Path != "" {
+		if out.Path, err = cd.CanonicalPath(in.Path); err != nil {
+			return nil, err
+		}
+	}
+
+	return out, nil
+}
+
+// generateRetry translates a retry policy into an ArgoCD retry strategy.
+func generateRetry(in *cd.SyncRetry) *argoprojv1.ApplicationSyncRetry {
+	out := &argoprojv1.ApplicationSyncRetry{
+		Limit: int64(in.Limit),
+	}
+
+	backoff := &argoprojv1.ApplicationSyncBackoff{}
+
+	if in.Backoff != 0 {
+		backoff.Duration = in.Backoff.String()
+	}
+
+	if in.BackoffFactor != 0 {
+		backoff.Factor = ptr.To(int64(in.BackoffFactor))
+	}
+
+	if in.MaxBackoff != 0 {
+		backoff.MaxDuration = in.MaxBackoff.String()
+	}
+
+	if *backoff != (argoprojv1.ApplicationSyncBackoff{}) {
+		out.Backoff = backoff
+	}
+
+	return out
+}
+
 // CreateOrUpdateHelmApplication creates or updates a helm application idempotently.
 //
 //nolint:cyclop
 func (d *Driver) CreateOrUpdateHelmApplication(ctx context.Context, id *cd.ResourceIdentifier, app *cd.HelmApplication) error {
 	log := log.FromContext(ctx)
 
+	if err := app.Validate(); err != nil {
+		return err
+	}
+
 	required, err := generateApplication(id, app)
 	if err != nil {
 		return err
@@ -380,6 +680,17 @@ func (d *Driver) CreateOrUpdateHelmApplication(ctx context.Context, id *cd.Resou
 		temp.Labels = required.Labels
 		temp.Spec = required.Spec
 
+		// Preserve any other annotations e.g. those added by ArgoCD.
+		if value, ok := required.Annotations[SyncWaveAnnotation]; ok {
+			if temp.Annotations == nil {
+				temp.Annotations = map[string]string{}
+			}
+
+			temp.Annotations[SyncWaveAnnotation] = value
+		} else {
+			delete(temp.Annotations, SyncWaveAnnotation)
+		}
+
 		if err := d.client.Patch(ctx, temp, client.MergeFrom(resource)); err != nil {
 			return err
 		}
@@ -389,26 +700,25 @@ func (d *Driver) CreateOrUpdateHelmApplication(ctx context.Context, id *cd.Resou
 
 	// Make sure the application is actual synchronized before checking the health.
 	// It can appear healty without being synced apparently.
-	if resource.Status.Sync == nil || resource.Status.Sync.Status != argoprojv1.Synced {
+	if convertSyncStatus(resource.Status.Sync) != cd.SyncStatusSynced {
 		return provisioners.ErrYield
 	}
 
-	if resource.Status.Health == nil {
-		return provisioners.ErrYield
-	}
+	health, _ := convertHealth(resource.Status.Health)
 
-	// Bit of a hack, for clusters, we know they are working and gated on
-	// remote cluster creation, so can allow the rest to provision while it's
-	// still sorting its manager out.
-	if app.AllowDegraded && resource.Status.Health.Status == argoprojv1.Degraded {
+	switch health {
+	case cd.HealthStatusHealthy:
 		return nil
+	case cd.HealthStatusDegraded:
+		// Bit of a hack, for clusters, we know they are working and gated on
+		// remote cluster creation, so can allow the rest to provision while it's
+		// still sorting its manager out.
+		if app.AllowDegraded {
+			return nil
+		}
 	}
 
-	if resource.Status.Health.Status != argoprojv1.Healthy {
-		return provisioners.ErrYield
-	}
-
-	return nil
+	return provisioners.ErrYield
 }
 
 // DeleteHelmApplication deletes an existing helm application.
@@ -474,6 +784,18 @@ type ClusterConfig struct {
 	TLSClientConfig ClusterTLSClientConfig `json:"tlsClientConfig"`
 }
 
+// clusterTLSClientConfigReference is used in place of ClusterTLSClientConfig
+// when credentials are stored externally.
+type clusterTLSClientConfigReference struct {
+	CAData   string `json:"caData"`
+	CertData string `json:"certData"`
+	KeyData  string `json:"keyData"`
+}
+
+type clusterConfigReference struct {
+	TLSClientConfig clusterTLSClientConfigReference `json:"tlsClientConfig"`
+}
+
 // clusterSecretName mirrors what Argo does for compatibility reasons.
 func clusterSecretName(host, prefix string) (string, error) {
 	if prefix == "" {
@@ -530,15 +852,68 @@ func (d *Driver) GetClusterSecret(ctx context.Context, id *cd.ResourceIdentifier
 	return &resources.Items[0], nil
 }
 
-func mustateSecret(current *corev1.Secret, labels map[string]string, data map[string][]byte) func() error {
+func mustateSecret(current *corev1.Secret, labels map[string]string, data map[string][]byte, reference string) func() error {
 	return func() error {
 		current.Labels = labels
 		current.Data = data
 
+		if reference == "" {
+			delete(current.Annotations, VaultPathAnnotation)
+		} else {
+			if current.Annotations == nil {
+				current.Annotations = map[string]string{}
+			}
+
+			current.Annotations[VaultPathAnnotation] = reference
+		}
+
 		return nil
 	}
 }
 
+// clusterConfig returns the cluster configuration to store in the cluster secret.
+// If an external credential store is configured, the credentials are persisted
+// there, and the configuration contains placeholders for the credentials, along
+// with a reference to where they are stored.
+func (d *Driver) clusterConfig(ctx context.Context, id *cd.ResourceIdentifier, credentials *cd.ClusterCredentials) ([]byte, string, error) {
+	if d.options.CredentialStore == nil {
+		config := &ClusterConfig{
+			TLSClientConfig: ClusterTLSClientConfig{
+				CAData:   credentials.CAData,
+				CertData: credentials.CertData,
+				KeyData:  credentials.KeyData,
+			},
+		}
+
+		configData, err := json.Marshal(config)
+		if err != nil {
+			return nil, "", err
+		}
+
+		return configData, "", nil
+	}
+
+	reference, err := d.options.CredentialStore.Store(ctx, id, credentials)
+	if err != nil {
+		return nil, "", err
+	}
+
+	config := &clusterConfigReference{
+		TLSClientConfig: clusterTLSClientConfigReference{
+			CAData:   "<caData>",
+			CertData: "<certData>",
+			KeyData:  "<keyData>",
+		},
+	}
+
+	configData, err := json.Marshal(config)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return configData, reference, nil
+}
+
 // CreateOrUpdateCluster creates or updates a cluster idempotently.
 func (d *Driver) CreateOrUpdateCluster(ctx context.Context, id *cd.ResourceIdentifier, cluster *cd.Cluster) error {
 	log := log.FromContext(ctx)
@@ -591,15 +966,13 @@ func (d *Driver) CreateOrUpdateCluster(ctx context.Context, id *cd.ResourceIdent
 
 	authInfo := cluster.Config.AuthInfos[configContext.AuthInfo]
 
-	config := &ClusterConfig{
-		TLSClientConfig: ClusterTLSClientConfig{
-			CAData:   clusterConfig.CertificateAuthorityData,
-			CertData: authInfo.ClientCertificateData,
-			KeyData:  authInfo.ClientKeyData,
-		},
+	credentials := &cd.ClusterCredentials{
+		CAData:   clusterConfig.CertificateAuthorityData,
+		CertData: authInfo.ClientCertificateData,
+		KeyData:  authInfo.ClientKeyData,
 	}
 
-	configData, err := json.Marshal(config)
+	configData, reference, err := d.clusterConfig(ctx, id, credentials)
 	if err != nil {
 		return err
 	}
@@ -623,7 +996,7 @@ func (d *Driver) CreateOrUpdateCluster(ctx context.Context, id *cd.ResourceIdent
 
 	log.V(1).Info("reconciling cluster", "id", id)
 
-	result, err := controllerutil.CreateOrPatch(ctx, d.client, current, mustateSecret(current, labels, data))
+	result, err := controllerutil.CreateOrPatch(ctx, d.client, current, mustateSecret(current, labels, data, reference))
 	if err != nil {
 		log.V(1).Info("cluster reconcile failed", "error", err)
 
@@ -638,16 +1011,22 @@ func (d *Driver) CreateOrUpdateCluster(ctx context.Context, id *cd.ResourceIdent
 // DeleteCluster deletes an existing cluster.
 func (d *Driver) DeleteCluster(ctx context.Context, id *cd.ResourceIdentifier) error {
 	resource, err := d.GetClusterSecret(ctx, id)
-	if err != nil {
-		if !errors.Is(err, cd.ErrNotFound) {
+	if err != nil && !errors.Is(err, cd.ErrNotFound) {
+		return err
+	}
+
+	if resource != nil {
+		if err := d.client.Delete(ctx, resource); err != nil {
 			return err
 		}
-
-		return nil
 	}
 
-	if err := d.client.Delete(ctx, resource); err != nil {
-		return err
+	// Credentials are deleted last so they aren't removed from under ArgoCD
+	// while still in use, and are cleaned up on a retry if this fails.
+	if d.options.CredentialStore != nil {
+		if err := d.options.CredentialStore.Delete(ctx, id); err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -19,9 +19,12 @@ limitations under the License.
 package argocd_test
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
@@ -35,7 +38,9 @@ import (
 	mockutil "github.com/unikorn-cloud/core/pkg/util/mock"
 
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	"k8s.io/utils/ptr"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -50,15 +55,21 @@ type testContext struct {
 func mustNewTestContext(t *testing.T, tester util.K8SAPITester) *testContext {
 	t.Helper()
 
+	o := argocd.Options{
+		K8SAPITester: tester,
+	}
+
+	return mustNewTestContextWithOptions(t, o)
+}
+
+func mustNewTestContextWithOptions(t *testing.T, o argocd.Options) *testContext {
+	t.Helper()
+
 	scheme, err := coreclient.NewScheme()
 	if err != nil {
 		t.Fatal(err)
 	}
 
-	o := argocd.Options{
-		K8SAPITester: tester,
-	}
-
 	c := fake.NewClientBuilder().WithScheme(scheme).Build()
 
 	tc := &testContext{
@@ -81,7 +92,7 @@ func mustGetApplication(t *testing.T, tc *testContext, id *cd.ResourceIdentifier
 }
 
 const (
-	repo    = "foo"
+	repo    = "https://charts.acme.com"
 	chart   = "bar"
 	version = "baz"
 	branch  = "groot"
@@ -145,6 +156,34 @@ func TestApplicationCreateHelm(t *testing.T) {
 	assert.NoError(t, tc.driver.CreateOrUpdateHelmApplication(t.Context(), id, app))
 }
 
+// TestApplicationCreateInvalid tests invalid applications are rejected.
+func TestApplicationCreateInvalid(t *testing.T) {
+	t.Parallel()
+
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	tester := mockutil.NewMockK8SAPITester(c)
+
+	tc := mustNewTestContext(t, tester)
+
+	id := &cd.ResourceIdentifier{
+		Name: "test",
+	}
+
+	app := &cd.HelmApplication{
+		Repo:    repo,
+		Chart:   chart,
+		Path:    "bar",
+		Version: version,
+	}
+
+	assert.ErrorIs(t, tc.driver.CreateOrUpdateHelmApplication(t.Context(), id, app), cd.ErrInvalidApplication)
+
+	_, err := tc.driver.GetHelmApplication(t.Context(), id)
+	assert.ErrorIs(t, err, cd.ErrNotFound)
+}
+
 // TestApplicationCreateHelmExtended tests that given the requested input the provisioner
 // creates an ArgoCD Application, and the fields are populated as expected.
 func TestApplicationCreateHelmExtended(t *testing.T) {
@@ -236,6 +275,308 @@ func TestApplicationCreateHelmExtended(t *testing.T) {
 	assert.Nil(t, application.Spec.SyncPolicy.ManagedNamespaceMetadata.Annotations)
 }
 
+// TestApplicationCreateSkipCRDs tests that CRD installation can be skipped.
+func TestApplicationCreateSkipCRDs(t *testing.T) {
+	t.Parallel()
+
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	tester := mockutil.NewMockK8SAPITester(c)
+
+	tc := mustNewTestContext(t, tester)
+
+	id := &cd.ResourceIdentifier{
+		Name: "test",
+	}
+
+	app := &cd.HelmApplication{
+		Repo:     repo,
+		Chart:    chart,
+		Version:  version,
+		SkipCRDs: true,
+	}
+
+	assert.ErrorIs(t, tc.driver.CreateOrUpdateHelmApplication(t.Context(), id, app), provisioners.ErrYield)
+
+	application := mustGetApplication(t, tc, id)
+	assert.NotNil(t, application.Spec.Source.Helm)
+	assert.True(t, application.Spec.Source.Helm.SkipCrds)
+}
+
+// TestApplicationCreateProject tests the project propagates, and defaults when not set.
+func TestApplicationCreateProject(t *testing.T) {
+	t.Parallel()
+
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	tester := mockutil.NewMockK8SAPITester(c)
+
+	tc := mustNewTestContext(t, tester)
+
+	id := &cd.ResourceIdentifier{
+		Name: "test",
+	}
+
+	app := &cd.HelmApplication{
+		Repo:    repo,
+		Chart:   chart,
+		Version: version,
+	}
+
+	assert.ErrorIs(t, tc.driver.CreateOrUpdateHelmApplication(t.Context(), id, app), provisioners.ErrYield)
+	assert.Equal(t, "default", mustGetApplication(t, tc, id).Spec.Project)
+
+	app.Project = "tenant"
+
+	assert.ErrorIs(t, tc.driver.CreateOrUpdateHelmApplication(t.Context(), id, app), provisioners.ErrYield)
+	assert.Equal(t, "tenant", mustGetApplication(t, tc, id).Spec.Project)
+}
+
+// TestApplicationCreateSyncWave tests the sync wave annotation is set when
+// specified, and removed when not.
+func TestApplicationCreateSyncWave(t *testing.T) {
+	t.Parallel()
+
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	tester := mockutil.NewMockK8SAPITester(c)
+
+	tc := mustNewTestContext(t, tester)
+
+	id := &cd.ResourceIdentifier{
+		Name: "test",
+	}
+
+	app := &cd.HelmApplication{
+		Repo:    repo,
+		Chart:   chart,
+		Version: version,
+	}
+
+	assert.ErrorIs(t, tc.driver.CreateOrUpdateHelmApplication(t.Context(), id, app), provisioners.ErrYield)
+	assert.NotContains(t, mustGetApplication(t, tc, id).Annotations, argocd.SyncWaveAnnotation)
+
+	app.SyncWave = ptr.To(-1)
+
+	assert.ErrorIs(t, tc.driver.CreateOrUpdateHelmApplication(t.Context(), id, app), provisioners.ErrYield)
+	assert.Equal(t, "-1", mustGetApplication(t, tc, id).Annotations[argocd.SyncWaveAnnotation])
+
+	app.SyncWave = nil
+
+	assert.ErrorIs(t, tc.driver.CreateOrUpdateHelmApplication(t.Context(), id, app), provisioners.ErrYield)
+	assert.NotContains(t, mustGetApplication(t, tc, id).Annotations, argocd.SyncWaveAnnotation)
+}
+
+// TestApplicationCreateRetry tests the sync retry policy is translated, and
+// omitted when not set.
+func TestApplicationCreateRetry(t *testing.T) {
+	t.Parallel()
+
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	tester := mockutil.NewMockK8SAPITester(c)
+
+	tc := mustNewTestContext(t, tester)
+
+	id := &cd.ResourceIdentifier{
+		Name: "test",
+	}
+
+	app := &cd.HelmApplication{
+		Repo:    repo,
+		Chart:   chart,
+		Version: version,
+	}
+
+	assert.ErrorIs(t, tc.driver.CreateOrUpdateHelmApplication(t.Context(), id, app), provisioners.ErrYield)
+	assert.Nil(t, mustGetApplication(t, tc, id).Spec.SyncPolicy.Retry)
+
+	app.Retry = &cd.SyncRetry{
+		Limit:         5,
+		Backoff:       5 * time.Second,
+		BackoffFactor: 2,
+		MaxBackoff:    3 * time.Minute,
+	}
+
+	assert.ErrorIs(t, tc.driver.CreateOrUpdateHelmApplication(t.Context(), id, app), provisioners.ErrYield)
+
+	retry := mustGetApplication(t, tc, id).Spec.SyncPolicy.Retry
+	assert.NotNil(t, retry)
+	assert.Equal(t, int64(5), retry.Limit)
+	assert.NotNil(t, retry.Backoff)
+	assert.Equal(t, "5s", retry.Backoff.Duration)
+	assert.Equal(t, ptr.To(int64(2)), retry.Backoff.Factor)
+	assert.Equal(t, "3m0s", retry.Backoff.MaxDuration)
+}
+
+// TestApplicationCreateIgnoreDifferences tests fields mutated after deployment
+// can be ignored in diffs.
+func TestApplicationCreateIgnoreDifferences(t *testing.T) {
+	t.Parallel()
+
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	tester := mockutil.NewMockK8SAPITester(c)
+
+	tc := mustNewTestContext(t, tester)
+
+	id := &cd.ResourceIdentifier{
+		Name: "test",
+	}
+
+	app := &cd.HelmApplication{
+		Repo:    repo,
+		Chart:   chart,
+		Version: version,
+		IgnoreDifferences: []cd.HelmApplicationField{
+			{
+				Group:        "admissionregistration.k8s.io",
+				Kind:         "ValidatingWebhookConfiguration",
+				JSONPointers: []string{"/webhooks/0/clientConfig/caBundle"},
+			},
+			{
+				Group:             "apps",
+				Kind:              "Deployment",
+				JQPathExpressions: []string{".spec.replicas"},
+			},
+		},
+	}
+
+	assert.ErrorIs(t, tc.driver.CreateOrUpdateHelmApplication(t.Context(), id, app), provisioners.ErrYield)
+
+	differences := mustGetApplication(t, tc, id).Spec.IgnoreDifferences
+	assert.Len(t, differences, 2)
+	assert.Equal(t, "ValidatingWebhookConfiguration", differences[0].Kind)
+	assert.Equal(t, []string{"/webhooks/0/clientConfig/caBundle"}, differences[0].JSONPointers)
+	assert.Nil(t, differences[0].JQPathExpressions)
+	assert.Equal(t, "apps", differences[1].Group)
+	assert.Equal(t, "Deployment", differences[1].Kind)
+	assert.Equal(t, []string{".spec.replicas"}, differences[1].JQPathExpressions)
+}
+
+// TestApplicationCreateMultiSource tests a chart can be sourced from a Helm
+// repository with values from a Git repository, and falls back to a single
+// source when no additional sources are specified.
+func TestApplicationCreateMultiSource(t *testing.T) {
+	t.Parallel()
+
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	tester := mockutil.NewMockK8SAPITester(c)
+
+	tc := mustNewTestContext(t, tester)
+
+	id := &cd.ResourceIdentifier{
+		Name: "test",
+	}
+
+	app := &cd.HelmApplication{
+		Repo:       repo,
+		Chart:      chart,
+		Version:    version,
+		ValueFiles: []string{"$values/charts/foo/values.yaml"},
+		AdditionalSources: []cd.ApplicationSource{
+			{
+				Repo:    "https://github.com/acme/values/",
+				Version: branch,
+				Ref:     "values",
+			},
+		},
+	}
+
+	assert.ErrorIs(t, tc.driver.CreateOrUpdateHelmApplication(t.Context(), id, app), provisioners.ErrYield)
+
+	application := mustGetApplication(t, tc, id)
+	assert.Zero(t, application.Spec.Source)
+	assert.Len(t, application.Spec.Sources, 2)
+	assert.Equal(t, repo, application.Spec.Sources[0].RepoURL)
+	assert.Equal(t, chart, application.Spec.Sources[0].Chart)
+	assert.Equal(t, version, application.Spec.Sources[0].TargetRevision)
+	assert.NotNil(t, application.Spec.Sources[0].Helm)
+	assert.Equal(t, []string{"$values/charts/foo/values.yaml"}, application.Spec.Sources[0].Helm.ValueFiles)
+	assert.Equal(t, "https://github.com/acme/values", application.Spec.Sources[1].RepoURL)
+	assert.Equal(t, branch, application.Spec.Sources[1].TargetRevision)
+	assert.Equal(t, "values", application.Spec.Sources[1].Ref)
+	assert.Nil(t, application.Spec.Sources[1].Helm)
+
+	app.ValueFiles = nil
+	app.AdditionalSources = nil
+
+	assert.ErrorIs(t, tc.driver.CreateOrUpdateHelmApplication(t.Context(), id, app), provisioners.ErrYield)
+
+	application = mustGetApplication(t, tc, id)
+	assert.Nil(t, application.Spec.Sources)
+	assert.Equal(t, repo, application.Spec.Source.RepoURL)
+	assert.Equal(t, chart, application.Spec.Source.Chart)
+}
+
+// TestApplicationCreateMultiSourceUnknownRef tests values files must reference
+// a defined source.
+func TestApplicationCreateMultiSourceUnknownRef(t *testing.T) {
+	t.Parallel()
+
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	tester := mockutil.NewMockK8SAPITester(c)
+
+	tc := mustNewTestContext(t, tester)
+
+	id := &cd.ResourceIdentifier{
+		Name: "test",
+	}
+
+	app := &cd.HelmApplication{
+		Repo:       repo,
+		Chart:      chart,
+		Version:    version,
+		ValueFiles: []string{"$missing/values.yaml"},
+		AdditionalSources: []cd.ApplicationSource{
+			{
+				Repo:    "https://github.com/acme/values",
+				Version: branch,
+				Ref:     "values",
+			},
+		},
+	}
+
+	assert.ErrorIs(t, tc.driver.CreateOrUpdateHelmApplication(t.Context(), id, app), cd.ErrInvalidApplication)
+}
+
+// TestApplicationCreateCanonicalSource tests the application source is normalized.
+func TestApplicationCreateCanonicalSource(t *testing.T) {
+	t.Parallel()
+
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	tester := mockutil.NewMockK8SAPITester(c)
+
+	tc := mustNewTestContext(t, tester)
+
+	id := &cd.ResourceIdentifier{
+		Name: "test",
+	}
+
+	app := &cd.HelmApplication{
+		Repo:   "HTTPS://GitHub.com/acme/foo/",
+		Path:   "./charts/foo/",
+		Branch: branch,
+	}
+
+	assert.ErrorIs(t, tc.driver.CreateOrUpdateHelmApplication(t.Context(), id, app), provisioners.ErrYield)
+
+	application := mustGetApplication(t, tc, id)
+	assert.Equal(t, "https://github.com/acme/foo", application.Spec.Source.RepoURL)
+	assert.Equal(t, "charts/foo", application.Spec.Source.Path)
+}
+
 // TestApplicationCreateGit tests that given the requested input the provisioner
 // creates an ArgoCD Application, and the fields are populated as expected.
 func TestApplicationCreateGit(t *testing.T) {
@@ -394,6 +735,259 @@ func mustGetClusterSecret(t *testing.T, tc *testContext, id *cd.ResourceIdentifi
 	return secret
 }
 
+// TestApplicationHealthStatus tests CD provider health is mapped to a typed
+// health status, and that the same status drives whether provisioning yields,
+// so a progressing application is never mistaken for a degraded one.
+func TestApplicationHealthStatus(t *testing.T) {
+	t.Parallel()
+
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	tester := mockutil.NewMockK8SAPITester(c)
+
+	tc := mustNewTestContext(t, tester)
+
+	id := &cd.ResourceIdentifier{
+		Name: "test",
+	}
+
+	app := &cd.HelmApplication{
+		Repo:          repo,
+		Chart:         chart,
+		Version:       version,
+		AllowDegraded: true,
+	}
+
+	assert.ErrorIs(t, tc.driver.CreateOrUpdateHelmApplication(t.Context(), id, app), provisioners.ErrYield)
+
+	health, err := tc.driver.GetApplicationHealth(t.Context(), id)
+	assert.NoError(t, err)
+	assert.Equal(t, cd.HealthStatusUnknown, health.Health)
+	assert.Equal(t, cd.SyncStatusUnknown, health.SyncStatus)
+
+	tests := []struct {
+		status   argoprojv1.ApplicationHealthStatus
+		expected cd.HealthStatus
+		yield    bool
+	}{
+		{status: argoprojv1.Healthy, expected: cd.HealthStatusHealthy},
+		{status: argoprojv1.Degraded, expected: cd.HealthStatusDegraded},
+		{status: argoprojv1.Progressing, expected: cd.HealthStatusProgressing, yield: true},
+		{status: argoprojv1.Missing, expected: cd.HealthStatusProgressing, yield: true},
+		{status: argoprojv1.Suspended, expected: cd.HealthStatusSuspended, yield: true},
+		{status: argoprojv1.ApplicationHealthStatus(argoprojv1.Unknown), expected: cd.HealthStatusUnknown, yield: true},
+	}
+
+	for _, test := range tests {
+		application := mustGetApplication(t, tc, id)
+		application.Status = argoprojv1.ApplicationStatus{
+			Health: &argoprojv1.ApplicationHealth{
+				Status: test.status,
+			},
+			Sync: &argoprojv1.ApplicationSync{
+				Status: argoprojv1.Synced,
+			},
+		}
+
+		assert.NoError(t, tc.client.Update(t.Context(), application))
+
+		health, err := tc.driver.GetApplicationHealth(t.Context(), id)
+		assert.NoError(t, err)
+		assert.Equal(t, test.expected, health.Health, test.status)
+		assert.Equal(t, cd.SyncStatusSynced, health.SyncStatus)
+
+		err = tc.driver.CreateOrUpdateHelmApplication(t.Context(), id, app)
+
+		if test.yield {
+			assert.ErrorIs(t, err, provisioners.ErrYield, test.status)
+		} else {
+			assert.NoError(t, err, test.status)
+		}
+	}
+}
+
+// TestApplicationHealth tests the application health summary is assembled
+// from the application status.
+func TestApplicationHealth(t *testing.T) {
+	t.Parallel()
+
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	tester := mockutil.NewMockK8SAPITester(c)
+
+	tc := mustNewTestContext(t, tester)
+
+	id := &cd.ResourceIdentifier{
+		Name: "test",
+	}
+
+	_, err := tc.driver.GetApplicationHealth(t.Context(), id)
+	assert.ErrorIs(t, err, cd.ErrNotFound)
+
+	app := &cd.HelmApplication{
+		Repo:    repo,
+		Chart:   chart,
+		Version: version,
+	}
+
+	assert.ErrorIs(t, tc.driver.CreateOrUpdateHelmApplication(t.Context(), id, app), provisioners.ErrYield)
+
+	started := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	finished := started.Add(time.Minute)
+
+	application := mustGetApplication(t, tc, id)
+	application.Status = argoprojv1.ApplicationStatus{
+		Health: &argoprojv1.ApplicationHealth{
+			Status: "Progressing",
+		},
+		Sync: &argoprojv1.ApplicationSync{
+			Status: "OutOfSync",
+		},
+		Resources: []argoprojv1.ApplicationResourceStatus{
+			{
+				Group:     "apps",
+				Version:   "v1",
+				Kind:      "Deployment",
+				Namespace: "default",
+				Name:      "foo",
+				Status:    argoprojv1.Synced,
+				Health: &argoprojv1.ApplicationHealth{
+					Status:  argoprojv1.Degraded,
+					Message: "deployment exceeded its progress deadline",
+				},
+			},
+			{
+				Version: "v1",
+				Kind:    "ConfigMap",
+				Name:    "bar",
+				Status:  argoprojv1.Synced,
+			},
+		},
+		OperationState: &argoprojv1.ApplicationOperationState{
+			Phase:      "Failed",
+			Message:    "one or more objects failed to apply",
+			StartedAt:  metav1.NewTime(started),
+			FinishedAt: ptr.To(metav1.NewTime(finished)),
+		},
+	}
+
+	assert.NoError(t, tc.client.Update(t.Context(), application))
+
+	health, err := tc.driver.GetApplicationHealth(t.Context(), id)
+	assert.NoError(t, err)
+	assert.Equal(t, cd.HealthStatusProgressing, health.Health)
+	assert.Equal(t, "Progressing", health.Message)
+	assert.Equal(t, cd.SyncStatusOutOfSync, health.SyncStatus)
+
+	expected := []cd.ApplicationResourceHealth{
+		{
+			Group:      "apps",
+			Kind:       "Deployment",
+			Namespace:  "default",
+			Name:       "foo",
+			SyncStatus: cd.SyncStatusSynced,
+			Health:     cd.HealthStatusDegraded,
+			Message:    "deployment exceeded its progress deadline",
+		},
+		{
+			Kind:       "ConfigMap",
+			Name:       "bar",
+			SyncStatus: cd.SyncStatusSynced,
+			Health:     cd.HealthStatusUnknown,
+		},
+	}
+
+	assert.Equal(t, expected, health.Resources)
+	assert.NotNil(t, health.LastOperation)
+	assert.Equal(t, "Failed", health.LastOperation.Phase)
+	assert.Equal(t, "one or more objects failed to apply", health.LastOperation.Message)
+	assert.True(t, started.Equal(health.LastOperation.StartedAt))
+	assert.NotNil(t, health.LastOperation.FinishedAt)
+	assert.True(t, finished.Equal(*health.LastOperation.FinishedAt))
+}
+
+// TestApplicationName tests application names are bounded, stable and unique
+// for long identifiers that would otherwise collide after truncation.
+func TestApplicationName(t *testing.T) {
+	t.Parallel()
+
+	id := &cd.ResourceIdentifier{
+		Name: strings.Repeat("a", 300),
+		Labels: []cd.ResourceIdentifierLabel{
+			{
+				Name:  "foo",
+				Value: "bar",
+			},
+			{
+				Name:  "baz",
+				Value: "cat",
+			},
+		},
+	}
+
+	name := argocd.ApplicationName(id)
+	assert.LessOrEqual(t, len(name), 63)
+	assert.Equal(t, name, argocd.ApplicationName(id))
+
+	// Label order must not matter.
+	reordered := &cd.ResourceIdentifier{
+		Name: id.Name,
+		Labels: []cd.ResourceIdentifierLabel{
+			id.Labels[1],
+			id.Labels[0],
+		},
+	}
+
+	assert.Equal(t, name, argocd.ApplicationName(reordered))
+
+	// Names differing beyond the truncation point must not collide.
+	other := &cd.ResourceIdentifier{
+		Name:   strings.Repeat("a", 299) + "b",
+		Labels: id.Labels,
+	}
+
+	assert.NotEqual(t, name, argocd.ApplicationName(other))
+
+	// Nor must identifiers differing only by label.
+	relabeled := &cd.ResourceIdentifier{
+		Name: id.Name,
+		Labels: []cd.ResourceIdentifierLabel{
+			id.Labels[0],
+		},
+	}
+
+	assert.NotEqual(t, name, argocd.ApplicationName(relabeled))
+}
+
+// TestApplicationCreateName tests the driver names applications deterministically.
+func TestApplicationCreateName(t *testing.T) {
+	t.Parallel()
+
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	tester := mockutil.NewMockK8SAPITester(c)
+
+	tc := mustNewTestContext(t, tester)
+
+	id := &cd.ResourceIdentifier{
+		Name: "test",
+	}
+
+	app := &cd.HelmApplication{
+		Repo:    repo,
+		Chart:   chart,
+		Version: version,
+	}
+
+	assert.ErrorIs(t, tc.driver.CreateOrUpdateHelmApplication(t.Context(), id, app), provisioners.ErrYield)
+
+	application := mustGetApplication(t, tc, id)
+	assert.Equal(t, argocd.ApplicationName(id), application.Name)
+}
+
 // TestClusterCreate ensures we can successfully create a new cluster, read it back and
 // the contents are correct.
 func TestClusterCreate(t *testing.T) {
@@ -432,6 +1026,78 @@ func TestClusterCreate(t *testing.T) {
 	assert.Equal(t, clusterClientKey(), config.TLSClientConfig.KeyData)
 }
 
+// fakeCredentialStore stores credentials in memory.
+type fakeCredentialStore struct {
+	credentials map[string]*cd.ClusterCredentials
+}
+
+func (s *fakeCredentialStore) path(id *cd.ResourceIdentifier) string {
+	return "secret/data/clusters/" + id.Name
+}
+
+func (s *fakeCredentialStore) Store(_ context.Context, id *cd.ResourceIdentifier, credentials *cd.ClusterCredentials) (string, error) {
+	s.credentials[s.path(id)] = credentials
+
+	return s.path(id), nil
+}
+
+func (s *fakeCredentialStore) Delete(_ context.Context, id *cd.ResourceIdentifier) error {
+	delete(s.credentials, s.path(id))
+
+	return nil
+}
+
+// TestClusterCreateCredentialStore tests that when an external credential store is
+// used, cluster secrets contain a reference to the credentials, not the credentials.
+func TestClusterCreateCredentialStore(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	tester := mockutil.NewMockK8SAPITester(c)
+
+	store := &fakeCredentialStore{
+		credentials: map[string]*cd.ClusterCredentials{},
+	}
+
+	o := argocd.Options{
+		K8SAPITester:    tester,
+		CredentialStore: store,
+	}
+
+	tc := mustNewTestContextWithOptions(t, o)
+
+	id := &cd.ResourceIdentifier{
+		Name: "test",
+	}
+
+	cluster := &cd.Cluster{
+		Config: getKubeconfig(),
+	}
+
+	tester.EXPECT().Connect(ctx, cluster.Config).Return(nil)
+
+	assert.NoError(t, tc.driver.CreateOrUpdateCluster(ctx, id, cluster))
+
+	secret := mustGetClusterSecret(t, tc, id)
+
+	assert.Equal(t, []byte(clusterServer), secret.Data["server"])
+	assert.Equal(t, "secret/data/clusters/test", secret.Annotations[argocd.VaultPathAnnotation])
+	assert.JSONEq(t, `{"tlsClientConfig":{"caData":"<caData>","certData":"<certData>","keyData":"<keyData>"}}`, string(secret.Data["config"]))
+
+	credentials, ok := store.credentials["secret/data/clusters/test"]
+	assert.True(t, ok)
+	assert.Equal(t, clusterCA(), credentials.CAData)
+	assert.Equal(t, clusterClientCert(), credentials.CertData)
+	assert.Equal(t, clusterClientKey(), credentials.KeyData)
+
+	assert.NoError(t, tc.driver.DeleteCluster(ctx, id))
+	assert.Empty(t, store.credentials)
+}
+
 // TestClusterUpdateAndDelete tests updates are reflected in the cluster e.g. certificate
 // rotation, and deletion does what it's supposed to.
 func TestClusterUpdateAndDelete(t *testing.T) {
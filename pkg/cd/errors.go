@@ -25,4 +25,7 @@ import (
 var (
 	// ErrNotFound is when a resource is not found.
 	ErrNotFound = errors.New("resource not found")
+
+	// ErrInvalidApplication is when an application definition is invalid.
+	ErrInvalidApplication = errors.New("invalid application")
 )
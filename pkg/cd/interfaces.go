@@ -39,10 +39,15 @@ type Driver interface {
 	// referenced by the resource identifier.
 	GetHealthStatus(ctx context.Context, id *ResourceIdentifier) (HealthStatus, error)
 
+	// GetApplicationHealth returns a summary of the application's health,
+	// synchronization status and last operation for diagnostic purposes.
+	GetApplicationHealth(ctx context.Context, id *ResourceIdentifier) (*ApplicationHealth, error)
+
 	// ListHelmApplications gets all applications that match the resource identifier.
 	ListHelmApplications(ctx context.Context, id *ResourceIdentifier) (map[*ResourceIdentifier]*HelmApplication, error)
 
 	// CreateOrUpdateHelmApplication creates or updates a helm application idempotently.
+	// Implementations must reject applications that fail validation.
 	CreateOrUpdateHelmApplication(ctx context.Context, id *ResourceIdentifier, app *HelmApplication) error
 
 	// DeleteHelmApplication deletes an existing helm application.
@@ -54,3 +59,16 @@ type Driver interface {
 	// DeleteCluster deletes an existing cluster.
 	DeleteCluster(ctx context.Context, id *ResourceIdentifier) error
 }
+
+// CredentialStore abstracts the persistence of cluster credentials, allowing
+// them to be held in an external secret manager e.g. Vault, rather than by
+// the CD driver itself.
+type CredentialStore interface {
+	// Store persists the credentials for the cluster idempotently, and returns
+	// a reference to them e.g. a Vault path, that the driver records in place
+	// of the credentials themselves.
+	Store(ctx context.Context, id *ResourceIdentifier, credentials *ClusterCredentials) (string, error)
+
+	// Delete removes any persisted credentials for the cluster.
+	Delete(ctx context.Context, id *ResourceIdentifier) error
+}
@@ -95,6 +95,21 @@ func (mr *MockDriverMockRecorder) DeleteHelmApplication(ctx, id, backgroundDelet
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteHelmApplication", reflect.TypeOf((*MockDriver)(nil).DeleteHelmApplication), ctx, id, backgroundDelete)
 }
 
+// GetApplicationHealth mocks base method.
+func (m *MockDriver) GetApplicationHealth(ctx context.Context, id *cd.ResourceIdentifier) (*cd.ApplicationHealth, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetApplicationHealth", ctx, id)
+	ret0, _ := ret[0].(*cd.ApplicationHealth)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetApplicationHealth indicates an expected call of GetApplicationHealth.
+func (mr *MockDriverMockRecorder) GetApplicationHealth(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetApplicationHealth", reflect.TypeOf((*MockDriver)(nil).GetApplicationHealth), ctx, id)
+}
+
 // GetHealthStatus mocks base method.
 func (m *MockDriver) GetHealthStatus(ctx context.Context, id *cd.ResourceIdentifier) (cd.HealthStatus, error) {
 	m.ctrl.T.Helper()
@@ -138,3 +153,55 @@ func (mr *MockDriverMockRecorder) ListHelmApplications(ctx, id any) *gomock.Call
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListHelmApplications", reflect.TypeOf((*MockDriver)(nil).ListHelmApplications), ctx, id)
 }
+
+// MockCredentialStore is a mock of CredentialStore interface.
+type MockCredentialStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockCredentialStoreMockRecorder
+}
+
+// MockCredentialStoreMockRecorder is the mock recorder for MockCredentialStore.
+type MockCredentialStoreMockRecorder struct {
+	mock *MockCredentialStore
+}
+
+// NewMockCredentialStore creates a new mock instance.
+func NewMockCredentialStore(ctrl *gomock.Controller) *MockCredentialStore {
+	mock := &MockCredentialStore{ctrl: ctrl}
+	mock.recorder = &MockCredentialStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCredentialStore) EXPECT() *MockCredentialStoreMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockCredentialStore) Delete(ctx context.Context, id *cd.ResourceIdentifier) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockCredentialStoreMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCredentialStore)(nil).Delete), ctx, id)
+}
+
+// Store mocks base method.
+func (m *MockCredentialStore) Store(ctx context.Context, id *cd.ResourceIdentifier, credentials *cd.ClusterCredentials) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Store", ctx, id, credentials)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Store indicates an expected call of Store.
+func (mr *MockCredentialStoreMockRecorder) Store(ctx, id, credentials any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Store", reflect.TypeOf((*MockCredentialStore)(nil).Store), ctx, id, credentials)
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2026 Nscale.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cd
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+)
+
+var (
+	// scpLikeRepoRegexp matches SCP like Git repositories e.g.
+	// git@github.com:org/repo.git.
+	scpLikeRepoRegexp = regexp.MustCompile(`^[a-zA-Z0-9._-]+@[a-zA-Z0-9.-]+:[^/]`)
+
+	// refRegexp matches valid source references.
+	refRegexp = regexp.MustCompile(`^[a-zA-Z0-9]([-a-zA-Z0-9_]*[a-zA-Z0-9])?$`)
+
+	// chartRegexp matches valid Helm chart names.
+	chartRegexp = regexp.MustCompile(`^[a-zA-Z0-9]([-a-zA-Z0-9_.]*[a-zA-Z0-9])?$`)
+
+	// defaultPorts are ports that are implied by a URL scheme.
+	//nolint:gochecknoglobals // maps cannot be constant
+	defaultPorts = map[string]string{
+		"http":  "80",
+		"https": "443",
+		"ssh":   "22",
+	}
+)
+
+// CanonicalRepoURL validates a Helm or Git repository URL and returns it in a
+// canonical form.  Trivially different URLs for the same repository would
+// otherwise be seen as distinct by the CD provider and may not match any
+// registered credentials.  The scheme and host are lower cased, default ports
+// and trailing slashes removed.  Plain HTTP is preserved as a repository may
+// not support anything else.  SCP like Git URLs are accepted, as are OCI
+// registries without a scheme.
+func CanonicalRepoURL(repo string) (string, error) {
+	repo = strings.TrimSpace(repo)
+
+	if repo == "" {
+		return "", fmt.Errorf("%w: repo must be specified", ErrInvalidApplication)
+	}
+
+	if scpLikeRepoRegexp.MatchString(repo) {
+		return strings.TrimRight(repo, "/"), nil
+	}
+
+	u, err := url.Parse(repo)
+	if err != nil {
+		return "", fmt.Errorf("%w: repo %q is not a valid URL: %w", ErrInvalidApplication, repo, err)
+	}
+
+	// OCI registries are referred to without a scheme, and are parsed as
+	// a path, so require the first segment to look like a host name.
+	if u.Scheme == "" {
+		host, _, _ := strings.Cut(u.Path, "/")
+
+		if u.Host != "" || !strings.ContainsAny(host, ".:") {
+			return "", fmt.Errorf("%w: repo %q is missing a scheme", ErrInvalidApplication, repo)
+		}
+
+		return strings.ToLower(host) + strings.TrimRight(strings.TrimPrefix(u.Path, host), "/"), nil
+	}
+
+	u.Scheme = strings.ToLower(u.Scheme)
+
+	switch u.Scheme {
+	case "http", "https", "ssh", "git", "oci":
+	default:
+		return "", fmt.Errorf("%w: repo %q has unsupported scheme %q", ErrInvalidApplication, repo, u.Scheme)
+	}
+
+	if u.Host == "" {
+		return "", fmt.Errorf("%w: repo %q is missing a host", ErrInvalidApplication, repo)
+	}
+
+	if _, ok := u.User.Password(); ok {
+		return "", fmt.Errorf("%w: repo %q must not contain credentials", ErrInvalidApplication, repo)
+	}
+
+	if u.RawQuery != "" || u.Fragment != "" {
+		return "", fmt.Errorf("%w: repo %q must not contain a query or fragment", ErrInvalidApplication, repo)
+	}
+
+	host := strings.ToLower(u.Hostname())
+
+	if port := u.Port(); port != "" && port != defaultPorts[u.Scheme] {
+		host += ":" + port
+	}
+
+	u.Host = host
+	u.Path = strings.TrimRight(u.Path, "/")
+	u.RawPath = ""
+
+	return u.String(), nil
+}
+
+// ValidateChart checks a Helm chart name is valid.
+func ValidateChart(chart string) error {
+	if !chartRegexp.MatchString(chart) {
+		return fmt.Errorf("%w: chart %q is not a valid chart name", ErrInvalidApplication, chart)
+	}
+
+	return nil
+}
+
+// CanonicalPath validates a path within a Git repository and returns it in
+// a canonical form.  The path must be relative and not escape the repository.
+func CanonicalPath(p string) (string, error) {
+	if path.IsAbs(p) {
+		return "", fmt.Errorf("%w: path %q must be relative", ErrInvalidApplication, p)
+	}
+
+	p = path.Clean(p)
+
+	if p == ".." || strings.HasPrefix(p, "../") {
+		return "", fmt.Errorf("%w: path %q must not escape the repository", ErrInvalidApplication, p)
+	}
+
+	return p, nil
+}
@@ -19,6 +19,10 @@ limitations under the License.
 package cd
 
 import (
+	"fmt"
+	"strings"
+	"time"
+
 	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 )
 
@@ -76,6 +80,10 @@ type HelmApplicationField struct {
 	Kind string
 
 	JSONPointers []string
+
+	// JQPathExpressions select fields with JQ, for where JSON pointers
+	// cannot express the selection e.g. a field of every list item.
+	JQPathExpressions []string
 }
 
 type LabelsAnnotations struct {
@@ -83,6 +91,100 @@ type LabelsAnnotations struct {
 	Annotations map[string]string
 }
 
+// ApplicationSource is an additional source for a multi-source application.
+type ApplicationSource struct {
+	// Repo is a URL to either a Helm or Git repository.
+	Repo string
+
+	// Chart is used when using a Helm repository.
+	Chart string
+
+	// Path is used when using a Git repository.
+	Path string
+
+	// Version is either the Helm chart version, or a Git branch, tag or
+	// hash.
+	Version string
+
+	// Ref names the source so its files can be referenced by value files
+	// e.g. a Ref of "values" allows "$values/path/to/values.yaml".
+	Ref string
+}
+
+// Validate checks the source is valid.
+func (s *ApplicationSource) Validate() error {
+	if _, err := CanonicalRepoURL(s.Repo); err != nil {
+		return err
+	}
+
+	if s.Version == "" {
+		return fmt.Errorf("%w: source %s requires a version", ErrInvalidApplication, s.Repo)
+	}
+
+	if s.Chart != "" && s.Path != "" {
+		return fmt.Errorf("%w: source %s chart and path are mutually exclusive", ErrInvalidApplication, s.Repo)
+	}
+
+	if s.Chart == "" && s.Path == "" && s.Ref == "" {
+		return fmt.Errorf("%w: source %s requires a chart, path or ref", ErrInvalidApplication, s.Repo)
+	}
+
+	if s.Chart != "" {
+		if err := ValidateChart(s.Chart); err != nil {
+			return err
+		}
+	}
+
+	if s.Path != "" {
+		if _, err := CanonicalPath(s.Path); err != nil {
+			return err
+		}
+	}
+
+	if s.Ref != "" && !refRegexp.MatchString(s.Ref) {
+		return fmt.Errorf("%w: source ref %q is invalid", ErrInvalidApplication, s.Ref)
+	}
+
+	return nil
+}
+
+// validateSources checks any additional sources are valid, and any value files
+// reference sources that exist.
+func (a *HelmApplication) validateSources() error {
+	refs := map[string]bool{}
+
+	for i := range a.AdditionalSources {
+		source := &a.AdditionalSources[i]
+
+		if err := source.Validate(); err != nil {
+			return err
+		}
+
+		if source.Ref != "" {
+			if refs[source.Ref] {
+				return fmt.Errorf("%w: source ref %q is not unique", ErrInvalidApplication, source.Ref)
+			}
+
+			refs[source.Ref] = true
+		}
+	}
+
+	for _, file := range a.ValueFiles {
+		ref, ok := strings.CutPrefix(file, "$")
+		if !ok {
+			continue
+		}
+
+		ref, _, _ = strings.Cut(ref, "/")
+
+		if !refs[ref] {
+			return fmt.Errorf("%w: values file %q references unknown source %q", ErrInvalidApplication, file, ref)
+		}
+	}
+
+	return nil
+}
+
 // HelmApplication defines a driver agnostic Helm application.
 type HelmApplication struct {
 	// Repo is a URL to either a Helm or Git repository.
@@ -113,12 +215,27 @@ type HelmApplication struct {
 	// just throw in a free-form map[string]any thing.
 	Values any
 
+	// ValueFiles are values files passed to Helm.  These may reference files
+	// in additional sources e.g. "$values/clusters/foo.yaml" where "values"
+	// is the additional source's Ref.
+	ValueFiles []string
+
+	// AdditionalSources, if set, create a multi-source application e.g.
+	// to source the chart from a Helm repository and its values files from
+	// a Git repository.
+	AdditionalSources []ApplicationSource
+
 	// Cluster identifies the cluster to install on to.
 	// By definition we require the CD provider to support multiple
 	// clusters to support cluster manager lane virtual clusters, and the
 	// CAPI Kubernetes clusters we create.
 	Cluster *ResourceIdentifier
 
+	// Project is the CD provider project the application belongs to, this
+	// allows access controls and source/destination restrictions to be
+	// applied per tenant.  Defaults to the provider's default project.
+	Project string
+
 	// Namespace identifies the namespace to install in to.
 	Namespace string
 
@@ -141,11 +258,105 @@ type HelmApplication struct {
 	// argo can be moved to using it by default.
 	ServerSideApply bool
 
+	// SkipCRDs stops the CD provider installing any CRDs bundled with
+	// the chart.  This is for charts whose CRDs must be installed once,
+	// and are managed out of band, as reapplying them on every sync may
+	// cause conflicts or wipe their status.
+	SkipCRDs bool
+
+	// Retry, if set, retries failed synchronizations e.g. when a webhook
+	// is not yet ready, rather than waiting for the next self-heal.
+	Retry *SyncRetry
+
+	// SyncWave, if set, orders synchronization of the application relative
+	// to others e.g. when provisioning an app-of-apps where dependencies must
+	// be installed first.  Lower waves are synchronized first.
+	SyncWave *int
+
 	// AllowDegraded allows us to tolerate degraded state and allow a success
 	// to be reported rather than a failure.
 	AllowDegraded bool
 }
 
+// SyncRetry defines how failed synchronizations are retried.
+type SyncRetry struct {
+	// Limit is the maximum number of attempts, a negative value means
+	// unlimited.
+	Limit int
+
+	// Backoff is the initial time between attempts.
+	Backoff time.Duration
+
+	// BackoffFactor multiplies the backoff after each attempt.
+	BackoffFactor int
+
+	// MaxBackoff is the maximum time between attempts.
+	MaxBackoff time.Duration
+}
+
+// Validate checks the retry policy is valid.
+func (r *SyncRetry) Validate() error {
+	if r.Backoff < 0 || r.MaxBackoff < 0 || r.BackoffFactor < 0 {
+		return fmt.Errorf("%w: retry backoff must not be negative", ErrInvalidApplication)
+	}
+
+	if r.MaxBackoff != 0 && r.MaxBackoff < r.Backoff {
+		return fmt.Errorf("%w: retry maximum backoff must not be less than the backoff", ErrInvalidApplication)
+	}
+
+	return nil
+}
+
+// Validate checks the application source is consistent, either a Helm
+// repository with a chart and version, or a Git repository with a path
+// and a branch or version, otherwise a driver may silently pick the wrong
+// fields.  The repository URL, chart and path must also be well formed.
+func (a *HelmApplication) Validate() error {
+	if _, err := CanonicalRepoURL(a.Repo); err != nil {
+		return err
+	}
+
+	if a.Retry != nil {
+		if err := a.Retry.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if err := a.validateSources(); err != nil {
+		return err
+	}
+
+	if a.Chart != "" && a.Path != "" {
+		return fmt.Errorf("%w: chart and path are mutually exclusive", ErrInvalidApplication)
+	}
+
+	if a.Chart != "" {
+		if a.Version == "" {
+			return fmt.Errorf("%w: chart %s requires a version", ErrInvalidApplication, a.Chart)
+		}
+
+		if a.Branch != "" {
+			return fmt.Errorf("%w: chart %s cannot specify a branch", ErrInvalidApplication, a.Chart)
+		}
+
+		return ValidateChart(a.Chart)
+	}
+
+	if a.Path != "" {
+		if a.Branch == "" && a.Version == "" {
+			return fmt.Errorf("%w: path %s requires a branch or version", ErrInvalidApplication, a.Path)
+		}
+
+		if _, err := CanonicalPath(a.Path); err != nil {
+			return err
+		}
+
+		return nil
+	}
+
+	return fmt.Errorf("%w: either chart or path must be specified", ErrInvalidApplication)
+}
+
 // Cluster identifies a Kubernetes cluster and allows a CD driver to
 // access it for management.
 type Cluster struct {
@@ -157,6 +368,16 @@ type Cluster struct {
 	Prefix string
 }
 
+// ClusterCredentials are the credentials used to access a cluster.
+type ClusterCredentials struct {
+	// CAData is the PEM encoded CA certificate of the cluster.
+	CAData []byte
+	// CertData is the PEM encoded client certificate.
+	CertData []byte
+	// KeyData is the PEM encoded client private key.
+	KeyData []byte
+}
+
 // HealthStatus is used to describe the health of the application.
 type HealthStatus string
 
@@ -168,4 +389,71 @@ const (
 	// HealthStatusDegraded means the application may still function
 	// but is in a degraded state.
 	HealthStatusDegraded HealthStatus = "degraded"
+	// HealthStatusProgressing means the application is not yet healthy
+	// but is expected to become so e.g. a deployment is scaling up.
+	HealthStatusProgressing HealthStatus = "progressing"
+	// HealthStatusSuspended means the application is deliberately paused.
+	HealthStatusSuspended HealthStatus = "suspended"
+)
+
+// SyncStatus is used to describe whether the application is synchronized.
+type SyncStatus string
+
+const (
+	// SyncStatusUnknown means the synchronization status cannot be derived.
+	SyncStatusUnknown SyncStatus = "unknown"
+	// SyncStatusSynced means the application matches the desired state.
+	SyncStatusSynced SyncStatus = "synced"
+	// SyncStatusOutOfSync means the application differs from the desired state.
+	SyncStatusOutOfSync SyncStatus = "outOfSync"
 )
+
+// ApplicationHealth is a summary of an application's state, intended to
+// aid diagnosis of an application that will not become healthy, and allow
+// provisioners to distinguish between an application that is still progressing
+// and one that is degraded.
+type ApplicationHealth struct {
+	// Health is the overall health of the application.
+	Health HealthStatus
+	// Message explains the health status, if not healthy.
+	Message string
+	// SyncStatus is the application's synchronization status.
+	SyncStatus SyncStatus
+	// Resources is the health of each resource managed by the application.
+	Resources []ApplicationResourceHealth
+	// LastOperation is the last operation the CD provider performed, if any.
+	LastOperation *ApplicationOperation
+}
+
+// ApplicationResourceHealth is the health of a resource managed by an
+// application.
+type ApplicationResourceHealth struct {
+	// Group is the resource API group.
+	Group string
+	// Kind is the resource kind.
+	Kind string
+	// Namespace is the resource namespace, if namespaced.
+	Namespace string
+	// Name is the resource name.
+	Name string
+	// SyncStatus is the resource's synchronization status.
+	SyncStatus SyncStatus
+	// Health is the resource's health.
+	Health HealthStatus
+	// Message explains the health status, if not healthy.
+	Message string
+}
+
+// ApplicationOperation describes an operation e.g. a sync, performed
+// by the CD provider.
+type ApplicationOperation struct {
+	// Phase is the CD provider's phase of the operation.
+	Phase string
+	// Message is the CD provider's message about the operation, this will
+	// typically contain any errors.
+	Message string
+	// StartedAt is when the operation started.
+	StartedAt time.Time
+	// FinishedAt is when the operation finished, if it has.
+	FinishedAt *time.Time
+}
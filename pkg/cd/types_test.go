@@ -0,0 +1,141 @@
+/*
+Copyright 2026 Nscale.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cd_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/unikorn-cloud/core/pkg/cd"
+)
+
+// TestHelmApplicationValidate tests valid application sources are accepted.
+func TestHelmApplicationValidate(t *testing.T) {
+	t.Parallel()
+
+	valid := []*cd.HelmApplication{
+		{
+			Repo:    "https://charts.acme.com",
+			Chart:   "foo",
+			Version: "1.0.0",
+		},
+		{
+			Repo:   "https://github.com/acme/foo",
+			Path:   "charts/foo",
+			Branch: "main",
+		},
+		{
+			Repo:    "https://github.com/acme/foo",
+			Path:    "charts/foo",
+			Version: "v1.0.0",
+		},
+	}
+
+	for _, app := range valid {
+		require.NoError(t, app.Validate())
+	}
+}
+
+// TestHelmApplicationValidateInvalid tests contradictory application sources are rejected.
+func TestHelmApplicationValidateInvalid(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		app  *cd.HelmApplication
+	}{
+		{
+			name: "NoRepo",
+			app: &cd.HelmApplication{
+				Chart:   "foo",
+				Version: "1.0.0",
+			},
+		},
+		{
+			name: "NoSource",
+			app: &cd.HelmApplication{
+				Repo:    "https://charts.acme.com",
+				Version: "1.0.0",
+			},
+		},
+		{
+			name: "ChartAndPath",
+			app: &cd.HelmApplication{
+				Repo:    "https://charts.acme.com",
+				Chart:   "foo",
+				Path:    "charts/foo",
+				Version: "1.0.0",
+			},
+		},
+		{
+			name: "ChartWithoutVersion",
+			app: &cd.HelmApplication{
+				Repo:  "https://charts.acme.com",
+				Chart: "foo",
+			},
+		},
+		{
+			name: "ChartWithBranch",
+			app: &cd.HelmApplication{
+				Repo:    "https://charts.acme.com",
+				Chart:   "foo",
+				Version: "1.0.0",
+				Branch:  "main",
+			},
+		},
+		{
+			name: "PathWithoutRevision",
+			app: &cd.HelmApplication{
+				Repo: "https://github.com/acme/foo",
+				Path: "charts/foo",
+			},
+		},
+		{
+			name: "NegativeRetryBackoff",
+			app: &cd.HelmApplication{
+				Repo:    "https://charts.acme.com",
+				Chart:   "foo",
+				Version: "1.0.0",
+				Retry: &cd.SyncRetry{
+					Backoff: -time.Second,
+				},
+			},
+		},
+		{
+			name: "RetryMaxBackoffTooSmall",
+			app: &cd.HelmApplication{
+				Repo:    "https://charts.acme.com",
+				Chart:   "foo",
+				Version: "1.0.0",
+				Retry: &cd.SyncRetry{
+					Backoff:    time.Minute,
+					MaxBackoff: time.Second,
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.ErrorIs(t, test.app.Validate(), cd.ErrInvalidApplication)
+		})
+	}
+}
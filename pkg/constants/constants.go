@@ -59,6 +59,20 @@ const (
 	// like functionality.
 	ModifiedTimestampAnnotation = "unikorn-cloud.org/modifiedTimestamp"
 
+	// TraceParentAnnotation and TraceStateAnnotation optionally record the W3C
+	// trace context of the request that last modified a resource, so
+	// asynchronous work triggered by it, e.g. cascading deletion, can be
+	// correlated with the originating request.
+	TraceParentAnnotation = "unikorn-cloud.org/traceparent"
+	TraceStateAnnotation  = "unikorn-cloud.org/tracestate"
+
+	// SkipProvisionersAnnotation is optionally attached to a resource to skip
+	// named provisioning steps, as a comma separated list, while still
+	// reconciling everything else.  This allows targeted debugging e.g. manual
+	// modification of an application without the whole resource being paused.
+	// Deprovisioning is never skipped, otherwise resources would be orphaned.
+	SkipProvisionersAnnotation = "unikorn-cloud.org/skip-provisioners"
+
 	// KindLabel is used to match a resource that may be owned by a particular kind.
 	// For example, projects and cluster managers are modelled on namespaces.  For CPs
 	// you have to select based on project and CP name, because of name reuse, but
@@ -162,6 +176,10 @@ const (
 	// and report a healthy status before yielding and giving someone else
 	// a go.
 	DefaultYieldTimeout = 10 * time.Second
+
+	// DefaultErrorBackoffMax caps how long a persistently failing provisioner
+	// will back off for before retrying.
+	DefaultErrorBackoffMax = 5 * time.Minute
 )
 
 // LabelPriorities assigns a priority to the labels for sorting.  Most things
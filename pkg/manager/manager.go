@@ -21,6 +21,7 @@ package manager
 import (
 	"context"
 	"os"
+	"time"
 
 	"github.com/spf13/pflag"
 
@@ -34,6 +35,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/manager/signals"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
@@ -43,6 +45,34 @@ type ControllerOptions interface {
 	AddFlags(f *pflag.FlagSet)
 }
 
+// ControllerFinalizers may be implemented by ControllerOptions when a controller
+// manages more than the default finalizer e.g. to block deletion until billing
+// has been finalized.
+type ControllerFinalizers interface {
+	// Finalizers returns an ordered list of finalizers added to resources on
+	// creation.  The first is removed by the reconciler to complete deletion,
+	// only once all others have been removed by the provisioner's Deprovision.
+	Finalizers() []string
+}
+
+// ControllerYieldTimeout may be implemented by ControllerOptions when a controller
+// wants yielding reconciles requeued sooner or later than the default e.g. a slow
+// provider may want longer, a fast one shorter.
+type ControllerYieldTimeout interface {
+	// YieldTimeout returns how long to wait before requeuing a yielding
+	// reconcile.  A zero value uses constants.DefaultYieldTimeout.
+	YieldTimeout() time.Duration
+}
+
+// ControllerErrorBackoff may be implemented by ControllerOptions when a controller
+// wants to tune how quickly resources that fail provisioning are retried.
+type ControllerErrorBackoff interface {
+	// ErrorBackoff returns the initial requeue period after a failure, which
+	// doubles with every consecutive failure, and the maximum it is capped at.
+	// Zero values use the yield timeout and constants.DefaultErrorBackoffMax.
+	ErrorBackoff() (base, maximum time.Duration)
+}
+
 // ControllerFactory allows creation of a Unikorn controller with
 // minimal code.
 type ControllerFactory interface {
@@ -109,6 +139,10 @@ func getManager(f ControllerFactory) (manager.Manager, error) {
 		return nil, err
 	}
 
+	if err := registerMetrics(metrics.Registry, service.Name); err != nil {
+		return nil, err
+	}
+
 	return manager, nil
 }
 
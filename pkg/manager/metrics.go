@@ -0,0 +1,99 @@
+/*
+Copyright 2026 Nscale.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manager
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/unikorn-cloud/core/pkg/provisioners"
+)
+
+// reconcileOutcome classifies how a reconcile ended.
+type reconcileOutcome string
+
+const (
+	// outcomeProvisioned means the resource was successfully provisioned.
+	outcomeProvisioned reconcileOutcome = "provisioned"
+	// outcomeYielded means the (de)provisioner yielded and will be requeued.
+	outcomeYielded reconcileOutcome = "yielded"
+	// outcomeErrored means the reconcile failed.
+	outcomeErrored reconcileOutcome = "errored"
+	// outcomeCancelled means the reconcile was cancelled e.g. on shutdown.
+	outcomeCancelled reconcileOutcome = "cancelled"
+	// outcomeDeleted means the resource was deprovisioned, or already gone.
+	outcomeDeleted reconcileOutcome = "deleted"
+	// outcomePaused means reconciliation of the resource is paused.
+	outcomePaused reconcileOutcome = "paused"
+)
+
+//nolint:gochecknoglobals // collectors are registered once per process
+var (
+	// reconcileTotal counts reconciles by outcome.
+	reconcileTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "unikorn_reconcile_total",
+		Help: "Total number of reconciles by outcome.",
+	}, []string{"outcome"})
+
+	// reconcileDuration records the wall clock time of reconciles by outcome.
+	reconcileDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "unikorn_reconcile_duration_seconds",
+		Help:    "Wall clock time of reconciles by outcome.",
+		Buckets: prometheus.ExponentialBuckets(0.01, 2, 14),
+	}, []string{"outcome"})
+)
+
+// registerMetrics registers reconcile metrics, labelled with the controller name
+// so metrics from different controllers can be distinguished once aggregated.
+func registerMetrics(registerer prometheus.Registerer, controller string) error {
+	registerer = prometheus.WrapRegistererWith(prometheus.Labels{"controller": controller}, registerer)
+
+	for _, collector := range []prometheus.Collector{reconcileTotal, reconcileDuration} {
+		if err := registerer.Register(collector); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// provisionOutcome classifies the result of a (de)provision.
+func provisionOutcome(err error, deprovision bool) reconcileOutcome {
+	switch {
+	case err == nil:
+		if deprovision {
+			return outcomeDeleted
+		}
+
+		return outcomeProvisioned
+	case errors.Is(err, provisioners.ErrYield):
+		return outcomeYielded
+	case errors.Is(err, context.Canceled):
+		return outcomeCancelled
+	}
+
+	return outcomeErrored
+}
+
+// observeReconcile records the outcome and duration of a reconcile.
+func observeReconcile(outcome reconcileOutcome, duration time.Duration) {
+	reconcileTotal.WithLabelValues(string(outcome)).Inc()
+	reconcileDuration.WithLabelValues(string(outcome)).Observe(duration.Seconds())
+}
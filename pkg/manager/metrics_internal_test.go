@@ -0,0 +1,77 @@
+/*
+Copyright 2026 Nscale.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/unikorn-cloud/core/pkg/provisioners"
+)
+
+var errTest = errors.New("test error")
+
+// TestProvisionOutcome tests (de)provisioning results are classified correctly.
+func TestProvisionOutcome(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, outcomeProvisioned, provisionOutcome(nil, false))
+	require.Equal(t, outcomeDeleted, provisionOutcome(nil, true))
+	require.Equal(t, outcomeYielded, provisionOutcome(fmt.Errorf("%w: waiting", provisioners.ErrYield), false))
+	require.Equal(t, outcomeCancelled, provisionOutcome(context.Canceled, false))
+	require.Equal(t, outcomeErrored, provisionOutcome(errTest, true))
+}
+
+// TestRegisterMetrics tests reconciles are recorded and labelled with the controller.
+//
+//nolint:paralleltest // collectors are shared with other tests
+func TestRegisterMetrics(t *testing.T) {
+	registry := prometheus.NewRegistry()
+
+	require.NoError(t, registerMetrics(registry, "test-controller"))
+
+	observeReconcile(outcomeYielded, time.Second)
+
+	families, err := registry.Gather()
+	require.NoError(t, err)
+
+	names := map[string]bool{}
+
+	for _, family := range families {
+		names[family.GetName()] = true
+
+		for _, metric := range family.GetMetric() {
+			labels := map[string]string{}
+
+			for _, label := range metric.GetLabel() {
+				labels[label.GetName()] = label.GetValue()
+			}
+
+			require.Equal(t, "test-controller", labels["controller"])
+			require.NotEmpty(t, labels["outcome"])
+		}
+	}
+
+	require.True(t, names["unikorn_reconcile_total"])
+	require.True(t, names["unikorn_reconcile_duration_seconds"])
+}
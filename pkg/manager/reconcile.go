@@ -21,6 +21,16 @@ package manager
 import (
 	"context"
 	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.22.0"
+	"go.opentelemetry.io/otel/trace"
 
 	unikornv1 "github.com/unikorn-cloud/core/pkg/apis/unikorn/v1alpha1"
 	"github.com/unikorn-cloud/core/pkg/cd"
@@ -36,6 +46,8 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/log"
@@ -49,6 +61,16 @@ var (
 	ErrResourceError = errors.New("unable to assert resource type")
 )
 
+const (
+	// EventRecorderName is the conventional component events are attributed to
+	// e.g. manager.GetEventRecorderFor(EventRecorderName).
+	EventRecorderName = "unikorn-manager"
+
+	// eventReasonCancelled is the event reason when a reconcile is cancelled,
+	// there is no corresponding condition reason as the condition is left as is.
+	eventReasonCancelled = "Cancelled"
+)
+
 // ProvisionerCreateFunc provides a type agnosic method to create a root provisioner.
 type ProvisionerCreateFunc func(ControllerOptions) provisioners.ManagerProvisioner
 
@@ -65,16 +87,50 @@ type Reconciler struct {
 
 	// controllerOptions are options to be passed to the reconciler.
 	controllerOptions ControllerOptions
+
+	// recorder emits events against managed resources, may be nil.
+	recorder record.EventRecorder
+
+	// failures records consecutive provisioning failures per resource
+	// so persistent failures back off.
+	failures map[types.NamespacedName]int
+
+	// failuresLock guards failures.
+	failuresLock sync.Mutex
+}
+
+// ReconcilerOption allows optional reconciler behavior to be enabled.
+type ReconcilerOption func(*Reconciler)
+
+// WithEventRecorder overrides the manager's event recorder used to record
+// provisioning transitions against the managed resource.  A nil recorder
+// disables events.
+func WithEventRecorder(recorder record.EventRecorder) ReconcilerOption {
+	return func(r *Reconciler) {
+		r.recorder = recorder
+	}
 }
 
-// NewReconciler creates a new reconciler.
-func NewReconciler(options *options.Options, controllerOptions ControllerOptions, manager manager.Manager, createProvisioner ProvisionerCreateFunc) *Reconciler {
-	return &Reconciler{
+// NewReconciler creates a new reconciler.  Events are recorded with the manager's
+// event recorder unless overridden.
+func NewReconciler(options *options.Options, controllerOptions ControllerOptions, manager manager.Manager, createProvisioner ProvisionerCreateFunc, reconcilerOptions ...ReconcilerOption) *Reconciler {
+	r := &Reconciler{
 		options:           options,
 		manager:           manager,
 		createProvisioner: createProvisioner,
 		controllerOptions: controllerOptions,
+		failures:          map[types.NamespacedName]int{},
 	}
+
+	if manager != nil {
+		r.recorder = manager.GetEventRecorderFor(EventRecorderName)
+	}
+
+	for _, o := range reconcilerOptions {
+		o(r)
+	}
+
+	return r
 }
 
 // Ensure this implements the reconcile.Reconciler interface.
@@ -88,10 +144,119 @@ func (r *Reconciler) getDriver() (cd.Driver, error) {
 	return argocd.New(r.manager.GetClient(), argocd.Options{}), nil
 }
 
+// yieldTimeout returns how long to wait before requeuing a yielding reconcile.
+func (r *Reconciler) yieldTimeout() time.Duration {
+	if o, ok := r.controllerOptions.(ControllerYieldTimeout); ok {
+		if timeout := o.YieldTimeout(); timeout != 0 {
+			return timeout
+		}
+	}
+
+	return constants.DefaultYieldTimeout
+}
+
+// finalizers returns the ordered list of finalizers managed by the controller.
+func (r *Reconciler) finalizers() []string {
+	if o, ok := r.controllerOptions.(ControllerFinalizers); ok {
+		if finalizers := o.Finalizers(); len(finalizers) > 0 {
+			return finalizers
+		}
+	}
+
+	return []string{constants.Finalizer}
+}
+
+// pendingFinalizers returns any finalizers the provisioner has yet to remove
+// before the reconciler can remove its own.
+func (r *Reconciler) pendingFinalizers(object unikornv1.ManagableResourceInterface) []string {
+	var pending []string
+
+	for _, finalizer := range r.finalizers()[1:] {
+		if controllerutil.ContainsFinalizer(object, finalizer) {
+			pending = append(pending, finalizer)
+		}
+	}
+
+	return pending
+}
+
+// errorBackoff records a provisioning failure and returns how long to wait
+// before retrying, doubling with each consecutive failure up to a limit.
+func (r *Reconciler) errorBackoff(key types.NamespacedName) time.Duration {
+	r.failuresLock.Lock()
+	defer r.failuresLock.Unlock()
+
+	r.failures[key]++
+
+	var backoff, maximum time.Duration
+
+	if o, ok := r.controllerOptions.(ControllerErrorBackoff); ok {
+		backoff, maximum = o.ErrorBackoff()
+	}
+
+	if backoff == 0 {
+		backoff = r.yieldTimeout()
+	}
+
+	if maximum == 0 {
+		maximum = constants.DefaultErrorBackoffMax
+	}
+
+	for i := 1; i < r.failures[key] && backoff < maximum; i++ {
+		backoff *= 2
+	}
+
+	return min(backoff, maximum)
+}
+
+// resetErrorBackoff forgets any provisioning failures.
+func (r *Reconciler) resetErrorBackoff(key types.NamespacedName) {
+	r.failuresLock.Lock()
+	defer r.failuresLock.Unlock()
+
+	delete(r.failures, key)
+}
+
+// recordEvent emits an event against the resource if we have a recorder.
+func (r *Reconciler) recordEvent(object unikornv1.ManagableResourceInterface, eventType, reason, message string) {
+	if r.recorder == nil {
+		return
+	}
+
+	r.recorder.Event(object, eventType, reason, message)
+}
+
 // Reconcile is the top-level reconcile interface that controller-runtime will
-// dispatch to.  It initialises the provisioner, extracts the request object and
-// based on whether it exists or not, reconciles or deletes the object respectively.
+// dispatch to.  It traces and records metrics for each reconcile.
 func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	// Each reconcile gets its own span, annotated with events at key milestones
+	// so it's obvious where time was spent and why the reconcile yielded.  This
+	// will be a child of any trace already in the context.
+	attr := []attribute.KeyValue{
+		semconv.K8SNamespaceName(request.Namespace),
+		attribute.String("k8s.resource.name", request.Name),
+	}
+
+	tracer := otel.GetTracerProvider().Tracer("unikorn reconciler")
+
+	ctx, span := tracer.Start(ctx, "reconcile", trace.WithAttributes(attr...))
+	defer span.End()
+
+	start := time.Now()
+
+	result, outcome, err := r.doReconcile(ctx, request)
+
+	observeReconcile(outcome, time.Since(start))
+
+	return result, err
+}
+
+// doReconcile initialises the provisioner, extracts the request object and
+// based on whether it exists or not, reconciles or deletes the object respectively.
+// The outcome is returned for metrics.
+//
+//nolint:cyclop
+func (r *Reconciler) doReconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, reconcileOutcome, error) {
 	log := log.FromContext(ctx)
 
 	provisioner := r.createProvisioner(r.controllerOptions)
@@ -100,7 +265,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (
 
 	driver, err := r.getDriver()
 	if err != nil {
-		return reconcile.Result{}, err
+		return reconcile.Result{}, outcomeErrored, err
 	}
 
 	// Add the manager to grant access to eventing.
@@ -134,17 +299,19 @@ func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (
 		if kerrors.IsNotFound(err) {
 			log.Info("object deleted")
 
-			return reconcile.Result{}, nil
+			r.resetErrorBackoff(request.NamespacedName)
+
+			return reconcile.Result{}, outcomeDeleted, nil
 		}
 
-		return reconcile.Result{}, err
+		return reconcile.Result{}, outcomeErrored, err
 	}
 
 	// If it's being deleted, ignore if there are no finalizers, Kubernetes is in
 	// charge now.  If the finalizer is still in place, run the deprovisioning.
 	if object.GetDeletionTimestamp() != nil {
 		if len(object.GetFinalizers()) == 0 {
-			return reconcile.Result{}, nil
+			return reconcile.Result{}, outcomeDeleted, nil
 		}
 
 		log.Info("deleting object")
@@ -152,10 +319,20 @@ func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (
 		return r.reconcileDelete(ctx, provisioner, object)
 	}
 
+	// Paused resources are left alone, and not requeued, the generation
+	// change on unpausing will trigger a reconcile.  Deletion is handled above
+	// so a paused resource can still be deleted.
 	if object.Paused() {
 		log.Info("reconcilication paused")
 
-		return reconcile.Result{}, nil
+		// NOTE: DO NOT return an error, see below, just try again later.
+		if err := r.handlePausedCondition(ctx, object); err != nil {
+			log.Error(err, "failed to mark resource as paused")
+
+			return reconcile.Result{RequeueAfter: r.yieldTimeout()}, outcomeErrored, nil
+		}
+
+		return reconcile.Result{}, outcomePaused, nil
 	}
 
 	// Create or update the resource.
@@ -164,14 +341,34 @@ func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (
 	return r.reconcileNormal(ctx, provisioner, object)
 }
 
+// recordProvisionEvent annotates the reconcile span with the outcome of a
+// (de)provision.
+func recordProvisionEvent(ctx context.Context, name string, err error) {
+	span := trace.SpanFromContext(ctx)
+
+	switch {
+	case err == nil:
+		span.AddEvent(name + " completed")
+	case errors.Is(err, provisioners.ErrYield):
+		span.AddEvent(name+" yielded", trace.WithAttributes(attribute.String("message", err.Error())))
+	default:
+		span.AddEvent(name + " failed")
+		span.RecordError(err)
+		span.SetStatus(codes.Error, name+" failed")
+	}
+}
+
 // reconcileDelete handles object deletion.
 // In the Deleting phase we wait for any references or dependencies to be cleaned.
 // In the Draining phase we hand off to the provision to clean up any resources.
-// In the Finalizing phase we remove our finalizer to allow deletion.
-func (r *Reconciler) reconcileDelete(ctx context.Context, provisioner provisioners.Provisioner, object unikornv1.ManagableResourceInterface) (reconcile.Result, error) {
+// In the Finalizing phase we remove our finalizer to allow deletion, once the
+// provisioner has removed any additional finalizers.
+func (r *Reconciler) reconcileDelete(ctx context.Context, provisioner provisioners.Provisioner, object unikornv1.ManagableResourceInterface) (reconcile.Result, reconcileOutcome, error) {
 	log := log.FromContext(ctx)
 
-	references := GetResourceReferences(object)
+	references := resourceReferences(object, r.finalizers()...)
+
+	finalizers := slices.Clone(object.GetFinalizers())
 
 	var perr error
 
@@ -189,16 +386,40 @@ func (r *Reconciler) reconcileDelete(ctx context.Context, provisioner provisione
 
 		perr = provisioners.ErrYield
 	default:
+		trace.SpanFromContext(ctx).AddEvent("deprovision started")
+
 		perr = provisioner.Deprovision(ctx)
+
+		recordProvisionEvent(ctx, "deprovision", perr)
+
+		// The provisioner may remove additional finalizers as it cleans up, wait
+		// for them all to be removed before removing our own.
+		if perr == nil {
+			if pending := r.pendingFinalizers(object); len(pending) > 0 {
+				perr = fmt.Errorf("%w: awaiting removal of finalizers %v", provisioners.ErrYield, pending)
+			}
+		}
+	}
+
+	// Persist any finalizers removed by the provisioner, they would otherwise be
+	// lost when the status is updated.
+	if !slices.Equal(finalizers, object.GetFinalizers()) {
+		if err := r.manager.GetClient().Update(ctx, object); err != nil {
+			log.Info("failed to remove finalizers", "error", err)
+
+			return reconcile.Result{RequeueAfter: r.yieldTimeout()}, outcomeErrored, nil
+		}
 	}
 
+	outcome := provisionOutcome(perr, true)
+
 	// Always update the condition, this may fail if someone has poked the resource
 	// e.g, added a finalizer, then just requeue, no need for an error.
 	if err := r.handleReconcileCondition(ctx, object, perr, true); err != nil {
 		log.Info("failed to update status, enqueuing retry")
 
 		//nolint:nilerr
-		return reconcile.Result{RequeueAfter: constants.DefaultYieldTimeout}, nil
+		return reconcile.Result{RequeueAfter: r.yieldTimeout()}, outcome, nil
 	}
 
 	// If anything went wrong, requeue for another attempt.
@@ -208,51 +429,82 @@ func (r *Reconciler) reconcileDelete(ctx context.Context, provisioner provisione
 		if !errors.Is(perr, provisioners.ErrYield) {
 			// This will result in an exponential backoff, so you want
 			// to avoid it!
-			return reconcile.Result{}, perr
+			return reconcile.Result{}, outcome, perr
 		}
 
 		log.Info("controller yielding", "message", perr)
 
-		return reconcile.Result{RequeueAfter: constants.DefaultYieldTimeout}, nil
+		return reconcile.Result{RequeueAfter: r.yieldTimeout()}, outcome, nil
 	}
 
 	// All good, signal the resource can be deleted.
-	if ok := controllerutil.RemoveFinalizer(object, constants.Finalizer); ok {
+	if ok := controllerutil.RemoveFinalizer(object, r.finalizers()[0]); ok {
 		if err := r.manager.GetClient().Update(ctx, object); err != nil {
 			log.Info("failed to remove finalizer", "error", err)
 
-			return reconcile.Result{RequeueAfter: constants.DefaultYieldTimeout}, nil
+			return reconcile.Result{RequeueAfter: r.yieldTimeout()}, outcomeErrored, nil
 		}
+
+		trace.SpanFromContext(ctx).AddEvent("finalizer removed")
 	}
 
 	log.Info("deletion complete")
 
-	return reconcile.Result{}, nil
+	return reconcile.Result{}, outcome, nil
 }
 
 // reconcileNormal adds the application finalizer, provisions the resource and
 // updates the resource status to indicate progress.
-func (r *Reconciler) reconcileNormal(ctx context.Context, provisioner provisioners.Provisioner, object unikornv1.ManagableResourceInterface) (reconcile.Result, error) {
+func (r *Reconciler) reconcileNormal(ctx context.Context, provisioner provisioners.Provisioner, object unikornv1.ManagableResourceInterface) (reconcile.Result, reconcileOutcome, error) {
 	log := log.FromContext(ctx)
 
-	// Add the finalizer so we can orchestrate resource garbage collection.
-	if ok := controllerutil.AddFinalizer(object, constants.Finalizer); ok {
+	// Add the finalizers so we can orchestrate resource garbage collection.
+	var added bool
+
+	for _, finalizer := range r.finalizers() {
+		if controllerutil.AddFinalizer(object, finalizer) {
+			added = true
+		}
+	}
+
+	if added {
 		if err := r.manager.GetClient().Update(ctx, object); err != nil {
-			return reconcile.Result{}, err
+			return reconcile.Result{}, outcomeErrored, err
 		}
+
+		trace.SpanFromContext(ctx).AddEvent("finalizer added")
 	}
 
+	trace.SpanFromContext(ctx).AddEvent("provision started")
+
 	perr := provisioner.Provision(ctx)
 
+	recordProvisionEvent(ctx, "provision", perr)
+
+	outcome := provisionOutcome(perr, false)
+
+	// Health is only meaningful once provisioned, and is persisted along with
+	// the Available condition below.
+	if perr == nil {
+		setHealthCondition(ctx, provisioner, object)
+	}
+
 	// Update the status conditionally, this will remove transient errors etc.
 	if err := r.handleReconcileCondition(ctx, object, perr, false); err != nil {
 		//nolint:nilerr
-		return reconcile.Result{RequeueAfter: constants.DefaultYieldTimeout}, nil
+		return reconcile.Result{RequeueAfter: r.yieldTimeout()}, outcome, nil
+	}
+
+	key := types.NamespacedName{
+		Namespace: object.GetNamespace(),
+		Name:      object.GetName(),
 	}
 
 	// If anything went wrong, requeue for another attempt.
-	// NOTE: DO NOT return an error, and use a constant period or you will
-	// suffer from an exponential back-off and kill performance.
+	// NOTE: DO NOT return an error or you will suffer from controller-runtime's
+	// exponential back-off and kill performance.  Yields use a constant period,
+	// while unexpected errors back off per resource so a persistently failing
+	// resource doesn't hammer its dependencies.
 	if perr != nil {
 		// Terminal dispositions are parked, not retried: requeuing them just
 		// burns the workqueue on a failure that will not self-heal (see the
@@ -264,19 +516,78 @@ func (r *Reconciler) reconcileNormal(ctx context.Context, provisioner provisione
 		if provisioners.IsTerminal(perr) {
 			log.Error(perr, "provisioning terminally failed, parking resource")
 
-			return reconcile.Result{}, nil
+			r.resetErrorBackoff(key)
+
+			return reconcile.Result{}, outcome, nil
 		}
 
 		if !errors.Is(perr, provisioners.ErrYield) {
 			log.Error(perr, "provisioning failed unexpectedly")
+
+			return reconcile.Result{RequeueAfter: r.errorBackoff(key)}, outcome, nil
 		}
 
-		return reconcile.Result{RequeueAfter: constants.DefaultYieldTimeout}, nil
+		r.resetErrorBackoff(key)
+
+		return reconcile.Result{RequeueAfter: r.yieldTimeout()}, outcome, nil
 	}
 
+	r.resetErrorBackoff(key)
+
 	log.Info("reconcile complete")
 
-	return reconcile.Result{}, nil
+	return reconcile.Result{}, outcome, nil
+}
+
+// setHealthCondition sets the Healthy condition, for resources that have one,
+// from the provisioner if it reports health, otherwise the resource is
+// considered healthy.
+func setHealthCondition(ctx context.Context, provisioner provisioners.Provisioner, object unikornv1.ManagableResourceInterface) {
+	writer, ok := object.(unikornv1.HealthConditionWriter)
+	if !ok {
+		return
+	}
+
+	reporter, ok := provisioner.(provisioners.HealthReporter)
+	if !ok {
+		writer.SetHealthCondition(corev1.ConditionTrue, unikornv1.ConditionReasonHealthy, "healthy")
+
+		return
+	}
+
+	status, reason, message := reporter.Health(ctx)
+
+	writer.SetHealthCondition(status, reason, message)
+}
+
+// handlePausedCondition marks the resource as paused, preserving the existing
+// status so a provisioned resource remains available.  An errored resource is
+// left as is so the error remains visible while paused.
+func (r *Reconciler) handlePausedCondition(ctx context.Context, object unikornv1.ManagableResourceInterface) error {
+	status := corev1.ConditionFalse
+
+	prior, _ := unikornv1.GetAvailableCondition(object)
+	if prior != nil {
+		if prior.Reason == unikornv1.ConditionReasonPaused || prior.Reason == unikornv1.ConditionReasonErrored {
+			return nil
+		}
+
+		status = prior.Status
+	}
+
+	const message = "reconciliation paused"
+
+	object.SetProvisioningCondition(status, unikornv1.ConditionReasonPaused, message)
+
+	if err := r.manager.GetClient().Status().Update(ctx, object); err != nil {
+		return err
+	}
+
+	provisioninglog.Emit(ctx, r.manager.GetScheme(), object, provisioninglog.StreamProvisioning, string(status), string(unikornv1.ConditionReasonPaused), message)
+
+	r.recordEvent(object, corev1.EventTypeNormal, string(unikornv1.ConditionReasonPaused), message)
+
+	return nil
 }
 
 // handleReconcileCondition maps the outcome of a (de)provision — the error, or
@@ -303,6 +614,12 @@ func (r *Reconciler) handleReconcileCondition(ctx context.Context, object unikor
 
 	var message string
 
+	// Events are visible only to those with access to Kubernetes, so unlike the
+	// condition may carry the raw error to aid diagnosis.
+	eventType := corev1.EventTypeNormal
+
+	var eventMessage string
+
 	// Capture the prior Available condition so the provisioning log below can be
 	// edge-triggered: emit only when the (status, reason, message) tuple actually
 	// changes, never on every poll. Nil when there is no condition yet.
@@ -332,6 +649,8 @@ func (r *Reconciler) handleReconcileCondition(ctx context.Context, object unikor
 		}
 	case errors.Is(err, context.Canceled):
 		// Leave it as it is.
+		r.recordEvent(object, corev1.EventTypeNormal, eventReasonCancelled, "reconcile cancelled")
+
 		return nil
 	default:
 		// Everything else, including the terminal dispositions
@@ -350,6 +669,9 @@ func (r *Reconciler) handleReconcileCondition(ctx context.Context, object unikor
 		status = corev1.ConditionFalse
 		reason = unikornv1.ConditionReasonErrored
 		message = "an unexpected error occurred"
+
+		eventType = corev1.EventTypeWarning
+		eventMessage = err.Error()
 	}
 
 	// Phase 2: enrich. A typed provisioning error carries its own closed-vocabulary
@@ -386,11 +708,22 @@ func (r *Reconciler) handleReconcileCondition(ctx context.Context, object unikor
 		return err
 	}
 
+	trace.SpanFromContext(ctx).AddEvent("status written", trace.WithAttributes(
+		attribute.String("status", string(status)),
+		attribute.String("reason", string(reason)),
+	))
+
 	// Emit the provisioning-transition log only once the change is persisted, so
 	// the stream reflects committed state (and a failed update simply retries and
 	// re-evaluates the edge next reconcile).
 	if changed {
 		provisioninglog.Emit(ctx, r.manager.GetScheme(), object, provisioninglog.StreamProvisioning, string(status), string(reason), message)
+
+		if eventMessage == "" {
+			eventMessage = message
+		}
+
+		r.recordEvent(object, eventType, string(reason), eventMessage)
 	}
 
 	return nil
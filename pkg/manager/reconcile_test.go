@@ -28,7 +28,11 @@ import (
 	"testing"
 	"time"
 
+	"github.com/spf13/pflag"
 	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 	"go.uber.org/mock/gomock"
 
 	unikornv1 "github.com/unikorn-cloud/core/pkg/apis/unikorn/v1alpha1"
@@ -47,16 +51,26 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	crmanager "sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
+// spanRecorder records all reconcile spans.
+//
+//nolint:gochecknoglobals
+var spanRecorder = tracetest.NewSpanRecorder()
+
 func TestMain(m *testing.M) {
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spanRecorder)))
+
 	var debug bool
 
 	flag.BoolVar(&debug, "debug", false, "Enables debug logging")
@@ -71,8 +85,9 @@ func TestMain(m *testing.M) {
 
 // testContext provides a common framework for test execution.
 type testContext struct {
-	client client.Client
-	scheme *runtime.Scheme
+	client   client.Client
+	scheme   *runtime.Scheme
+	recorder record.EventRecorder
 }
 
 func mustNewTestContext(t *testing.T, objects ...client.Object) *testContext {
@@ -115,6 +130,14 @@ func (tc *testContext) newManager(c *gomock.Controller) crmanager.Manager {
 	m.EXPECT().GetClient().Return(tc.client).AnyTimes()
 	m.EXPECT().GetScheme().Return(tc.scheme).AnyTimes()
 
+	// A fake recorder without a channel discards events.
+	recorder := tc.recorder
+	if recorder == nil {
+		recorder = &record.FakeRecorder{}
+	}
+
+	m.EXPECT().GetEventRecorderFor(manager.EventRecorderName).Return(recorder).AnyTimes()
+
 	return m
 }
 
@@ -132,6 +155,63 @@ func mustAssertStatus(t *testing.T, resource unikornv1.StatusConditionReader, st
 	}
 }
 
+// mustAssertHealth checks the Healthy condition is as we expect.
+func mustAssertHealth(t *testing.T, resource unikornv1.StatusConditionReader, status corev1.ConditionStatus, reason unikornv1.HealthConditionReason) {
+	t.Helper()
+
+	condition, err := unikornv1.GetHealthyCondition(resource)
+	assert.NoError(t, err)
+
+	if condition != nil {
+		assert.Equal(t, status, condition.Status)
+		assert.Equal(t, reason, condition.Reason)
+	}
+}
+
+// healthReportingProvisioner is a manager provisioner that reports health.
+type healthReportingProvisioner struct {
+	*mockprovisioners.MockManagerProvisioner
+	*mockprovisioners.MockHealthReporter
+}
+
+const (
+	billingFinalizer = "billing.unikorn-cloud.org"
+	cleanupFinalizer = "cleanup.unikorn-cloud.org"
+)
+
+// finalizerOptions are controller options that manage additional finalizers.
+type finalizerOptions struct{}
+
+func (*finalizerOptions) AddFlags(_ *pflag.FlagSet) {}
+
+func (*finalizerOptions) Finalizers() []string {
+	return []string{
+		constants.Finalizer,
+		billingFinalizer,
+		cleanupFinalizer,
+	}
+}
+
+// yieldTimeoutOptions are controller options that override the yield timeout.
+type yieldTimeoutOptions struct {
+	timeout time.Duration
+}
+
+func (*yieldTimeoutOptions) AddFlags(_ *pflag.FlagSet) {}
+
+func (o *yieldTimeoutOptions) YieldTimeout() time.Duration {
+	return o.timeout
+}
+
+// errorBackoffOptions are controller options that override the error backoff.
+type errorBackoffOptions struct{}
+
+func (*errorBackoffOptions) AddFlags(_ *pflag.FlagSet) {}
+
+func (*errorBackoffOptions) ErrorBackoff() (time.Duration, time.Duration) {
+	return time.Second, 5 * time.Second
+}
+
 func managerOptions() *options.Options {
 	return &options.Options{
 		CDDriver: cd.DriverKindFlag{
@@ -203,6 +283,218 @@ func TestReconcileCreate(t *testing.T) {
 	mustAssertStatus(t, &result, corev1.ConditionTrue, unikornv1.ConditionReasonProvisioned)
 }
 
+// mustGetSpanEvents returns the events recorded against the reconcile span for
+// the named resource.
+func mustGetSpanEvents(t *testing.T, name string) []string {
+	t.Helper()
+
+	for _, span := range spanRecorder.Ended() {
+		for _, attr := range span.Attributes() {
+			if attr.Key != "k8s.resource.name" || attr.Value.AsString() != name {
+				continue
+			}
+
+			events := make([]string, len(span.Events()))
+
+			for i, event := range span.Events() {
+				events[i] = event.Name
+			}
+
+			return events
+		}
+	}
+
+	t.Fatal("reconcile span not found")
+
+	return nil
+}
+
+// TestReconcileCreateFinalizers tests all finalizers managed by the controller
+// are added on creation.
+func TestReconcileCreateFinalizers(t *testing.T) {
+	t.Parallel()
+
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	request := &unikornv1fake.ManagedResource{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testNamespace,
+			Name:      testName,
+		},
+	}
+
+	tc := mustNewTestContext(t, request)
+	ctx := t.Context()
+
+	p := mockprovisioners.NewMockManagerProvisioner(c)
+	p.EXPECT().Object().Return(&unikornv1fake.ManagedResource{})
+	p.EXPECT().Provision(gomock.Any()).Return(nil)
+
+	reconciler := manager.NewReconciler(managerOptions(), &finalizerOptions{}, tc.newManager(c), func(_ manager.ControllerOptions) provisioners.ManagerProvisioner { return p })
+
+	_, err := reconciler.Reconcile(ctx, newRequest(testNamespace, testName))
+	assert.NoError(t, err)
+
+	var result unikornv1fake.ManagedResource
+
+	assert.NoError(t, tc.client.Get(ctx, newNamespacedName(testNamespace, testName), &result))
+	assert.Equal(t, []string{constants.Finalizer, billingFinalizer, cleanupFinalizer}, result.Finalizers)
+}
+
+// TestReconcileCreateSpanEvents tests the reconcile span is annotated with
+// events at key milestones.
+func TestReconcileCreateSpanEvents(t *testing.T) {
+	t.Parallel()
+
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	const name = "traced"
+
+	request := &unikornv1fake.ManagedResource{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testNamespace,
+			Name:      name,
+		},
+	}
+
+	tc := mustNewTestContext(t, request)
+	ctx := t.Context()
+
+	p := mockprovisioners.NewMockManagerProvisioner(c)
+	p.EXPECT().Object().Return(&unikornv1fake.ManagedResource{})
+	p.EXPECT().Provision(gomock.Any()).Return(nil)
+
+	reconciler := manager.NewReconciler(managerOptions(), nil, tc.newManager(c), func(_ manager.ControllerOptions) provisioners.ManagerProvisioner { return p })
+
+	_, err := reconciler.Reconcile(ctx, newRequest(testNamespace, name))
+	assert.NoError(t, err)
+
+	expected := []string{
+		"finalizer added",
+		"provision started",
+		"provision completed",
+		"status written",
+	}
+
+	assert.Equal(t, expected, mustGetSpanEvents(t, name))
+}
+
+// TestReconcileCreateHealthy tests a provisioner that doesn't report health is
+// considered healthy.
+func TestReconcileCreateHealthy(t *testing.T) {
+	t.Parallel()
+
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	request := &unikornv1fake.ManagedResource{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testNamespace,
+			Name:      testName,
+		},
+	}
+
+	tc := mustNewTestContext(t, request)
+	ctx := t.Context()
+
+	p := mockprovisioners.NewMockManagerProvisioner(c)
+	p.EXPECT().Object().Return(&unikornv1fake.ManagedResource{})
+	p.EXPECT().Provision(gomock.Any()).Return(nil)
+
+	reconciler := manager.NewReconciler(managerOptions(), nil, tc.newManager(c), func(_ manager.ControllerOptions) provisioners.ManagerProvisioner { return p })
+
+	_, err := reconciler.Reconcile(ctx, newRequest(testNamespace, testName))
+	assert.NoError(t, err)
+
+	var resource unikornv1fake.ManagedResource
+
+	assert.NoError(t, tc.client.Get(ctx, newNamespacedName(testNamespace, testName), &resource))
+	mustAssertStatus(t, &resource, corev1.ConditionTrue, unikornv1.ConditionReasonProvisioned)
+	mustAssertHealth(t, &resource, corev1.ConditionTrue, unikornv1.ConditionReasonHealthy)
+}
+
+// TestReconcileCreateDegraded tests a provisioner can report a resource as
+// degraded independently of it being provisioned.
+func TestReconcileCreateDegraded(t *testing.T) {
+	t.Parallel()
+
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	request := &unikornv1fake.ManagedResource{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testNamespace,
+			Name:      testName,
+		},
+	}
+
+	tc := mustNewTestContext(t, request)
+	ctx := t.Context()
+
+	p := &healthReportingProvisioner{
+		MockManagerProvisioner: mockprovisioners.NewMockManagerProvisioner(c),
+		MockHealthReporter:     mockprovisioners.NewMockHealthReporter(c),
+	}
+
+	p.MockManagerProvisioner.EXPECT().Object().Return(&unikornv1fake.ManagedResource{})
+	p.MockManagerProvisioner.EXPECT().Provision(gomock.Any()).Return(nil)
+	p.MockHealthReporter.EXPECT().Health(gomock.Any()).Return(corev1.ConditionFalse, unikornv1.ConditionReasonDegraded, "workloads degraded")
+
+	reconciler := manager.NewReconciler(managerOptions(), nil, tc.newManager(c), func(_ manager.ControllerOptions) provisioners.ManagerProvisioner { return p })
+
+	_, err := reconciler.Reconcile(ctx, newRequest(testNamespace, testName))
+	assert.NoError(t, err)
+
+	var resource unikornv1fake.ManagedResource
+
+	assert.NoError(t, tc.client.Get(ctx, newNamespacedName(testNamespace, testName), &resource))
+	mustAssertStatus(t, &resource, corev1.ConditionTrue, unikornv1.ConditionReasonProvisioned)
+	mustAssertHealth(t, &resource, corev1.ConditionFalse, unikornv1.ConditionReasonDegraded)
+}
+
+// TestReconcileCreateErrorHealth tests health is not evaluated when provisioning
+// fails, as it is meaningless for a resource that isn't provisioned.
+func TestReconcileCreateErrorHealth(t *testing.T) {
+	t.Parallel()
+
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	request := &unikornv1fake.ManagedResource{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testNamespace,
+			Name:      testName,
+		},
+	}
+
+	tc := mustNewTestContext(t, request)
+	ctx := t.Context()
+
+	p := &healthReportingProvisioner{
+		MockManagerProvisioner: mockprovisioners.NewMockManagerProvisioner(c),
+		MockHealthReporter:     mockprovisioners.NewMockHealthReporter(c),
+	}
+
+	p.MockManagerProvisioner.EXPECT().Object().Return(&unikornv1fake.ManagedResource{})
+	p.MockManagerProvisioner.EXPECT().Provision(gomock.Any()).Return(errUnhandled)
+
+	reconciler := manager.NewReconciler(managerOptions(), nil, tc.newManager(c), func(_ manager.ControllerOptions) provisioners.ManagerProvisioner { return p })
+
+	_, err := reconciler.Reconcile(ctx, newRequest(testNamespace, testName))
+	assert.NoError(t, err)
+
+	var resource unikornv1fake.ManagedResource
+
+	assert.NoError(t, tc.client.Get(ctx, newNamespacedName(testNamespace, testName), &resource))
+	mustAssertStatus(t, &resource, corev1.ConditionFalse, unikornv1.ConditionReasonErrored)
+
+	condition, err := unikornv1.GetHealthyCondition(&resource)
+	assert.Error(t, err)
+	assert.Nil(t, condition)
+}
+
 // TestReconcileCreateYield tests resource creation and the status when the provisioner
 // yields.
 func TestReconcileCreateYield(t *testing.T) {
@@ -238,6 +530,166 @@ func TestReconcileCreateYield(t *testing.T) {
 	mustAssertStatus(t, &result, corev1.ConditionFalse, unikornv1.ConditionReasonProvisioning)
 }
 
+// TestReconcileCreateYieldTimeout tests that yields are requeued using the default
+// timeout, unless the controller options provide one.
+func TestReconcileCreateYieldTimeout(t *testing.T) {
+	t.Parallel()
+
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	request := &unikornv1fake.ManagedResource{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testNamespace,
+			Name:      testName,
+		},
+	}
+
+	tc := mustNewTestContext(t, request)
+	ctx := t.Context()
+
+	p := mockprovisioners.NewMockManagerProvisioner(c)
+	p.EXPECT().Object().Return(&unikornv1fake.ManagedResource{}).Times(2)
+	p.EXPECT().Provision(gomock.Any()).Return(provisioners.ErrYield).Times(2)
+
+	controllerOptions := &yieldTimeoutOptions{}
+
+	reconciler := manager.NewReconciler(managerOptions(), controllerOptions, tc.newManager(c), func(_ manager.ControllerOptions) provisioners.ManagerProvisioner { return p })
+
+	result, err := reconciler.Reconcile(ctx, newRequest(testNamespace, testName))
+	assert.NoError(t, err)
+	assert.Equal(t, constants.DefaultYieldTimeout, result.RequeueAfter)
+
+	controllerOptions.timeout = time.Minute
+
+	result, err = reconciler.Reconcile(ctx, newRequest(testNamespace, testName))
+	assert.NoError(t, err)
+	assert.Equal(t, time.Minute, result.RequeueAfter)
+}
+
+// TestReconcileCreateErrorBackoff tests that consecutive unexpected errors
+// back off exponentially up to a limit, and that a success resets the backoff.
+func TestReconcileCreateErrorBackoff(t *testing.T) {
+	t.Parallel()
+
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	request := &unikornv1fake.ManagedResource{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testNamespace,
+			Name:      testName,
+		},
+	}
+
+	tc := mustNewTestContext(t, request)
+	ctx := t.Context()
+
+	p := mockprovisioners.NewMockManagerProvisioner(c)
+	p.EXPECT().Object().Return(&unikornv1fake.ManagedResource{}).Times(6)
+
+	gomock.InOrder(
+		p.EXPECT().Provision(gomock.Any()).Return(errUnhandled).Times(4),
+		p.EXPECT().Provision(gomock.Any()).Return(nil),
+		p.EXPECT().Provision(gomock.Any()).Return(errUnhandled),
+	)
+
+	reconciler := manager.NewReconciler(managerOptions(), &errorBackoffOptions{}, tc.newManager(c), func(_ manager.ControllerOptions) provisioners.ManagerProvisioner { return p })
+
+	for _, expected := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 0, time.Second} {
+		result, err := reconciler.Reconcile(ctx, newRequest(testNamespace, testName))
+		assert.NoError(t, err)
+		assert.Equal(t, expected, result.RequeueAfter)
+	}
+}
+
+// TestReconcileCreateEvents tests that provisioning transitions are recorded as
+// events, with errors carrying the underlying error, and that unchanged
+// transitions aren't repeated.
+func TestReconcileCreateEvents(t *testing.T) {
+	t.Parallel()
+
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	request := &unikornv1fake.ManagedResource{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testNamespace,
+			Name:      testName,
+		},
+	}
+
+	tc := mustNewTestContext(t, request)
+	ctx := t.Context()
+
+	p := mockprovisioners.NewMockManagerProvisioner(c)
+	p.EXPECT().Object().Return(&unikornv1fake.ManagedResource{}).Times(4)
+
+	gomock.InOrder(
+		p.EXPECT().Provision(gomock.Any()).Return(provisioners.ErrYield),
+		p.EXPECT().Provision(gomock.Any()).Return(errUnhandled).Times(2),
+		p.EXPECT().Provision(gomock.Any()).Return(nil),
+	)
+
+	recorder := record.NewFakeRecorder(10)
+	tc.recorder = recorder
+
+	reconciler := manager.NewReconciler(managerOptions(), nil, tc.newManager(c), func(_ manager.ControllerOptions) provisioners.ManagerProvisioner { return p })
+
+	for range 4 {
+		_, err := reconciler.Reconcile(ctx, newRequest(testNamespace, testName))
+		assert.NoError(t, err)
+	}
+
+	close(recorder.Events)
+
+	var events []string
+
+	for event := range recorder.Events {
+		events = append(events, event)
+	}
+
+	expected := []string{
+		"Normal Provisioning provisioning",
+		"Warning Errored " + errUnhandled.Error(),
+		"Normal Provisioned provisioned",
+	}
+
+	assert.Equal(t, expected, events)
+}
+
+// TestReconcileCreateEventsDisabled tests a nil event recorder overrides the
+// manager's and disables events.
+func TestReconcileCreateEventsDisabled(t *testing.T) {
+	t.Parallel()
+
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	request := &unikornv1fake.ManagedResource{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testNamespace,
+			Name:      testName,
+		},
+	}
+
+	tc := mustNewTestContext(t, request)
+	ctx := t.Context()
+
+	p := mockprovisioners.NewMockManagerProvisioner(c)
+	p.EXPECT().Object().Return(&unikornv1fake.ManagedResource{})
+	p.EXPECT().Provision(gomock.Any()).Return(nil)
+
+	recorder := record.NewFakeRecorder(10)
+	tc.recorder = recorder
+
+	reconciler := manager.NewReconciler(managerOptions(), nil, tc.newManager(c), func(_ manager.ControllerOptions) provisioners.ManagerProvisioner { return p }, manager.WithEventRecorder(nil))
+
+	_, err := reconciler.Reconcile(ctx, newRequest(testNamespace, testName))
+	assert.NoError(t, err)
+	assert.Empty(t, recorder.Events)
+}
+
 // TestReconcileCreateYieldReason tests that a typed yield surfaces its
 // closed-vocabulary reason on the Available condition's Reason field (rather than
 // the bare Provisioning lifecycle default) with the safe detail as the Message,
@@ -512,6 +964,239 @@ func TestReconcileDelete(t *testing.T) {
 	assert.Equal(t, metav1.StatusReasonNotFound, apiError.Status().Reason)
 }
 
+// TestReconcileDeleteFinalizers checks that a resource with additional finalizers
+// is only deleted once the provisioner has removed them all, and that they may be
+// removed incrementally.
+func TestReconcileDeleteFinalizers(t *testing.T) {
+	t.Parallel()
+
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	request := &unikornv1fake.ManagedResource{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testNamespace,
+			Name:      testName,
+			Finalizers: []string{
+				constants.Finalizer,
+				billingFinalizer,
+				cleanupFinalizer,
+			},
+			DeletionTimestamp: &metav1.Time{
+				Time: time.Now(),
+			},
+		},
+	}
+
+	tc := mustNewTestContext(t, request)
+	ctx := t.Context()
+
+	var object *unikornv1fake.ManagedResource
+
+	newObject := func() unikornv1.ManagableResourceInterface {
+		object = &unikornv1fake.ManagedResource{}
+
+		return object
+	}
+
+	// Remove one finalizer per reconcile.
+	deprovision := func(_ context.Context) error {
+		controllerutil.RemoveFinalizer(object, object.Finalizers[len(object.Finalizers)-1])
+
+		return nil
+	}
+
+	p := mockprovisioners.NewMockManagerProvisioner(c)
+	p.EXPECT().Object().DoAndReturn(newObject).Times(2)
+	p.EXPECT().Deprovision(gomock.Any()).DoAndReturn(deprovision).Times(2)
+
+	reconciler := manager.NewReconciler(managerOptions(), &finalizerOptions{}, tc.newManager(c), func(_ manager.ControllerOptions) provisioners.ManagerProvisioner { return p })
+
+	// The first finalizer removal yields while the other is still present.
+	result, err := reconciler.Reconcile(ctx, newRequest(testNamespace, testName))
+	assert.NoError(t, err)
+	assert.NotZero(t, result.RequeueAfter)
+
+	var resource unikornv1fake.ManagedResource
+
+	assert.NoError(t, tc.client.Get(ctx, newNamespacedName(testNamespace, testName), &resource))
+	assert.Equal(t, []string{constants.Finalizer, billingFinalizer}, resource.Finalizers)
+	mustAssertStatus(t, &resource, corev1.ConditionFalse, unikornv1.ConditionReasonDeprovisioning)
+
+	// The second removes the last, so our finalizer is removed and it's deleted.
+	_, err = reconciler.Reconcile(ctx, newRequest(testNamespace, testName))
+	assert.NoError(t, err)
+
+	var apiError kerrors.APIStatus
+
+	assert.ErrorAs(t, tc.client.Get(ctx, newNamespacedName(testNamespace, testName), &resource), &apiError)
+	assert.Equal(t, metav1.StatusReasonNotFound, apiError.Status().Reason)
+}
+
+// TestReconcilePaused checks that a paused resource is not provisioned or
+// requeued, and is marked as paused preserving its existing status.
+func TestReconcilePaused(t *testing.T) {
+	t.Parallel()
+
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	request := &unikornv1fake.ManagedResource{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testNamespace,
+			Name:      testName,
+		},
+		Spec: unikornv1fake.ManagedResourceSpec{
+			Pause: true,
+		},
+	}
+
+	request.SetProvisioningCondition(corev1.ConditionTrue, unikornv1.ConditionReasonProvisioned, "provisioned")
+
+	tc := mustNewTestContext(t, request)
+	ctx := t.Context()
+
+	p := mockprovisioners.NewMockManagerProvisioner(c)
+	p.EXPECT().Object().Return(&unikornv1fake.ManagedResource{})
+
+	reconciler := manager.NewReconciler(managerOptions(), nil, tc.newManager(c), func(_ manager.ControllerOptions) provisioners.ManagerProvisioner { return p })
+
+	result, err := reconciler.Reconcile(ctx, newRequest(testNamespace, testName))
+	assert.NoError(t, err)
+	assert.Equal(t, reconcile.Result{}, result)
+
+	var resource unikornv1fake.ManagedResource
+
+	assert.NoError(t, tc.client.Get(ctx, newNamespacedName(testNamespace, testName), &resource))
+	assert.NotContains(t, resource.Finalizers, constants.Finalizer)
+	mustAssertStatus(t, &resource, corev1.ConditionTrue, unikornv1.ConditionReasonPaused)
+}
+
+// TestReconcilePausedErrored checks that pausing an errored resource leaves the
+// error visible.
+func TestReconcilePausedErrored(t *testing.T) {
+	t.Parallel()
+
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	request := &unikornv1fake.ManagedResource{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testNamespace,
+			Name:      testName,
+		},
+		Spec: unikornv1fake.ManagedResourceSpec{
+			Pause: true,
+		},
+	}
+
+	request.SetProvisioningCondition(corev1.ConditionFalse, unikornv1.ConditionReasonErrored, "an unexpected error occurred")
+
+	tc := mustNewTestContext(t, request)
+	ctx := t.Context()
+
+	p := mockprovisioners.NewMockManagerProvisioner(c)
+	p.EXPECT().Object().Return(&unikornv1fake.ManagedResource{})
+
+	reconciler := manager.NewReconciler(managerOptions(), nil, tc.newManager(c), func(_ manager.ControllerOptions) provisioners.ManagerProvisioner { return p })
+
+	result, err := reconciler.Reconcile(ctx, newRequest(testNamespace, testName))
+	assert.NoError(t, err)
+	assert.Equal(t, reconcile.Result{}, result)
+
+	var resource unikornv1fake.ManagedResource
+
+	assert.NoError(t, tc.client.Get(ctx, newNamespacedName(testNamespace, testName), &resource))
+	mustAssertStatus(t, &resource, corev1.ConditionFalse, unikornv1.ConditionReasonErrored)
+}
+
+// TestReconcilePausedUpdateError checks that failing to mark a resource as paused
+// is retried after the yield timeout rather than returned to controller-runtime.
+func TestReconcilePausedUpdateError(t *testing.T) {
+	t.Parallel()
+
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	request := &unikornv1fake.ManagedResource{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testNamespace,
+			Name:      testName,
+		},
+		Spec: unikornv1fake.ManagedResourceSpec{
+			Pause: true,
+		},
+	}
+
+	scheme, err := coreclient.NewScheme()
+	assert.NoError(t, err)
+
+	funcs := interceptor.Funcs{
+		SubResourceUpdate: func(_ context.Context, _ client.Client, _ string, _ client.Object, _ ...client.SubResourceUpdateOption) error {
+			return errUnhandled
+		},
+	}
+
+	tc := &testContext{
+		client: fake.NewClientBuilder().WithScheme(scheme).WithStatusSubresource(&unikornv1fake.ManagedResource{}).WithObjects(request).WithInterceptorFuncs(funcs).Build(),
+		scheme: scheme,
+	}
+
+	ctx := t.Context()
+
+	p := mockprovisioners.NewMockManagerProvisioner(c)
+	p.EXPECT().Object().Return(&unikornv1fake.ManagedResource{})
+
+	reconciler := manager.NewReconciler(managerOptions(), nil, tc.newManager(c), func(_ manager.ControllerOptions) provisioners.ManagerProvisioner { return p })
+
+	result, err := reconciler.Reconcile(ctx, newRequest(testNamespace, testName))
+	assert.NoError(t, err)
+	assert.Equal(t, constants.DefaultYieldTimeout, result.RequeueAfter)
+}
+
+// TestReconcileDeletePaused checks that a paused resource can still be deleted.
+func TestReconcileDeletePaused(t *testing.T) {
+	t.Parallel()
+
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	request := &unikornv1fake.ManagedResource{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testNamespace,
+			Name:      testName,
+			Finalizers: []string{
+				constants.Finalizer,
+			},
+			DeletionTimestamp: &metav1.Time{
+				Time: time.Now(),
+			},
+		},
+		Spec: unikornv1fake.ManagedResourceSpec{
+			Pause: true,
+		},
+	}
+
+	tc := mustNewTestContext(t, request)
+	ctx := t.Context()
+
+	p := mockprovisioners.NewMockManagerProvisioner(c)
+	p.EXPECT().Object().Return(&unikornv1fake.ManagedResource{})
+	p.EXPECT().Deprovision(gomock.Any()).Return(nil)
+
+	reconciler := manager.NewReconciler(managerOptions(), nil, tc.newManager(c), func(_ manager.ControllerOptions) provisioners.ManagerProvisioner { return p })
+
+	_, err := reconciler.Reconcile(ctx, newRequest(testNamespace, testName))
+	assert.NoError(t, err)
+
+	var result unikornv1fake.ManagedResource
+
+	var apiError kerrors.APIStatus
+
+	assert.ErrorAs(t, tc.client.Get(ctx, newNamespacedName(testNamespace, testName), &result), &apiError)
+	assert.Equal(t, metav1.StatusReasonNotFound, apiError.Status().Reason)
+}
+
 // TestReconcileDeleteYield checks that a resource marked as being deleted and
 // yields due to a deprovision operation has the corrent status.
 func TestReconcileDeleteYield(t *testing.T) {
@@ -73,13 +73,17 @@ func GenerateResourceReference(client client.Client, resource client.Object) (st
 // deletion will have consequences.  It may also be used to inhibit deletion in
 // certain cercumstances.
 func GetResourceReferences(object client.Object) []string {
-	ignored := []string{
-		// Our finalizer to inhibit deletion until we are finished.
-		constants.Finalizer,
+	return resourceReferences(object, constants.Finalizer)
+}
+
+// resourceReferences returns all resource references attached to a resource,
+// ignoring the finalizers managed by the controller.
+func resourceReferences(object client.Object, finalizers ...string) []string {
+	ignored := append([]string{
 		// Some internal components will use cacscading deletion to
 		// block deletion.
 		metav1.FinalizerDeleteDependents,
-	}
+	}, finalizers...)
 
 	discard := func(s string) bool {
 		return slices.Contains(ignored, s)
@@ -139,27 +143,72 @@ func AddResourceReference(ctx context.Context, cli client.Client, resource clien
 	return nil
 }
 
+// ReferenceChange records a resource whose references were modified.
+type ReferenceChange struct {
+	// Key identifies the resource.
+	Key client.ObjectKey
+	// Before is the set of finalizers before modification.
+	Before []string
+	// After is the set of finalizers after modification.
+	After []string
+}
+
+// ReferenceChanges is an ordered list of resources whose references were modified.
+type ReferenceChanges []ReferenceChange
+
+// Keys returns the keys of all modified resources.
+func (c ReferenceChanges) Keys() []client.ObjectKey {
+	keys := make([]client.ObjectKey, len(c))
+
+	for i := range c {
+		keys[i] = c[i].Key
+	}
+
+	return keys
+}
+
+// newReferenceChange records the finalizers before modification.
+func newReferenceChange(object client.Object) ReferenceChange {
+	return ReferenceChange{
+		Key:    client.ObjectKeyFromObject(object),
+		Before: slices.Clone(object.GetFinalizers()),
+	}
+}
+
 // AddResourceReferences adds the given resource reference to all resources that match the selector
 // and that are in the given set of IDs.  An error is raised if an ID is not present.  This is
 // typically run by a controller before the resource is consumed.
 func AddResourceReferences(ctx context.Context, cli client.Client, resources client.ObjectList, options *client.ListOptions, reference string, ids []string) error {
+	_, err := AddResourceReferencesWithChanges(ctx, cli, resources, options, reference, ids)
+
+	return err
+}
+
+// AddResourceReferencesWithChanges is like AddResourceReferences, but also returns the
+// resources that were modified, so they can be logged or have events emitted against them.
+// Changes are returned on error, and reflect modifications persisted before the error.
+func AddResourceReferencesWithChanges(ctx context.Context, cli client.Client, resources client.ObjectList, options *client.ListOptions, reference string, ids []string) (ReferenceChanges, error) {
 	log := log.FromContext(ctx)
 
 	if err := cli.List(ctx, resources, options); err != nil {
-		return err
+		return nil, err
 	}
 
 	resourceIDMap, err := resourceIDMap(resources)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
+	var changes ReferenceChanges
+
 	for _, id := range ids {
 		resource, ok := resourceIDMap[id]
 		if !ok {
-			return fmt.Errorf("%w: attempt to reference unknown resource ID %s", errors.ErrConsistency, id)
+			return changes, fmt.Errorf("%w: attempt to reference unknown resource ID %s", errors.ErrConsistency, id)
 		}
 
+		change := newReferenceChange(resource)
+
 		if updated := controllerutil.AddFinalizer(resource, reference); !updated {
 			continue
 		}
@@ -170,14 +219,18 @@ func AddResourceReferences(ctx context.Context, cli client.Client, resources cli
 
 		if err := cli.Update(ctx, resource); err != nil {
 			if kerrors.IsConflict(err) {
-				return provisioners.ErrYield
+				return changes, provisioners.ErrYield
 			}
 
-			return err
+			return changes, err
 		}
+
+		change.After = slices.Clone(resource.GetFinalizers())
+
+		changes = append(changes, change)
 	}
 
-	return nil
+	return changes, nil
 }
 
 // RemoveResourceReference removes the given resource reference from the selected resource.
@@ -220,12 +273,23 @@ func RemoveResourceReference(ctx context.Context, cli client.Client, resource cl
 // selector and that are not in the given set of IDs.  This is typically run by a controller after
 // a resource has stopped being used.
 func RemoveResourceReferences(ctx context.Context, cli client.Client, resources client.ObjectList, options *client.ListOptions, reference string, ids []string) error {
+	_, err := RemoveResourceReferencesWithChanges(ctx, cli, resources, options, reference, ids)
+
+	return err
+}
+
+// RemoveResourceReferencesWithChanges is like RemoveResourceReferences, but also returns the
+// resources that were modified, so they can be logged or have events emitted against them.
+// Changes are returned on error, and reflect modifications persisted before the error.
+func RemoveResourceReferencesWithChanges(ctx context.Context, cli client.Client, resources client.ObjectList, options *client.ListOptions, reference string, ids []string) (ReferenceChanges, error) {
 	log := log.FromContext(ctx)
 
 	if err := cli.List(ctx, resources, options); err != nil {
-		return err
+		return nil, err
 	}
 
+	var changes ReferenceChanges
+
 	callback := func(resource runtime.Object) error {
 		object, ok := resource.(client.Object)
 		if !ok {
@@ -236,6 +300,8 @@ func RemoveResourceReferences(ctx context.Context, cli client.Client, resources
 			return nil
 		}
 
+		change := newReferenceChange(object)
+
 		if updated := controllerutil.RemoveFinalizer(object, reference); !updated {
 			return nil
 		}
@@ -252,10 +318,18 @@ func RemoveResourceReferences(ctx context.Context, cli client.Client, resources
 			return err
 		}
 
+		change.After = slices.Clone(object.GetFinalizers())
+
+		changes = append(changes, change)
+
 		return nil
 	}
 
-	return meta.EachListItem(resources, callback)
+	if err := meta.EachListItem(resources, callback); err != nil {
+		return changes, err
+	}
+
+	return changes, nil
 }
 
 // ClearResourceReferences is used by controllers whose object may reference one of
@@ -264,3 +338,9 @@ func RemoveResourceReferences(ctx context.Context, cli client.Client, resources
 func ClearResourceReferences(ctx context.Context, cli client.Client, resources client.ObjectList, options *client.ListOptions, reference string) error {
 	return RemoveResourceReferences(ctx, cli, resources, options, reference, nil)
 }
+
+// ClearResourceReferencesWithChanges is like ClearResourceReferences, but also returns
+// the resources that were modified.
+func ClearResourceReferencesWithChanges(ctx context.Context, cli client.Client, resources client.ObjectList, options *client.ListOptions, reference string) (ReferenceChanges, error) {
+	return RemoveResourceReferencesWithChanges(ctx, cli, resources, options, reference, nil)
+}
@@ -256,3 +256,61 @@ func TestReferenceRemoveMissingReference(t *testing.T) {
 	require.Len(t, result.Finalizers, 1)
 	require.Contains(t, result.Finalizers, constants.Finalizer)
 }
+
+// TestReferenceChanges tests the changed set returned by bulk reference operations
+// accurately reflects which resources were modified.
+func TestReferenceChanges(t *testing.T) {
+	t.Parallel()
+
+	reference := testResourceReference
+	namespace := testReferenceNamespace
+
+	objects := &networkingv1.IngressList{
+		Items: []networkingv1.Ingress{
+			// Already referenced.
+			{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: namespace,
+					Name:      "foo",
+					Finalizers: []string{
+						constants.Finalizer,
+						reference,
+					},
+				},
+			},
+			// Not referenced.
+			{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: namespace,
+					Name:      "bar",
+				},
+			},
+		},
+	}
+
+	cli := fake.NewClientBuilder().WithObjects(&objects.Items[0], &objects.Items[1]).Build()
+
+	options := &client.ListOptions{
+		Namespace: namespace,
+	}
+
+	changes, err := manager.AddResourceReferencesWithChanges(t.Context(), cli, &networkingv1.IngressList{}, options, reference, []string{"foo", "bar"})
+	require.NoError(t, err)
+	require.Equal(t, []client.ObjectKey{{Namespace: namespace, Name: "bar"}}, changes.Keys())
+	require.Empty(t, changes[0].Before)
+	require.Equal(t, []string{reference}, changes[0].After)
+
+	changes, err = manager.RemoveResourceReferencesWithChanges(t.Context(), cli, &networkingv1.IngressList{}, options, reference, []string{"bar"})
+	require.NoError(t, err)
+	require.Equal(t, []client.ObjectKey{{Namespace: namespace, Name: "foo"}}, changes.Keys())
+	require.Equal(t, []string{constants.Finalizer, reference}, changes[0].Before)
+	require.Equal(t, []string{constants.Finalizer}, changes[0].After)
+
+	changes, err = manager.ClearResourceReferencesWithChanges(t.Context(), cli, &networkingv1.IngressList{}, options, reference)
+	require.NoError(t, err)
+	require.Equal(t, []client.ObjectKey{{Namespace: namespace, Name: "bar"}}, changes.Keys())
+
+	changes, err = manager.ClearResourceReferencesWithChanges(t.Context(), cli, &networkingv1.IngressList{}, options, reference)
+	require.NoError(t, err)
+	require.Empty(t, changes)
+}
@@ -28,6 +28,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/utils/ptr"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -48,6 +49,10 @@ type CascadingDelete struct {
 	resourceLabel string
 	// resources is storage for resources being searched for.
 	resources client.ObjectList
+	// continueOnError attempts all deletions even if some fail.
+	continueOnError bool
+	// gvk if set restricts deletion to resources of this kind.
+	gvk *schema.GroupVersionKind
 }
 
 var _ = messaging.Consumer(&CascadingDelete{})
@@ -70,6 +75,72 @@ func WithResourceLabel(label string) Option {
 	}
 }
 
+// WithContinueOnError attempts to delete all resources, rather than returning
+// on the first error.  If any deletions fail a *PartialDeleteError is returned
+// that describes the outcome for each resource.
+func WithContinueOnError() Option {
+	return func(c *CascadingDelete) {
+		c.continueOnError = true
+	}
+}
+
+// WithTypeFilter only deletes resources of the given kind, any other kinds
+// returned when listing resources are ignored.  This guards against accidental
+// fan-out when multiple kinds reference the same resource ID.
+func WithTypeFilter(gvk schema.GroupVersionKind) Option {
+	return func(c *CascadingDelete) {
+		c.gvk = &gvk
+	}
+}
+
+// DeleteOutcome records the outcome of an individual resource deletion.
+type DeleteOutcome struct {
+	// Namespace is the resource's namespace.
+	Namespace string `json:"namespace,omitempty"`
+	// Name is the resource's name.
+	Name string `json:"name"`
+	// Reason describes why a deletion failed.
+	Reason string `json:"reason,omitempty"`
+}
+
+// DeleteResult records the outcome of a bulk deletion.  This is JSON
+// encodable so it may be returned to API clients as a multi-status response.
+type DeleteResult struct {
+	// Succeeded are resources that were deleted, or are already deleting.
+	Succeeded []DeleteOutcome `json:"succeeded,omitempty"`
+	// Failed are resources that could not be deleted.
+	Failed []DeleteOutcome `json:"failed,omitempty"`
+}
+
+// PartialDeleteError is returned when some deletions in a bulk delete failed.
+type PartialDeleteError struct {
+	// Result is the outcome for every resource.
+	Result *DeleteResult
+	// errs are the underlying errors.
+	errs []error
+}
+
+// Error implements the error interface.
+func (e *PartialDeleteError) Error() string {
+	return fmt.Sprintf("%d of %d resource deletions failed", len(e.Result.Failed), len(e.Result.Failed)+len(e.Result.Succeeded))
+}
+
+// Unwrap allows the underlying errors to be inspected.
+func (e *PartialDeleteError) Unwrap() []error {
+	return e.errs
+}
+
+// eventType returns the envelope's event type, falling back to the deletion
+// timestamp for envelopes built directly, rather than by a backend, that may
+// predate event types.
+func eventType(envelope *messaging.Envelope) messaging.EventType {
+	if envelope.EventType == "" {
+		return messaging.EventTypeFromDeletionTimestamp(envelope.DeletionTimestamp)
+	}
+
+	return envelope.EventType
+}
+
 // NewCascadingDelete creates a new cascading deletion consumer.
 func NewCascadingDelete(client client.Client, resources client.ObjectList, options ...Option) *CascadingDelete {
 	c := &CascadingDelete{
@@ -89,8 +160,8 @@ func NewCascadingDelete(client client.Client, resources client.ObjectList, optio
 func (c *CascadingDelete) Consume(ctx context.Context, envelope *messaging.Envelope) error {
 	log := log.FromContext(ctx)
 
-	if envelope.DeletionTimestamp == nil {
-		log.V(1).Info("ignoring live resource", "id", envelope.ResourceID)
+	if event := eventType(envelope); event != messaging.EventTypeDeleted {
+		log.V(1).Info("ignoring live resource", "id", envelope.ResourceID, "event", event)
 		return nil
 	}
 
@@ -108,10 +179,8 @@ func (c *CascadingDelete) Consume(ctx context.Context, envelope *messaging.Envel
 		return err
 	}
 
-	// Some resources may use ownder references to perform cascading deletion
-	// of their children, and they will need to block until cleanup has occurred.
-	deleteOptions := &client.DeleteOptions{
-		PropagationPolicy: ptr.To(metav1.DeletePropagationForeground),
+	if c.continueOnError {
+		return c.deleteAll(ctx)
 	}
 
 	deleteItem := func(object runtime.Object) error {
@@ -120,21 +189,103 @@ func (c *CascadingDelete) Consume(ctx context.Context, envelope *messaging.Envel
 			return fmt.Errorf("%w: cannot convert from runtime object to client", errors.ErrTypeConversion)
 		}
 
-		if resource.GetDeletionTimestamp() != nil {
-			log.V(1).Info("awaiting resource deletion", "id", resource.GetName())
-			return nil
+		if ok, err := c.managed(ctx, resource); err != nil || !ok {
+			return err
 		}
 
-		log.Info("deleting resource", "id", resource.GetName())
+		return c.delete(ctx, resource)
+	}
+
+	// This is literally the best thing ever!
+	// Well, sort of, it almost definitely uses reflection...
+	return meta.EachListItem(c.resources, deleteItem)
+}
+
+// deleteAll attempts to delete all listed resources, reporting the outcome
+// of each.
+func (c *CascadingDelete) deleteAll(ctx context.Context) error {
+	result := &DeleteResult{}
+
+	var errs []error
 
-		if err := c.client.Delete(ctx, resource, deleteOptions); err != nil {
+	deleteItem := func(object runtime.Object) error {
+		resource, ok := object.(client.Object)
+		if !ok {
+			return fmt.Errorf("%w: cannot convert from runtime object to client", errors.ErrTypeConversion)
+		}
+
+		if ok, err := c.managed(ctx, resource); err != nil || !ok {
 			return err
 		}
 
+		outcome := DeleteOutcome{
+			Namespace: resource.GetNamespace(),
+			Name:      resource.GetName(),
+		}
+
+		if err := c.delete(ctx, resource); err != nil {
+			outcome.Reason = err.Error()
+
+			result.Failed = append(result.Failed, outcome)
+			errs = append(errs, err)
+
+			return nil
+		}
+
+		result.Succeeded = append(result.Succeeded, outcome)
+
 		return nil
 	}
 
-	// This is literally the best thing ever!
-	// Well, sort of, it almost definitely uses reflection...
-	return meta.EachListItem(c.resources, deleteItem)
+	if err := meta.EachListItem(c.resources, deleteItem); err != nil {
+		return err
+	}
+
+	if len(errs) != 0 {
+		return &PartialDeleteError{
+			Result: result,
+			errs:   errs,
+		}
+	}
+
+	return nil
+}
+
+// managed returns whether the resource is of a kind managed by this consumer.
+func (c *CascadingDelete) managed(ctx context.Context, resource client.Object) (bool, error) {
+	if c.gvk == nil {
+		return true, nil
+	}
+
+	gvk, err := c.client.GroupVersionKindFor(resource)
+	if err != nil {
+		return false, err
+	}
+
+	if gvk != *c.gvk {
+		log.FromContext(ctx).V(1).Info("ignoring unmanaged resource kind", "id", resource.GetName(), "kind", gvk.String())
+		return false, nil
+	}
+
+	return true, nil
+}
+
+// delete deletes an individual resource if it's not already being deleted.
+func (c *CascadingDelete) delete(ctx context.Context, resource client.Object) error {
+	log := log.FromContext(ctx)
+
+	if resource.GetDeletionTimestamp() != nil {
+		log.V(1).Info("awaiting resource deletion", "id", resource.GetName())
+		return nil
+	}
+
+	log.Info("deleting resource", "id", resource.GetName())
+
+	// Some resources may use ownder references to perform cascading deletion
+	// of their children, and they will need to block until cleanup has occurred.
+	deleteOptions := &client.DeleteOptions{
+		PropagationPolicy: ptr.To(metav1.DeletePropagationForeground),
+	}
+
+	return c.client.Delete(ctx, resource, deleteOptions)
 }
@@ -0,0 +1,209 @@
+/*
+Copyright 2026 Nscale.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package consumer_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/unikorn-cloud/core/pkg/messaging"
+	"github.com/unikorn-cloud/core/pkg/messaging/consumer"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+const (
+	namespace     = "test"
+	resourceLabel = "test.unikorn-cloud.org/parent"
+	resourceID    = "parent"
+)
+
+var errDeleteFailed = errors.New("delete failed")
+
+func mustNewScheme(t *testing.T) *runtime.Scheme {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+
+	return scheme
+}
+
+func configMap(name string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+			Labels: map[string]string{
+				resourceLabel: resourceID,
+			},
+		},
+	}
+}
+
+func deletionEnvelope() *messaging.Envelope {
+	return &messaging.Envelope{
+		ResourceID:        resourceID,
+		DeletionTimestamp: ptr.To(time.Now()),
+	}
+}
+
+// failingClient returns a client that fails to delete the named resources.
+func failingClient(t *testing.T, failures ...string) client.Client {
+	t.Helper()
+
+	funcs := interceptor.Funcs{
+		Delete: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.DeleteOption) error {
+			for _, name := range failures {
+				if obj.GetName() == name {
+					return errDeleteFailed
+				}
+			}
+
+			return c.Delete(ctx, obj, opts...)
+		},
+	}
+
+	return fake.NewClientBuilder().
+		WithScheme(mustNewScheme(t)).
+		WithObjects(configMap("a"), configMap("b"), configMap("c")).
+		WithInterceptorFuncs(funcs).
+		Build()
+}
+
+// TestCascadingDeleteIgnoresLiveResources checks creation and update events
+// are ignored, as are envelopes without an event type or deletion timestamp.
+func TestCascadingDeleteIgnoresLiveResources(t *testing.T) {
+	t.Parallel()
+
+	cli := failingClient(t)
+
+	c := consumer.NewCascadingDelete(cli, &corev1.ConfigMapList{}, consumer.WithNamespace(namespace), consumer.WithResourceLabel(resourceLabel))
+
+	for _, eventType := range []messaging.EventType{"", messaging.EventTypeCreated, messaging.EventTypeUpdated} {
+		envelope := &messaging.Envelope{
+			ResourceID: resourceID,
+			EventType:  eventType,
+		}
+
+		require.NoError(t, c.Consume(t.Context(), envelope))
+	}
+
+	resources := &corev1.ConfigMapList{}
+	require.NoError(t, cli.List(t.Context(), resources, client.InNamespace(namespace)))
+	require.Len(t, resources.Items, 3)
+}
+
+// TestCascadingDeleteFailFast checks the default behaviour returns the first error.
+func TestCascadingDeleteFailFast(t *testing.T) {
+	t.Parallel()
+
+	cli := failingClient(t, "b")
+
+	c := consumer.NewCascadingDelete(cli, &corev1.ConfigMapList{}, consumer.WithNamespace(namespace), consumer.WithResourceLabel(resourceLabel))
+
+	err := c.Consume(t.Context(), deletionEnvelope())
+	require.ErrorIs(t, err, errDeleteFailed)
+
+	var partial *consumer.PartialDeleteError
+
+	require.NotErrorAs(t, err, &partial)
+}
+
+// TestCascadingDeleteContinueOnError checks all deletions are attempted and
+// the outcome of each is reported.
+func TestCascadingDeleteContinueOnError(t *testing.T) {
+	t.Parallel()
+
+	cli := failingClient(t, "a", "c")
+
+	c := consumer.NewCascadingDelete(cli, &corev1.ConfigMapList{}, consumer.WithNamespace(namespace), consumer.WithResourceLabel(resourceLabel), consumer.WithContinueOnError())
+
+	err := c.Consume(t.Context(), deletionEnvelope())
+	require.ErrorIs(t, err, errDeleteFailed)
+
+	var partial *consumer.PartialDeleteError
+
+	require.ErrorAs(t, err, &partial)
+
+	expected := &consumer.DeleteResult{
+		Succeeded: []consumer.DeleteOutcome{
+			{Namespace: namespace, Name: "b"},
+		},
+		Failed: []consumer.DeleteOutcome{
+			{Namespace: namespace, Name: "a", Reason: errDeleteFailed.Error()},
+			{Namespace: namespace, Name: "c", Reason: errDeleteFailed.Error()},
+		},
+	}
+
+	require.Equal(t, expected, partial.Result)
+
+	resources := &corev1.ConfigMapList{}
+	require.NoError(t, cli.List(t.Context(), resources, client.InNamespace(namespace)))
+	require.Len(t, resources.Items, 2)
+}
+
+// TestCascadingDeleteTypeFilter checks only resources of the managed kind are
+// deleted when multiple kinds reference the same resource ID.
+func TestCascadingDeleteTypeFilter(t *testing.T) {
+	t.Parallel()
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      "a",
+			Labels: map[string]string{
+				resourceLabel: resourceID,
+			},
+		},
+	}
+
+	cli := fake.NewClientBuilder().
+		WithScheme(mustNewScheme(t)).
+		WithObjects(configMap("a"), configMap("b"), secret).
+		Build()
+
+	// A mismatched kind is ignored.
+	c := consumer.NewCascadingDelete(cli, &corev1.ConfigMapList{}, consumer.WithNamespace(namespace), consumer.WithResourceLabel(resourceLabel), consumer.WithTypeFilter(corev1.SchemeGroupVersion.WithKind("Secret")))
+	require.NoError(t, c.Consume(t.Context(), deletionEnvelope()))
+
+	configMaps := &corev1.ConfigMapList{}
+	require.NoError(t, cli.List(t.Context(), configMaps, client.InNamespace(namespace)))
+	require.Len(t, configMaps.Items, 2)
+
+	// The managed kind is deleted, and other kinds are left alone.
+	c = consumer.NewCascadingDelete(cli, &corev1.ConfigMapList{}, consumer.WithNamespace(namespace), consumer.WithResourceLabel(resourceLabel), consumer.WithTypeFilter(corev1.SchemeGroupVersion.WithKind("ConfigMap")))
+	require.NoError(t, c.Consume(t.Context(), deletionEnvelope()))
+
+	require.NoError(t, cli.List(t.Context(), configMaps, client.InNamespace(namespace)))
+	require.Empty(t, configMaps.Items)
+
+	secrets := &corev1.SecretList{}
+	require.NoError(t, cli.List(t.Context(), secrets, client.InNamespace(namespace)))
+	require.Len(t, secrets.Items, 1)
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2026 Nscale.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package consumer
+
+import (
+	"context"
+	"fmt"
+	"maps"
+
+	"github.com/unikorn-cloud/core/pkg/errors"
+	"github.com/unikorn-cloud/core/pkg/messaging"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// LabelPropagation implements a message queue consumer that watches for
+// resource creation and update events and then copies a set of labels from
+// the resource onto another local type, so label selectors stay consistent
+// when the resource is relabelled.
+type LabelPropagation struct {
+	// client is a Kubernetes client.
+	client client.Client
+	// namespace is the where to look for resources.
+	namespace string
+	// resourceLabel defines a label to use for resource selection based
+	// on the resource ID passed in the message envelope.
+	resourceLabel string
+	// keys are the label keys to propagate.
+	keys []string
+	// resources is storage for resources being searched for.
+	resources client.ObjectList
+}
+
+var _ = messaging.Consumer(&LabelPropagation{})
+
+// NewLabelPropagation creates a new label propagation consumer.  Resources in
+// the namespace whose resource label matches the message's resource ID will have
+// the label keys synchronized with the labels in the message.
+func NewLabelPropagation(client client.Client, resources client.ObjectList, namespace, resourceLabel string, keys ...string) *LabelPropagation {
+	return &LabelPropagation{
+		client:        client,
+		namespace:     namespace,
+		resourceLabel: resourceLabel,
+		keys:          keys,
+		resources:     resources,
+	}
+}
+
+// Consume receives resource events.  If the resource is live we update all
+// local resources that reference that resource with its labels.
+func (c *LabelPropagation) Consume(ctx context.Context, envelope *messaging.Envelope) error {
+	log := log.FromContext(ctx)
+
+	if eventType(envelope) == messaging.EventTypeDeleted {
+		log.V(1).Info("ignoring deleted resource", "id", envelope.ResourceID)
+		return nil
+	}
+
+	// Producers that predate labels send none at all, as opposed to an empty
+	// set, so don't mistake that for the labels having been removed.
+	if envelope.Labels == nil {
+		log.V(1).Info("ignoring resource without labels", "id", envelope.ResourceID)
+		return nil
+	}
+
+	opts := &client.ListOptions{
+		Namespace: c.namespace,
+		LabelSelector: labels.SelectorFromSet(map[string]string{
+			c.resourceLabel: envelope.ResourceID,
+		}),
+	}
+
+	if err := c.client.List(ctx, c.resources, opts); err != nil {
+		return err
+	}
+
+	updateItem := func(object runtime.Object) error {
+		resource, ok := object.(client.Object)
+		if !ok {
+			return fmt.Errorf("%w: cannot convert from runtime object to client", errors.ErrTypeConversion)
+		}
+
+		return c.update(ctx, resource, envelope.Labels)
+	}
+
+	return meta.EachListItem(c.resources, updateItem)
+}
+
+// update synchronizes the resource's labels, doing nothing if they are
+// already correct.
+func (c *LabelPropagation) update(ctx context.Context, resource client.Object, source map[string]string) error {
+	current := resource.GetLabels()
+
+	desired := maps.Clone(current)
+	if desired == nil {
+		desired = map[string]string{}
+	}
+
+	for _, key := range c.keys {
+		if value, ok := source[key]; ok {
+			desired[key] = value
+		} else {
+			delete(desired, key)
+		}
+	}
+
+	if maps.Equal(current, desired) {
+		return nil
+	}
+
+	log.FromContext(ctx).Info("updating resource labels", "id", resource.GetName())
+
+	base, ok := resource.DeepCopyObject().(client.Object)
+	if !ok {
+		return fmt.Errorf("%w: cannot convert from runtime object to client", errors.ErrTypeConversion)
+	}
+
+	resource.SetLabels(desired)
+
+	return c.client.Patch(ctx, resource, client.MergeFrom(base))
+}
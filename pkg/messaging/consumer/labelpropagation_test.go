@@ -0,0 +1,175 @@
+/*
+Copyright 2026 Nscale.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package consumer_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/unikorn-cloud/core/pkg/messaging"
+	"github.com/unikorn-cloud/core/pkg/messaging/consumer"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+const (
+	propagatedLabel = "test.unikorn-cloud.org/name"
+)
+
+// patchCountingClient returns a client that counts patches.
+func patchCountingClient(t *testing.T, patches *int, objects ...client.Object) client.Client {
+	t.Helper()
+
+	funcs := interceptor.Funcs{
+		Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+			*patches++
+
+			return c.Patch(ctx, obj, patch, opts...)
+		},
+	}
+
+	return fake.NewClientBuilder().
+		WithScheme(mustNewScheme(t)).
+		WithObjects(objects...).
+		WithInterceptorFuncs(funcs).
+		Build()
+}
+
+func labelsOf(t *testing.T, cli client.Client, name string) map[string]string {
+	t.Helper()
+
+	resource := &corev1.ConfigMap{}
+	require.NoError(t, cli.Get(t.Context(), client.ObjectKey{Namespace: namespace, Name: name}, resource))
+
+	return resource.Labels
+}
+
+// TestLabelPropagationCreate checks labels are added to referencing resources only.
+func TestLabelPropagationCreate(t *testing.T) {
+	t.Parallel()
+
+	other := configMap("c")
+	other.Labels[resourceLabel] = "other"
+
+	var patches int
+
+	cli := patchCountingClient(t, &patches, configMap("a"), configMap("b"), other)
+
+	c := consumer.NewLabelPropagation(cli, &corev1.ConfigMapList{}, namespace, resourceLabel, propagatedLabel)
+
+	envelope := &messaging.Envelope{
+		ResourceID: resourceID,
+		EventType:  messaging.EventTypeCreated,
+		Labels: map[string]string{
+			propagatedLabel: "foo",
+		},
+	}
+
+	require.NoError(t, c.Consume(t.Context(), envelope))
+	require.Equal(t, 2, patches)
+	require.Equal(t, "foo", labelsOf(t, cli, "a")[propagatedLabel])
+	require.Equal(t, "foo", labelsOf(t, cli, "b")[propagatedLabel])
+	require.NotContains(t, labelsOf(t, cli, "c"), propagatedLabel)
+
+	// Replaying the event is a no-op.
+	require.NoError(t, c.Consume(t.Context(), envelope))
+	require.Equal(t, 2, patches)
+}
+
+// TestLabelPropagationUpdate checks labels are changed and removed to match the resource.
+func TestLabelPropagationUpdate(t *testing.T) {
+	t.Parallel()
+
+	const removedLabel = "test.unikorn-cloud.org/removed"
+
+	resource := configMap("a")
+	resource.Labels[propagatedLabel] = "foo"
+	resource.Labels[removedLabel] = "bar"
+
+	cli := fake.NewClientBuilder().
+		WithScheme(mustNewScheme(t)).
+		WithObjects(resource).
+		Build()
+
+	c := consumer.NewLabelPropagation(cli, &corev1.ConfigMapList{}, namespace, resourceLabel, propagatedLabel, removedLabel)
+
+	envelope := &messaging.Envelope{
+		ResourceID: resourceID,
+		EventType:  messaging.EventTypeUpdated,
+		Labels: map[string]string{
+			propagatedLabel: "baz",
+		},
+	}
+
+	require.NoError(t, c.Consume(t.Context(), envelope))
+
+	expected := map[string]string{
+		resourceLabel:   resourceID,
+		propagatedLabel: "baz",
+	}
+
+	require.Equal(t, expected, labelsOf(t, cli, "a"))
+}
+
+// TestLabelPropagationNoLabels checks labels are preserved when the envelope
+// carries none, e.g. from a producer that does not propagate them.
+func TestLabelPropagationNoLabels(t *testing.T) {
+	t.Parallel()
+
+	resource := configMap("a")
+	resource.Labels[propagatedLabel] = "foo"
+
+	var patches int
+
+	cli := patchCountingClient(t, &patches, resource)
+
+	c := consumer.NewLabelPropagation(cli, &corev1.ConfigMapList{}, namespace, resourceLabel, propagatedLabel)
+
+	envelope := &messaging.Envelope{
+		ResourceID: resourceID,
+		EventType:  messaging.EventTypeUpdated,
+	}
+
+	require.NoError(t, c.Consume(t.Context(), envelope))
+	require.Zero(t, patches)
+	require.Equal(t, "foo", labelsOf(t, cli, "a")[propagatedLabel])
+}
+
+// TestLabelPropagationDelete checks deletion events are ignored.
+func TestLabelPropagationDelete(t *testing.T) {
+	t.Parallel()
+
+	var patches int
+
+	cli := patchCountingClient(t, &patches, configMap("a"))
+
+	c := consumer.NewLabelPropagation(cli, &corev1.ConfigMapList{}, namespace, resourceLabel, propagatedLabel)
+
+	envelope := deletionEnvelope()
+	envelope.Labels = map[string]string{
+		propagatedLabel: "foo",
+	}
+
+	require.NoError(t, c.Consume(t.Context(), envelope))
+	require.Zero(t, patches)
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2026 Nscale.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package messaging
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/propagation"
+
+	"github.com/unikorn-cloud/core/pkg/constants"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// correlationKeys map from trace context propagation fields to the annotations
+// they are stored in.
+//
+//nolint:gochecknoglobals // maps cannot be constant
+var correlationKeys = map[string]string{
+	"traceparent": constants.TraceParentAnnotation,
+	"tracestate":  constants.TraceStateAnnotation,
+}
+
+// propagator is used explicitly rather than the global propagator, which may
+// not be configured, as correlation must survive regardless.
+//
+//nolint:gochecknoglobals // stateless, and shared
+var propagator = propagation.TraceContext{}
+
+// SetCorrelationAnnotations records the trace context of the producing request
+// on a resource, this should be called on create, update and before deletion.
+func SetCorrelationAnnotations(ctx context.Context, object metav1.Object) {
+	carrier := propagation.MapCarrier{}
+
+	propagator.Inject(ctx, carrier)
+
+	if len(carrier) == 0 {
+		return
+	}
+
+	annotations := object.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+
+	for field, annotation := range correlationKeys {
+		if value, ok := carrier[field]; ok {
+			annotations[annotation] = value
+		} else {
+			delete(annotations, annotation)
+		}
+	}
+
+	object.SetAnnotations(annotations)
+}
+
+// CorrelationFromAnnotations returns any correlation metadata recorded on a
+// resource by SetCorrelationAnnotations.
+func CorrelationFromAnnotations(object metav1.Object) map[string]string {
+	annotations := object.GetAnnotations()
+
+	var correlation map[string]string
+
+	for field, annotation := range correlationKeys {
+		if value, ok := annotations[annotation]; ok {
+			if correlation == nil {
+				correlation = map[string]string{}
+			}
+
+			correlation[field] = value
+		}
+	}
+
+	return correlation
+}
+
+// InjectCorrelation attaches the trace context from the producing context to
+// the envelope.
+func InjectCorrelation(ctx context.Context, envelope *Envelope) {
+	carrier := propagation.MapCarrier{}
+
+	propagator.Inject(ctx, carrier)
+
+	if len(carrier) == 0 {
+		return
+	}
+
+	envelope.Correlation = carrier
+}
+
+// ContextWithCorrelation restores any trace context carried by the envelope
+// so any spans created by the consumer are part of the originating trace.
+func ContextWithCorrelation(ctx context.Context, envelope *Envelope) context.Context {
+	if len(envelope.Correlation) == 0 {
+		return ctx
+	}
+
+	return propagator.Extract(ctx, propagation.MapCarrier(envelope.Correlation))
+}
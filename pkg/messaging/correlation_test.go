@@ -0,0 +1,98 @@
+/*
+Copyright 2026 Nscale.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package messaging_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/unikorn-cloud/core/pkg/messaging"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// newTraceContext returns a context with a known remote span.
+func newTraceContext(t *testing.T) (context.Context, trace.SpanContext) {
+	t.Helper()
+
+	spanContext := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x01, 0x02, 0x03, 0x04},
+		SpanID:     trace.SpanID{0x05, 0x06, 0x07, 0x08},
+		TraceFlags: trace.FlagsSampled,
+		Remote:     true,
+	})
+
+	return trace.ContextWithSpanContext(t.Context(), spanContext), spanContext
+}
+
+// TestCorrelationEnvelope tests trace context survives a round trip through
+// the envelope.
+func TestCorrelationEnvelope(t *testing.T) {
+	t.Parallel()
+
+	ctx, spanContext := newTraceContext(t)
+
+	envelope := &messaging.Envelope{}
+
+	messaging.InjectCorrelation(ctx, envelope)
+	require.NotEmpty(t, envelope.Correlation)
+
+	restored := trace.SpanContextFromContext(messaging.ContextWithCorrelation(t.Context(), envelope))
+	require.Equal(t, spanContext.TraceID(), restored.TraceID())
+	require.Equal(t, spanContext.SpanID(), restored.SpanID())
+	require.True(t, restored.IsSampled())
+}
+
+// TestCorrelationAnnotations tests trace context survives a round trip through
+// resource annotations.
+func TestCorrelationAnnotations(t *testing.T) {
+	t.Parallel()
+
+	ctx, spanContext := newTraceContext(t)
+
+	object := &corev1.ConfigMap{}
+
+	messaging.SetCorrelationAnnotations(ctx, object)
+
+	envelope := &messaging.Envelope{
+		Correlation: messaging.CorrelationFromAnnotations(object),
+	}
+
+	restored := trace.SpanContextFromContext(messaging.ContextWithCorrelation(t.Context(), envelope))
+	require.Equal(t, spanContext.TraceID(), restored.TraceID())
+}
+
+// TestCorrelationNone tests no trace context results in no correlation metadata.
+func TestCorrelationNone(t *testing.T) {
+	t.Parallel()
+
+	object := &corev1.ConfigMap{}
+
+	messaging.SetCorrelationAnnotations(t.Context(), object)
+	require.Nil(t, messaging.CorrelationFromAnnotations(object))
+
+	envelope := &messaging.Envelope{}
+
+	messaging.InjectCorrelation(t.Context(), envelope)
+	require.Nil(t, envelope.Correlation)
+
+	ctx := t.Context()
+	require.Equal(t, ctx, messaging.ContextWithCorrelation(ctx, envelope))
+}
@@ -26,6 +26,14 @@ type Consumer interface {
 	Consume(ctx context.Context, envelope *Envelope) error
 }
 
+// DeadLetter receives envelopes that could not be consumed, after a queue
+// has exhausted its retries, so the queue can make progress.
+type DeadLetter interface {
+	// DeadLetter records the envelope and the last consumer error.  If this
+	// returns an error the queue will continue to retry the event.
+	DeadLetter(ctx context.Context, envelope *Envelope, err error) error
+}
+
 // Queue is an abstract message queue client, the exact implementation
 // is defined by the implementation.  A queue must always replay all active
 // resources, so we can witness missed events on a restart.  If an error is
@@ -21,17 +21,22 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"os"
 	"path/filepath"
+	"sync"
 
 	"github.com/unikorn-cloud/core/pkg/messaging"
+	"github.com/unikorn-cloud/core/pkg/util/readiness"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/rest"
 
 	cr "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 	crmanager "sigs.k8s.io/controller-runtime/pkg/manager"
 )
 
@@ -43,21 +48,59 @@ type MessageQueue struct {
 	scheme    *runtime.Scheme
 	prototype client.Object
 	consumers []messaging.Consumer
+
+	// maxFailures is the number of consecutive failures for a resource
+	// before it is sent to the dead letter sink.
+	maxFailures int
+	// deadLetter, if set, receives events that repeatedly fail.
+	deadLetter messaging.DeadLetter
+	// failures counts consecutive failures per resource.
+	failures     map[types.NamespacedName]int
+	failuresLock sync.Mutex
+
+	// ready reports when the queue is running.
+	ready     readiness.Checker
+	readyLock sync.Mutex
+}
+
+// Option defines a set of runtime composable options.
+type Option func(q *MessageQueue)
+
+// WithDeadLetter stops retrying an event once consumers have failed maxFailures
+// times in a row for a resource.  The event is passed to the sink and acknowledged
+// so that a single poison message cannot wedge the queue.
+func WithDeadLetter(maxFailures int, sink messaging.DeadLetter) Option {
+	return func(q *MessageQueue) {
+		q.maxFailures = maxFailures
+		q.deadLetter = sink
+	}
 }
 
-func New(config *rest.Config, scheme *runtime.Scheme, object client.Object) *MessageQueue {
-	return &MessageQueue{
+func New(config *rest.Config, scheme *runtime.Scheme, object client.Object, options ...Option) *MessageQueue {
+	q := &MessageQueue{
 		config:    config,
 		scheme:    scheme,
 		prototype: object,
 	}
+
+	for _, o := range options {
+		o(q)
+	}
+
+	return q
 }
 
 // NewForManager creates a queue that can be registered with an existing manager.
-func NewForManager(object client.Object) *MessageQueue {
-	return &MessageQueue{
+func NewForManager(object client.Object, options ...Option) *MessageQueue {
+	q := &MessageQueue{
 		prototype: object,
 	}
+
+	for _, o := range options {
+		o(q)
+	}
+
+	return q
 }
 
 var _ = messaging.Queue(&MessageQueue{})
@@ -88,11 +131,41 @@ func (q *MessageQueue) SetupWithManager(manager crmanager.Manager, consumers ...
 	q.consumers = consumers
 	q.Client = manager.GetClient()
 
+	q.readyLock.Lock()
+	q.ready = readiness.Elected(manager)
+	q.readyLock.Unlock()
+
 	return cr.NewControllerManagedBy(manager).
 		For(q.prototype).
 		Complete(q)
 }
 
+// Ready returns true once the queue is consuming events, this is when the manager
+// has been elected leader.
+func (q *MessageQueue) Ready() bool {
+	q.readyLock.Lock()
+	defer q.readyLock.Unlock()
+
+	return q.ready != nil && q.ready.Ready()
+}
+
+// eventType infers the lifecycle event from the object.  Reconciliation is level
+// triggered so we cannot see the actual event, instead an object whose spec has
+// never been modified is considered created.  Status updates and replays will
+// therefore redeliver created events, which consumers must tolerate.  Types that
+// do not track generation, e.g. core types, only ever report updates.
+func eventType(object client.Object) messaging.EventType {
+	if object.GetDeletionTimestamp() != nil {
+		return messaging.EventTypeDeleted
+	}
+
+	if object.GetGeneration() == 1 {
+		return messaging.EventTypeCreated
+	}
+
+	return messaging.EventTypeUpdated
+}
+
 func (q *MessageQueue) Reconcile(ctx context.Context, request cr.Request) (cr.Result, error) {
 	object, ok := q.prototype.DeepCopyObject().(client.Object)
 	if !ok {
@@ -101,6 +174,8 @@ func (q *MessageQueue) Reconcile(ctx context.Context, request cr.Request) (cr.Re
 
 	if err := q.Get(ctx, request.NamespacedName, object); err != nil {
 		if apierrors.IsNotFound(err) {
+			q.resetFailures(request.NamespacedName)
+
 			return cr.Result{}, nil
 		}
 
@@ -108,18 +183,68 @@ func (q *MessageQueue) Reconcile(ctx context.Context, request cr.Request) (cr.Re
 	}
 
 	envelope := &messaging.Envelope{
-		ResourceID: object.GetName(),
+		ResourceID:  object.GetName(),
+		EventType:   eventType(object),
+		Labels:      maps.Clone(object.GetLabels()),
+		Correlation: messaging.CorrelationFromAnnotations(object),
 	}
 
 	if t := object.GetDeletionTimestamp(); t != nil {
 		envelope.DeletionTimestamp = &t.Time
 	}
 
+	// Consumers continue the trace of the request that triggered the event.
+	ctx = messaging.ContextWithCorrelation(ctx, envelope)
+
 	for _, consumer := range q.consumers {
 		if err := consumer.Consume(ctx, envelope); err != nil {
-			return cr.Result{}, err
+			return cr.Result{}, q.handleFailure(ctx, request.NamespacedName, envelope, err)
 		}
 	}
 
+	q.resetFailures(request.NamespacedName)
+
 	return cr.Result{}, nil
 }
+
+// resetFailures forgets any failures recorded for a resource.
+func (q *MessageQueue) resetFailures(key types.NamespacedName) {
+	q.failuresLock.Lock()
+	defer q.failuresLock.Unlock()
+
+	delete(q.failures, key)
+}
+
+// handleFailure records a consumer failure and returns an error if the event
+// should be retried.  Once the failure limit is reached the event is sent to
+// the dead letter sink and nil is returned to acknowledge it.
+func (q *MessageQueue) handleFailure(ctx context.Context, key types.NamespacedName, envelope *messaging.Envelope, err error) error {
+	if q.deadLetter == nil {
+		return err
+	}
+
+	q.failuresLock.Lock()
+	defer q.failuresLock.Unlock()
+
+	if q.failures == nil {
+		q.failures = map[types.NamespacedName]int{}
+	}
+
+	q.failures[key]++
+
+	if q.failures[key] < q.maxFailures {
+		return err
+	}
+
+	log.FromContext(ctx).Error(err, "retries exhausted, sending to dead letter sink", "id", envelope.ResourceID, "failures", q.failures[key])
+
+	// Keep the failure count on error so the next attempt goes straight
+	// back to the sink.
+	if dlErr := q.deadLetter.DeadLetter(ctx, envelope, err); dlErr != nil {
+		return errors.Join(err, dlErr)
+	}
+
+	delete(q.failures, key)
+
+	return nil
+}
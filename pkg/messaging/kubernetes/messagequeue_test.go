@@ -23,8 +23,10 @@ import (
 	"time"
 
 	"github.com/go-logr/logr"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/mock/gomock"
 
+	"github.com/unikorn-cloud/core/pkg/constants"
 	mockmanager "github.com/unikorn-cloud/core/pkg/manager/mock"
 	"github.com/unikorn-cloud/core/pkg/messaging"
 	"github.com/unikorn-cloud/core/pkg/messaging/kubernetes"
@@ -55,16 +57,30 @@ func (c *errorClient) Get(context.Context, client.ObjectKey, client.Object, ...c
 }
 
 type recordingConsumer struct {
-	envelopes []*messaging.Envelope
-	err       error
+	envelopes    []*messaging.Envelope
+	spanContexts []trace.SpanContext
+	err          error
 }
 
-func (c *recordingConsumer) Consume(_ context.Context, envelope *messaging.Envelope) error {
+func (c *recordingConsumer) Consume(ctx context.Context, envelope *messaging.Envelope) error {
 	c.envelopes = append(c.envelopes, envelope)
+	c.spanContexts = append(c.spanContexts, trace.SpanContextFromContext(ctx))
 
 	return c.err
 }
 
+type recordingDeadLetter struct {
+	envelopes []*messaging.Envelope
+	errs      []error
+}
+
+func (d *recordingDeadLetter) DeadLetter(ctx context.Context, envelope *messaging.Envelope, err error) error {
+	d.envelopes = append(d.envelopes, envelope)
+	d.errs = append(d.errs, err)
+
+	return nil
+}
+
 func mustNewScheme(t *testing.T) *runtime.Scheme {
 	t.Helper()
 
@@ -99,8 +115,23 @@ func setupQueueWithManager(t *testing.T, consumer messaging.Consumer, objects ..
 		WithScheme(scheme).
 		WithObjects(objects...).
 		Build()
+
+	q := kubernetes.NewForManager(&corev1.ConfigMap{})
+	if err := q.SetupWithManager(setupManager(t, cli, scheme), consumer); err != nil {
+		t.Fatal(err)
+	}
+
+	return q
+}
+
+func setupManager(t *testing.T, cli client.Client, scheme *runtime.Scheme) *mockmanager.MockManager {
+	t.Helper()
+
 	skipNameValidation := true
 
+	elected := make(chan struct{})
+	close(elected)
+
 	manager := mockmanager.NewMockManager(gomock.NewController(t))
 	manager.EXPECT().GetClient().Return(cli)
 	manager.EXPECT().GetControllerOptions().Return(ctrlconfig.Controller{
@@ -110,13 +141,9 @@ func setupQueueWithManager(t *testing.T, consumer messaging.Consumer, objects ..
 	manager.EXPECT().GetLogger().Return(logr.Discard()).AnyTimes()
 	manager.EXPECT().Add(gomock.Any()).Return(nil)
 	manager.EXPECT().GetCache().Return(nil)
+	manager.EXPECT().Elected().Return(elected).AnyTimes()
 
-	q := kubernetes.NewForManager(&corev1.ConfigMap{})
-	if err := q.SetupWithManager(manager, consumer); err != nil {
-		t.Fatal(err)
-	}
-
-	return q
+	return manager
 }
 
 func TestSetupWithManagerDeliversEnvelopeFromFetchedObject(t *testing.T) {
@@ -129,6 +156,9 @@ func TestSetupWithManagerDeliversEnvelopeFromFetchedObject(t *testing.T) {
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      name,
 			Namespace: metav1.NamespaceDefault,
+			Labels: map[string]string{
+				"foo": "bar",
+			},
 		},
 	})
 
@@ -148,6 +178,14 @@ func TestSetupWithManagerDeliversEnvelopeFromFetchedObject(t *testing.T) {
 	if got := consumer.envelopes[0].ResourceID; got != name {
 		t.Fatalf("expected resource ID %q, got %q", name, got)
 	}
+
+	if got := consumer.envelopes[0].EventType; got != messaging.EventTypeUpdated {
+		t.Fatalf("expected event type %q, got %q", messaging.EventTypeUpdated, got)
+	}
+
+	if got := consumer.envelopes[0].Labels["foo"]; got != "bar" {
+		t.Fatalf("expected label %q, got %q", "bar", got)
+	}
 }
 
 func TestSetupWithManagerDeliversDeletionTimestampFromFetchedObject(t *testing.T) {
@@ -187,6 +225,59 @@ func TestSetupWithManagerDeliversDeletionTimestampFromFetchedObject(t *testing.T
 	if !got.Equal(deletionTimestamp.Time) {
 		t.Fatalf("expected deletion timestamp %s, got %s", deletionTimestamp.Time, *got)
 	}
+
+	if got := consumer.envelopes[0].EventType; got != messaging.EventTypeDeleted {
+		t.Fatalf("expected event type %q, got %q", messaging.EventTypeDeleted, got)
+	}
+}
+
+func TestSetupWithManagerRestoresCorrelationFromFetchedObject(t *testing.T) {
+	t.Parallel()
+
+	const (
+		name    = "resource"
+		traceID = "0102030405060708090a0b0c0d0e0f10"
+	)
+
+	consumer := &recordingConsumer{}
+	q := setupQueueWithManager(t, consumer, &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: metav1.NamespaceDefault,
+			Annotations: map[string]string{
+				constants.TraceParentAnnotation: "00-" + traceID + "-0102030405060708-01",
+			},
+		},
+	})
+
+	if _, err := q.Reconcile(t.Context(), cr.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      name,
+			Namespace: metav1.NamespaceDefault,
+		},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(consumer.spanContexts) != 1 {
+		t.Fatalf("expected 1 envelope, got %d", len(consumer.spanContexts))
+	}
+
+	if got := consumer.spanContexts[0].TraceID().String(); got != traceID {
+		t.Fatalf("expected trace ID %q, got %q", traceID, got)
+	}
+}
+
+func TestReadyOnceRunning(t *testing.T) {
+	t.Parallel()
+
+	if kubernetes.NewForManager(&corev1.ConfigMap{}).Ready() {
+		t.Fatal("expected queue not to be ready before setup")
+	}
+
+	if !setupQueueWithManager(t, &recordingConsumer{}).Ready() {
+		t.Fatal("expected queue to be ready once elected")
+	}
 }
 
 func TestReconcileReturnsConsumerError(t *testing.T) {
@@ -328,3 +419,69 @@ func TestReconcilePropagatesClientErrors(t *testing.T) {
 		t.Fatalf("expected %v, got %v", errClientFailed, err)
 	}
 }
+
+func TestReconcileDeadLettersAfterMaxFailures(t *testing.T) {
+	t.Parallel()
+
+	const (
+		name        = "resource"
+		maxFailures = 3
+	)
+
+	scheme := mustNewScheme(t)
+	consumer := &recordingConsumer{err: errConsumerFailed}
+	deadLetter := &recordingDeadLetter{}
+
+	cli := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: metav1.NamespaceDefault,
+			},
+		}).
+		Build()
+
+	q := kubernetes.NewForManager(&corev1.ConfigMap{}, kubernetes.WithDeadLetter(maxFailures, deadLetter))
+	if err := q.SetupWithManager(setupManager(t, cli, scheme), consumer); err != nil {
+		t.Fatal(err)
+	}
+
+	request := cr.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      name,
+			Namespace: metav1.NamespaceDefault,
+		},
+	}
+
+	for range maxFailures - 1 {
+		if _, err := q.Reconcile(t.Context(), request); !errors.Is(err, errConsumerFailed) {
+			t.Fatalf("expected %v, got %v", errConsumerFailed, err)
+		}
+	}
+
+	if len(deadLetter.envelopes) != 0 {
+		t.Fatalf("expected no dead letters before max failures, got %d", len(deadLetter.envelopes))
+	}
+
+	if _, err := q.Reconcile(t.Context(), request); err != nil {
+		t.Fatalf("expected dead lettered event to be acknowledged, got %v", err)
+	}
+
+	if len(deadLetter.envelopes) != 1 {
+		t.Fatalf("expected 1 dead letter, got %d", len(deadLetter.envelopes))
+	}
+
+	if got := deadLetter.envelopes[0].ResourceID; got != name {
+		t.Fatalf("expected resource ID %q, got %q", name, got)
+	}
+
+	if !errors.Is(deadLetter.errs[0], errConsumerFailed) {
+		t.Fatalf("expected %v, got %v", errConsumerFailed, deadLetter.errs[0])
+	}
+
+	// The failure count is reset, so the next failure is retried.
+	if _, err := q.Reconcile(t.Context(), request); !errors.Is(err, errConsumerFailed) {
+		t.Fatalf("expected %v, got %v", errConsumerFailed, err)
+	}
+}
@@ -0,0 +1,267 @@
+/*
+Copyright 2026 Nscale.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nats
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/spf13/pflag"
+
+	"github.com/unikorn-cloud/core/pkg/messaging"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var (
+	// ErrInvalidOptions is raised when the queue options are invalid.
+	ErrInvalidOptions = errors.New("invalid NATS options")
+)
+
+// Message is the wire format of resource messages.
+type Message struct {
+	// ResourceID the GUID of a resource.
+	ResourceID string `json:"resourceId"`
+	// EventType describes the lifecycle event.  If not set this is inferred
+	// from the deletion timestamp.
+	EventType messaging.EventType `json:"eventType,omitempty"`
+	// DeletionTimestamp is set when the resource is being deleted.
+	DeletionTimestamp *time.Time `json:"deletionTimestamp,omitempty"`
+	// Labels are propagated from the source resource.
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+const (
+	// nakDelayBase is how long to wait before redelivering a message after
+	// its first failure, this doubles with each subsequent delivery.
+	nakDelayBase = time.Second
+	// nakDelayMax bounds redelivery delays so retries are never starved.
+	nakDelayMax = time.Minute
+)
+
+// correlationHeaders are the message headers that carry W3C trace context.
+func correlationHeaders() []string {
+	return []string{
+		"traceparent",
+		"tracestate",
+	}
+}
+
+// Publish sends an envelope to the subject, along with any correlation data.
+// For replay to work as intended, the subject should be unique to the resource.
+func Publish(ctx context.Context, js jetstream.JetStream, subject string, envelope *messaging.Envelope) error {
+	data, err := json.Marshal(&Message{
+		ResourceID:        envelope.ResourceID,
+		EventType:         envelope.EventType,
+		DeletionTimestamp: envelope.DeletionTimestamp,
+		Labels:            envelope.Labels,
+	})
+	if err != nil {
+		return err
+	}
+
+	msg := nats.NewMsg(subject)
+	msg.Data = data
+
+	for _, key := range correlationHeaders() {
+		if value, ok := envelope.Correlation[key]; ok {
+			msg.Header.Set(key, value)
+		}
+	}
+
+	if _, err := js.PublishMsg(ctx, msg); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Options defines how to connect to and consume from JetStream.
+type Options struct {
+	// URL is the NATS server URL.
+	URL string
+	// Stream is the JetStream stream to consume from.
+	Stream string
+	// Subject filters the messages consumed from the stream e.g. "projects.>".
+	Subject string
+	// Durable is the name of the durable consumer, all replicas of a service
+	// share this, so each message is delivered to a single replica.
+	Durable string
+}
+
+func (o *Options) AddFlags(f *pflag.FlagSet) {
+	f.StringVar(&o.URL, "nats-url", nats.DefaultURL, "NATS server URL.")
+	f.StringVar(&o.Stream, "nats-stream", "", "JetStream stream to consume resource messages from.")
+	f.StringVar(&o.Subject, "nats-subject", "", "Subject filter for resource messages.")
+	f.StringVar(&o.Durable, "nats-durable", "", "Durable consumer name shared by all replicas.")
+}
+
+// Validate checks the required options are set, so misconfiguration is
+// reported up front rather than when the consumer is created.
+func (o *Options) Validate() error {
+	if o.Stream == "" {
+		return fmt.Errorf("%w: stream must be specified", ErrInvalidOptions)
+	}
+
+	if o.Durable == "" {
+		return fmt.Errorf("%w: durable consumer name must be specified", ErrInvalidOptions)
+	}
+
+	return nil
+}
+
+// MessageQueue implements a message queue using a NATS JetStream consumer.
+type MessageQueue struct {
+	options *Options
+}
+
+func New(options *Options) *MessageQueue {
+	return &MessageQueue{
+		options: options,
+	}
+}
+
+var _ = messaging.Queue(&MessageQueue{})
+
+// Run consumes messages until the context is cancelled.  When the durable consumer
+// is first created, consumption starts from the last message for each subject, so
+// when subjects are unique to resources, all active resources are replayed.  An
+// existing durable consumer resumes from its acknowledgement floor instead.
+func (q *MessageQueue) Run(ctx context.Context, consumers ...messaging.Consumer) error {
+	if err := q.options.Validate(); err != nil {
+		return err
+	}
+
+	conn, err := nats.Connect(q.options.URL)
+	if err != nil {
+		return err
+	}
+
+	defer conn.Close()
+
+	js, err := jetstream.New(conn)
+	if err != nil {
+		return err
+	}
+
+	config := jetstream.ConsumerConfig{
+		Durable:       q.options.Durable,
+		FilterSubject: q.options.Subject,
+		DeliverPolicy: jetstream.DeliverLastPerSubjectPolicy,
+		AckPolicy:     jetstream.AckExplicitPolicy,
+	}
+
+	consumer, err := js.CreateOrUpdateConsumer(ctx, q.options.Stream, config)
+	if err != nil {
+		return err
+	}
+
+	consumeContext, err := consumer.Consume(q.Handler(ctx, consumers...))
+	if err != nil {
+		return err
+	}
+
+	defer consumeContext.Stop()
+
+	<-ctx.Done()
+
+	return nil
+}
+
+// nakDelay returns how long to wait before redelivery of a failed message, this
+// backs off exponentially with the number of deliveries to avoid hot looping.
+func nakDelay(msg jetstream.Msg) time.Duration {
+	delay := nakDelayBase
+
+	metadata, err := msg.Metadata()
+	if err != nil {
+		return delay
+	}
+
+	for i := uint64(1); i < metadata.NumDelivered && delay < nakDelayMax; i++ {
+		delay *= 2
+	}
+
+	return min(delay, nakDelayMax)
+}
+
+// Handler returns a JetStream message handler that decodes messages and fans
+// them out to the consumers.  Messages are acknowledged once all consumers have
+// succeeded, otherwise they are requeued with a bounded backoff.  Messages that
+// cannot be decoded will never succeed, so are terminated.
+func (q *MessageQueue) Handler(ctx context.Context, consumers ...messaging.Consumer) jetstream.MessageHandler {
+	return func(msg jetstream.Msg) {
+		log := log.FromContext(ctx).WithValues("subject", msg.Subject())
+
+		var message Message
+
+		if err := json.Unmarshal(msg.Data(), &message); err != nil {
+			log.Error(err, "failed to decode message")
+
+			if err := msg.Term(); err != nil {
+				log.Error(err, "failed to terminate message")
+			}
+
+			return
+		}
+
+		envelope := &messaging.Envelope{
+			ResourceID:        message.ResourceID,
+			EventType:         message.EventType,
+			DeletionTimestamp: message.DeletionTimestamp,
+			Labels:            message.Labels,
+		}
+
+		if envelope.EventType == "" {
+			envelope.EventType = messaging.EventTypeFromDeletionTimestamp(envelope.DeletionTimestamp)
+		}
+
+		for _, key := range correlationHeaders() {
+			if value := msg.Headers().Get(key); value != "" {
+				if envelope.Correlation == nil {
+					envelope.Correlation = map[string]string{}
+				}
+
+				envelope.Correlation[key] = value
+			}
+		}
+
+		// Consumers continue the trace of the request that triggered the event.
+		ctx := messaging.ContextWithCorrelation(ctx, envelope)
+
+		for _, consumer := range consumers {
+			if err := consumer.Consume(ctx, envelope); err != nil {
+				log.Error(err, "consumer failed", "resourceID", envelope.ResourceID)
+
+				if err := msg.NakWithDelay(nakDelay(msg)); err != nil {
+					log.Error(err, "failed to requeue message")
+				}
+
+				return
+			}
+		}
+
+		if err := msg.Ack(); err != nil {
+			log.Error(err, "failed to acknowledge message")
+		}
+	}
+}
@@ -0,0 +1,260 @@
+/*
+Copyright 2026 Nscale.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nats_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	natsio "github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/unikorn-cloud/core/pkg/messaging"
+	"github.com/unikorn-cloud/core/pkg/messaging/nats"
+)
+
+var (
+	errConsumerFailed = errors.New("consumer failed")
+)
+
+const (
+	traceParent = "00-0102030405060708090a0b0c0d0e0f10-0102030405060708-01"
+)
+
+// fakeMsg records how a message was acknowledged.
+type fakeMsg struct {
+	jetstream.Msg
+
+	data      []byte
+	headers   natsio.Header
+	delivered uint64
+
+	acked      bool
+	naked      bool
+	nakDelay   time.Duration
+	terminated bool
+}
+
+func (m *fakeMsg) Subject() string {
+	return "projects.foo"
+}
+
+func (m *fakeMsg) Data() []byte {
+	return m.data
+}
+
+func (m *fakeMsg) Headers() natsio.Header {
+	return m.headers
+}
+
+func (m *fakeMsg) Ack() error {
+	m.acked = true
+	return nil
+}
+
+func (m *fakeMsg) Metadata() (*jetstream.MsgMetadata, error) {
+	return &jetstream.MsgMetadata{
+		NumDelivered: m.delivered,
+	}, nil
+}
+
+func (m *fakeMsg) NakWithDelay(delay time.Duration) error {
+	m.naked = true
+	m.nakDelay = delay
+
+	return nil
+}
+
+func (m *fakeMsg) Term() error {
+	m.terminated = true
+	return nil
+}
+
+// fakeJetStream records published messages.
+type fakeJetStream struct {
+	jetstream.JetStream
+
+	published []*natsio.Msg
+}
+
+func (j *fakeJetStream) PublishMsg(ctx context.Context, msg *natsio.Msg, opts ...jetstream.PublishOpt) (*jetstream.PubAck, error) {
+	j.published = append(j.published, msg)
+
+	return &jetstream.PubAck{}, nil
+}
+
+type recordingConsumer struct {
+	envelopes    []*messaging.Envelope
+	spanContexts []trace.SpanContext
+	err          error
+}
+
+func (c *recordingConsumer) Consume(ctx context.Context, envelope *messaging.Envelope) error {
+	c.envelopes = append(c.envelopes, envelope)
+	c.spanContexts = append(c.spanContexts, trace.SpanContextFromContext(ctx))
+
+	return c.err
+}
+
+// TestHandler tests messages are decoded, passed to consumers, and acknowledged.
+func TestHandler(t *testing.T) {
+	t.Parallel()
+
+	consumer := &recordingConsumer{}
+
+	msg := &fakeMsg{
+		data: []byte(`{"resourceId":"foo","deletionTimestamp":"2026-01-01T00:00:00Z"}`),
+		headers: natsio.Header{
+			"traceparent": []string{traceParent},
+		},
+	}
+
+	nats.New(&nats.Options{}).Handler(t.Context(), consumer)(msg)
+
+	require.True(t, msg.acked)
+	require.False(t, msg.naked)
+	require.Len(t, consumer.envelopes, 1)
+
+	envelope := consumer.envelopes[0]
+	require.Equal(t, "foo", envelope.ResourceID)
+	require.Equal(t, messaging.EventTypeDeleted, envelope.EventType)
+	require.NotNil(t, envelope.DeletionTimestamp)
+	require.True(t, envelope.DeletionTimestamp.Equal(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)))
+	require.Equal(t, map[string]string{"traceparent": traceParent}, envelope.Correlation)
+	require.Equal(t, "0102030405060708090a0b0c0d0e0f10", consumer.spanContexts[0].TraceID().String())
+}
+
+// TestHandlerConsumerError tests messages are requeued on consumer failure.
+func TestHandlerConsumerError(t *testing.T) {
+	t.Parallel()
+
+	consumer := &recordingConsumer{
+		err: errConsumerFailed,
+	}
+
+	msg := &fakeMsg{
+		data:      []byte(`{"resourceId":"foo"}`),
+		delivered: 1,
+	}
+
+	nats.New(&nats.Options{}).Handler(t.Context(), consumer)(msg)
+
+	require.False(t, msg.acked)
+	require.True(t, msg.naked)
+	require.Equal(t, time.Second, msg.nakDelay)
+	require.Equal(t, messaging.EventTypeUpdated, consumer.envelopes[0].EventType)
+}
+
+// TestHandlerConsumerErrorBackoff tests redelivery delays grow with each
+// delivery and are bounded.
+func TestHandlerConsumerErrorBackoff(t *testing.T) {
+	t.Parallel()
+
+	consumer := &recordingConsumer{
+		err: errConsumerFailed,
+	}
+
+	tests := map[uint64]time.Duration{
+		2:   2 * time.Second,
+		3:   4 * time.Second,
+		7:   time.Minute,
+		100: time.Minute,
+	}
+
+	for delivered, expected := range tests {
+		msg := &fakeMsg{
+			data:      []byte(`{"resourceId":"foo"}`),
+			delivered: delivered,
+		}
+
+		nats.New(&nats.Options{}).Handler(t.Context(), consumer)(msg)
+
+		require.True(t, msg.naked)
+		require.Equal(t, expected, msg.nakDelay, "delivered %d", delivered)
+	}
+}
+
+// TestHandlerInvalidMessage tests messages that cannot be decoded are terminated.
+func TestHandlerInvalidMessage(t *testing.T) {
+	t.Parallel()
+
+	consumer := &recordingConsumer{}
+
+	msg := &fakeMsg{
+		data: []byte(`not json`),
+	}
+
+	nats.New(&nats.Options{}).Handler(t.Context(), consumer)(msg)
+
+	require.True(t, msg.terminated)
+	require.Empty(t, consumer.envelopes)
+}
+
+// TestRunInvalidOptions tests missing options are rejected before connecting.
+func TestRunInvalidOptions(t *testing.T) {
+	t.Parallel()
+
+	options := []*nats.Options{
+		{
+			Durable: "foo",
+		},
+		{
+			Stream: "foo",
+		},
+	}
+
+	for _, o := range options {
+		require.ErrorIs(t, nats.New(o).Run(t.Context()), nats.ErrInvalidOptions)
+	}
+}
+
+// TestPublish tests published messages can be consumed.
+func TestPublish(t *testing.T) {
+	t.Parallel()
+
+	js := &fakeJetStream{}
+
+	envelope := &messaging.Envelope{
+		ResourceID: "foo",
+		EventType:  messaging.EventTypeCreated,
+		Labels: map[string]string{
+			"bar": "baz",
+		},
+		Correlation: map[string]string{
+			"traceparent": traceParent,
+		},
+	}
+
+	require.NoError(t, nats.Publish(t.Context(), js, "projects.foo", envelope))
+	require.Len(t, js.published, 1)
+	require.Equal(t, "projects.foo", js.published[0].Subject)
+
+	consumer := &recordingConsumer{}
+
+	msg := &fakeMsg{
+		data:    js.published[0].Data,
+		headers: js.published[0].Header,
+	}
+
+	nats.New(&nats.Options{}).Handler(t.Context(), consumer)(msg)
+
+	require.Equal(t, []*messaging.Envelope{envelope}, consumer.envelopes)
+}
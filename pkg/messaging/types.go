@@ -21,12 +21,42 @@ import (
 	"time"
 )
 
+// EventType describes the lifecycle event that produced a message.
+type EventType string
+
+const (
+	// EventTypeCreated is emitted when a resource has been created.
+	EventTypeCreated EventType = "created"
+	// EventTypeUpdated is emitted when a resource has been modified, and for
+	// any existing resources replayed on startup.
+	EventTypeUpdated EventType = "updated"
+	// EventTypeDeleted is emitted when a resource is being deleted.
+	EventTypeDeleted EventType = "deleted"
+)
+
+// EventTypeFromDeletionTimestamp is used by backends that cannot tell the difference
+// between creation and update, and when decoding messages from producers that do
+// not set an event type.
+func EventTypeFromDeletionTimestamp(t *time.Time) EventType {
+	if t != nil {
+		return EventTypeDeleted
+	}
+
+	return EventTypeUpdated
+}
+
 // Envelope is a generic messaging envelope for resource messages.
 type Envelope struct {
 	// ResourceID the GUID of a resource.
 	ResourceID string
-	// DeletionTimestamp describes whether the resource is being deleted
-	// or not, and is used for routing.  If not set this is a creation or
-	// update event.
+	// EventType describes the lifecycle event, and is used for routing.
+	EventType EventType
+	// DeletionTimestamp is set when the resource is being deleted.
 	DeletionTimestamp *time.Time
+	// Labels are propagated from the source resource, allowing consumers to
+	// filter or route without rehydrating the resource.
+	Labels map[string]string
+	// Correlation optionally carries the W3C trace context of the request
+	// that produced the message, so consumers can continue the same trace.
+	Correlation map[string]string
 }
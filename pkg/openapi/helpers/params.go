@@ -0,0 +1,192 @@
+/*
+Copyright 2026 Nscale.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helpers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/routers"
+
+	servererrors "github.com/unikorn-cloud/core/pkg/server/errors"
+
+	"k8s.io/utils/ptr"
+)
+
+var (
+	// ErrParameter is raised when a parameter is not defined by the operation.
+	ErrParameter = errors.New("query parameter undefined")
+)
+
+// queryParameter looks up a query parameter definition, operation parameters
+// override those defined for the path.
+func queryParameter(route *routers.Route, name string) (*openapi3.Parameter, error) {
+	var parameter *openapi3.Parameter
+
+	if route.Operation != nil {
+		parameter = route.Operation.Parameters.GetByInAndName(openapi3.ParameterInQuery, name)
+	}
+
+	if parameter == nil && route.PathItem != nil {
+		parameter = route.PathItem.Parameters.GetByInAndName(openapi3.ParameterInQuery, name)
+	}
+
+	if parameter == nil || parameter.Schema == nil || parameter.Schema.Value == nil {
+		return nil, fmt.Errorf("%w: %s", ErrParameter, name)
+	}
+
+	return parameter, nil
+}
+
+// invalidParameter returns a client facing error for a bad parameter value.
+func invalidParameter(name string, err error) error {
+	reason := err.Error()
+
+	var schemaError *openapi3.SchemaError
+
+	if errors.As(err, &schemaError) {
+		reason = schemaError.Reason
+	}
+
+	return servererrors.OAuth2InvalidRequest("query parameter", name, "is invalid:", reason).WithError(err).WithValues("parameter", name)
+}
+
+// getParam gets a single valued query parameter, parses it, then validates it
+// against the schema.  If the parameter is not specified, then the schema default
+// is returned if set, otherwise nil.
+func getParam[T any](r *http.Request, route *routers.Route, name string, parse func(string) (T, error)) (*T, error) {
+	parameter, err := queryParameter(route, name)
+	if err != nil {
+		return nil, err
+	}
+
+	schema := parameter.Schema.Value
+
+	values := r.URL.Query()[name]
+
+	if len(values) == 0 {
+		if parameter.Required {
+			return nil, servererrors.OAuth2InvalidRequest("query parameter", name, "is required").WithValues("parameter", name)
+		}
+
+		if schema.Default == nil {
+			return nil, nil
+		}
+
+		// Defaults come from the specification, so are trusted.
+		value, err := parse(fmt.Sprint(schema.Default))
+		if err != nil {
+			return nil, fmt.Errorf("%w: default for %s: %w", ErrParameter, name, err)
+		}
+
+		return &value, nil
+	}
+
+	if len(values) > 1 {
+		return nil, servererrors.OAuth2InvalidRequest("query parameter", name, "must be specified once").WithValues("parameter", name)
+	}
+
+	value, err := parse(values[0])
+	if err != nil {
+		return nil, invalidParameter(name, err)
+	}
+
+	if err := schema.VisitJSON(jsonValue(value)); err != nil {
+		return nil, invalidParameter(name, err)
+	}
+
+	return &value, nil
+}
+
+// jsonValue converts a value to how it would appear in decoded JSON, which is
+// what schema validation expects.
+func jsonValue(value any) any {
+	if t, ok := value.(int); ok {
+		return float64(t)
+	}
+
+	return value
+}
+
+// GetIntParam returns an integer query parameter from a resolved route.
+func GetIntParam(r *http.Request, route *routers.Route, name string) (*int, error) {
+	return getParam(r, route, name, strconv.Atoi)
+}
+
+// GetBoolParam returns a boolean query parameter from a resolved route.
+func GetBoolParam(r *http.Request, route *routers.Route, name string) (*bool, error) {
+	return getParam(r, route, name, strconv.ParseBool)
+}
+
+// GetStringParam returns a string query parameter from a resolved route.
+func GetStringParam(r *http.Request, route *routers.Route, name string) (*string, error) {
+	return getParam(r, route, name, func(s string) (string, error) {
+		return s, nil
+	})
+}
+
+// GetEnumParam returns an enumerated query parameter from a resolved route,
+// typically this will be a generated OpenAPI enum type.  The value must be one
+// of those defined by the schema.
+func GetEnumParam[T ~string](r *http.Request, route *routers.Route, name string) (*T, error) {
+	value, err := GetStringParam(r, route, name)
+	if err != nil || value == nil {
+		return nil, err
+	}
+
+	return ptr.To(T(*value)), nil
+}
+
+// GetStringArrayParam returns an array of strings query parameter from a resolved
+// route.  Values may be specified as repeated parameters, or comma separated if the
+// parameter is not exploded.
+func GetStringArrayParam(r *http.Request, route *routers.Route, name string) ([]string, error) {
+	parameter, err := queryParameter(route, name)
+	if err != nil {
+		return nil, err
+	}
+
+	values := r.URL.Query()[name]
+
+	if len(values) == 0 {
+		if parameter.Required {
+			return nil, servererrors.OAuth2InvalidRequest("query parameter", name, "is required").WithValues("parameter", name)
+		}
+
+		return nil, nil
+	}
+
+	if parameter.Explode != nil && !*parameter.Explode {
+		values = strings.Split(values[0], ",")
+	}
+
+	items := make([]any, len(values))
+
+	for i := range values {
+		items[i] = values[i]
+	}
+
+	if err := parameter.Schema.Value.VisitJSON(items); err != nil {
+		return nil, invalidParameter(name, err)
+	}
+
+	return values, nil
+}
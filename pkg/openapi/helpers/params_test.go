@@ -0,0 +1,177 @@
+/*
+Copyright 2026 Nscale.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helpers_test
+
+import (
+	_ "embed"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/stretchr/testify/require"
+
+	"github.com/unikorn-cloud/core/pkg/openapi/helpers"
+	"github.com/unikorn-cloud/core/pkg/server/errors"
+
+	"k8s.io/utils/ptr"
+)
+
+//go:embed params_test.schema.yaml
+var paramsSchema []byte
+
+type order string
+
+// getParamsRoute returns the resolved route for parameter tests.
+func getParamsRoute(t *testing.T) *routers.Route {
+	t.Helper()
+
+	spec, err := openapi3.NewLoader().LoadFromData(paramsSchema)
+	require.NoError(t, err)
+
+	item := spec.Paths.Find("/things")
+	require.NotNil(t, item)
+
+	return &routers.Route{
+		Spec:      spec,
+		Path:      "/things",
+		PathItem:  item,
+		Method:    http.MethodGet,
+		Operation: item.GetOperation(http.MethodGet),
+	}
+}
+
+// paramsRequest returns a request with the query string.
+func paramsRequest(t *testing.T, query string) *http.Request {
+	t.Helper()
+
+	return httptest.NewRequestWithContext(t.Context(), http.MethodGet, "/things?"+query, nil)
+}
+
+// TestGetIntParam tests integer parameters are parsed, and defaulted.
+func TestGetIntParam(t *testing.T) {
+	t.Parallel()
+
+	route := getParamsRoute(t)
+
+	value, err := helpers.GetIntParam(paramsRequest(t, "limit=50"), route, "limit")
+	require.NoError(t, err)
+	require.Equal(t, ptr.To(50), value)
+
+	value, err = helpers.GetIntParam(paramsRequest(t, ""), route, "limit")
+	require.NoError(t, err)
+	require.Equal(t, ptr.To(20), value)
+
+	value, err = helpers.GetIntParam(paramsRequest(t, ""), route, "offset")
+	require.NoError(t, err)
+	require.Nil(t, value)
+}
+
+// TestGetIntParamInvalid tests malformed and out of range integers are rejected.
+func TestGetIntParamInvalid(t *testing.T) {
+	t.Parallel()
+
+	route := getParamsRoute(t)
+
+	for _, query := range []string{"limit=foo", "limit=0", "limit=101", "limit=1&limit=2"} {
+		_, err := helpers.GetIntParam(paramsRequest(t, query), route, "limit")
+		require.True(t, errors.IsBadRequest(err), query)
+	}
+}
+
+// TestGetBoolParam tests boolean parameters are parsed, including those defined
+// for the path.
+func TestGetBoolParam(t *testing.T) {
+	t.Parallel()
+
+	route := getParamsRoute(t)
+
+	value, err := helpers.GetBoolParam(paramsRequest(t, "verbose=true"), route, "verbose")
+	require.NoError(t, err)
+	require.Equal(t, ptr.To(true), value)
+
+	_, err = helpers.GetBoolParam(paramsRequest(t, "verbose=maybe"), route, "verbose")
+	require.True(t, errors.IsBadRequest(err))
+}
+
+// TestGetStringParam tests string parameters are validated.
+func TestGetStringParam(t *testing.T) {
+	t.Parallel()
+
+	route := getParamsRoute(t)
+
+	value, err := helpers.GetStringParam(paramsRequest(t, "name=foo"), route, "name")
+	require.NoError(t, err)
+	require.Equal(t, ptr.To("foo"), value)
+
+	_, err = helpers.GetStringParam(paramsRequest(t, "name=waytoolong"), route, "name")
+	require.True(t, errors.IsBadRequest(err))
+}
+
+// TestGetStringParamRequired tests required parameters must be specified.
+func TestGetStringParamRequired(t *testing.T) {
+	t.Parallel()
+
+	_, err := helpers.GetStringParam(paramsRequest(t, ""), getParamsRoute(t), "cursor")
+	require.True(t, errors.IsBadRequest(err))
+}
+
+// TestGetEnumParam tests enumerated parameters must be one of the allowed values.
+func TestGetEnumParam(t *testing.T) {
+	t.Parallel()
+
+	route := getParamsRoute(t)
+
+	value, err := helpers.GetEnumParam[order](paramsRequest(t, "order=desc"), route, "order")
+	require.NoError(t, err)
+	require.Equal(t, ptr.To(order("desc")), value)
+
+	value, err = helpers.GetEnumParam[order](paramsRequest(t, ""), route, "order")
+	require.NoError(t, err)
+	require.Nil(t, value)
+
+	_, err = helpers.GetEnumParam[order](paramsRequest(t, "order=sideways"), route, "order")
+	require.True(t, errors.IsBadRequest(err))
+}
+
+// TestGetStringArrayParam tests arrays may be repeated or comma separated, and
+// are validated.
+func TestGetStringArrayParam(t *testing.T) {
+	t.Parallel()
+
+	route := getParamsRoute(t)
+
+	values, err := helpers.GetStringArrayParam(paramsRequest(t, "tag=a&tag=b"), route, "tag")
+	require.NoError(t, err)
+	require.Equal(t, []string{"a", "b"}, values)
+
+	values, err = helpers.GetStringArrayParam(paramsRequest(t, "region=eu,us"), route, "region")
+	require.NoError(t, err)
+	require.Equal(t, []string{"eu", "us"}, values)
+
+	_, err = helpers.GetStringArrayParam(paramsRequest(t, "tag=a&tag=b&tag=c"), route, "tag")
+	require.True(t, errors.IsBadRequest(err))
+}
+
+// TestGetParamUndefined tests parameters must be defined by the specification.
+func TestGetParamUndefined(t *testing.T) {
+	t.Parallel()
+
+	_, err := helpers.GetIntParam(paramsRequest(t, "missing=1"), getParamsRoute(t), "missing")
+	require.ErrorIs(t, err, helpers.ErrParameter)
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2026 Nscale.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helpers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+
+	chi "github.com/go-chi/chi/v5"
+)
+
+var (
+	// ErrRouteMismatch is raised when the router and specification disagree.
+	ErrRouteMismatch = errors.New("router and specification mismatch")
+
+	// parameterRegexp matches path parameters, their names are irrelevant
+	// when matching routes.
+	parameterRegexp = regexp.MustCompile(`\{[^}]*\}`)
+)
+
+// routeKey uniquely identifies an operation by method and normalized path.
+func routeKey(method, path string) string {
+	return strings.ToUpper(method) + " " + parameterRegexp.ReplaceAllString(path, "{}")
+}
+
+// ValidateRoutes checks that every route registered with the router has an
+// operation in the specification, and vice versa.  This should be called at
+// startup, so drift between handlers and the specification fails fast rather
+// than surfacing as 404 errors from FindRoute.
+func (s *Schema) ValidateRoutes(routes chi.Routes) error {
+	registered := map[string]bool{}
+
+	walker := func(method, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+		registered[routeKey(method, route)] = true
+
+		return nil
+	}
+
+	if err := chi.Walk(routes, walker); err != nil {
+		return err
+	}
+
+	specified := map[string]bool{}
+
+	for path, item := range s.spec.Paths.Map() {
+		for method := range item.Operations() {
+			specified[routeKey(method, path)] = true
+		}
+	}
+
+	var problems []string
+
+	for key := range registered {
+		if !specified[key] {
+			problems = append(problems, "route "+key+" not in specification")
+		}
+	}
+
+	for key := range specified {
+		if !registered[key] {
+			problems = append(problems, "operation "+key+" not routed")
+		}
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+
+	slices.Sort(problems)
+
+	return fmt.Errorf("%w: %s", ErrRouteMismatch, strings.Join(problems, ", "))
+}
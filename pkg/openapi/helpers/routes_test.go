@@ -0,0 +1,74 @@
+/*
+Copyright 2026 Nscale.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helpers_test
+
+import (
+	_ "embed"
+	"net/http"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	chi "github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+
+	"github.com/unikorn-cloud/core/pkg/openapi/helpers"
+)
+
+//go:embed routes_test.schema.yaml
+var routesSchema []byte
+
+// getRoutesSchema returns the routes test schema.
+func getRoutesSchema(t *testing.T) *helpers.Schema {
+	t.Helper()
+
+	schema, err := helpers.NewSchema(func() (*openapi3.T, error) {
+		return openapi3.NewLoader().LoadFromData(routesSchema)
+	})
+	require.NoError(t, err)
+
+	return schema
+}
+
+func handler(w http.ResponseWriter, r *http.Request) {}
+
+// TestValidateRoutes tests a router that matches the specification, with
+// differently named path parameters, is valid.
+func TestValidateRoutes(t *testing.T) {
+	t.Parallel()
+
+	r := chi.NewRouter()
+	r.Get("/things", handler)
+	r.Post("/things", handler)
+	r.Get("/things/{id}", handler)
+
+	require.NoError(t, getRoutesSchema(t).ValidateRoutes(r))
+}
+
+// TestValidateRoutesMismatch tests missing and unspecified routes are reported.
+func TestValidateRoutesMismatch(t *testing.T) {
+	t.Parallel()
+
+	r := chi.NewRouter()
+	r.Get("/things", handler)
+	r.Get("/things/{thingID}", handler)
+	r.Delete("/things/{thingID}", handler)
+
+	err := getRoutesSchema(t).ValidateRoutes(r)
+	require.ErrorIs(t, err, helpers.ErrRouteMismatch)
+	require.ErrorContains(t, err, "operation POST /things not routed")
+	require.ErrorContains(t, err, "route DELETE /things/{} not in specification")
+}
@@ -0,0 +1,166 @@
+/*
+Copyright 2026 Nscale.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helpers
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/routers"
+)
+
+// NoSecurityRequirementsExtension explicitly marks an operation as public.
+const NoSecurityRequirementsExtension = "x-no-security-requirements"
+
+var (
+	// ErrSecurity is raised when an operation's security is misconfigured.
+	ErrSecurity = errors.New("security requirements invalid")
+)
+
+// SecurityScheme is a named security scheme, and the scopes it must grant.
+type SecurityScheme struct {
+	// Name is the security scheme name in the specification's components.
+	Name string
+	// Type is the security scheme type e.g. "oauth2" or "http".
+	Type string
+	// Scopes are any scopes required by the operation.
+	Scopes []string
+}
+
+// SecurityRequirement is a set of schemes that must all be satisfied.  An empty
+// requirement means authentication is optional.
+type SecurityRequirement []SecurityScheme
+
+// Security describes how an operation must be authenticated.
+type Security struct {
+	// Public operations require no authentication.
+	Public bool
+	// Requirements are alternatives, any one of which must be satisfied.
+	Requirements []SecurityRequirement
+}
+
+// Optional returns true if a request may be unauthenticated.
+func (s *Security) Optional() bool {
+	return s.Public || slices.ContainsFunc(s.Requirements, func(r SecurityRequirement) bool {
+		return len(r) == 0
+	})
+}
+
+// Schemes returns the names of all schemes that may be used, in the order they
+// are defined.
+func (s *Security) Schemes() []string {
+	var names []string
+
+	for _, requirement := range s.Requirements {
+		for _, scheme := range requirement {
+			if !slices.Contains(names, scheme.Name) {
+				names = append(names, scheme.Name)
+			}
+		}
+	}
+
+	return names
+}
+
+// isPublic checks for the explicit public operation extension.
+func isPublic(operation *openapi3.Operation) bool {
+	value, ok := operation.Extensions[NoSecurityRequirementsExtension]
+	if !ok {
+		return false
+	}
+
+	if b, ok := value.(bool); ok {
+		return b
+	}
+
+	return true
+}
+
+// ResolveSecurity returns the security required by a resolved route, so auth
+// middleware can consult one place rather than interpreting the specification.
+// Operation security overrides that defined globally.  An operation with no
+// security requirements is an error, unless it is explicitly marked as public,
+// so that forgetting to secure an operation fails closed.
+func ResolveSecurity(route *routers.Route) (*Security, error) {
+	operation := route.Operation
+
+	if isPublic(operation) {
+		return &Security{
+			Public: true,
+		}, nil
+	}
+
+	requirements := route.Spec.Security
+
+	if operation.Security != nil {
+		requirements = *operation.Security
+	}
+
+	if len(requirements) == 0 {
+		return nil, fmt.Errorf("%w: operation %s %s has no security requirements", ErrSecurity, route.Method, route.Path)
+	}
+
+	security := &Security{
+		Requirements: make([]SecurityRequirement, len(requirements)),
+	}
+
+	for i, requirement := range requirements {
+		names := make([]string, 0, len(requirement))
+
+		for name := range requirement {
+			names = append(names, name)
+		}
+
+		// Maps are unordered, so make the output deterministic.
+		slices.Sort(names)
+
+		out := make(SecurityRequirement, len(names))
+
+		for j, name := range names {
+			scheme, err := lookupSecurityScheme(route.Spec, name)
+			if err != nil {
+				return nil, err
+			}
+
+			out[j] = SecurityScheme{
+				Name:   name,
+				Type:   scheme.Type,
+				Scopes: requirement[name],
+			}
+		}
+
+		security.Requirements[i] = out
+	}
+
+	return security, nil
+}
+
+// lookupSecurityScheme finds a security scheme in the specification's components.
+func lookupSecurityScheme(spec *openapi3.T, name string) (*openapi3.SecurityScheme, error) {
+	if spec.Components == nil {
+		return nil, fmt.Errorf("%w: security scheme %s not defined", ErrSecurity, name)
+	}
+
+	ref, ok := spec.Components.SecuritySchemes[name]
+	if !ok || ref == nil || ref.Value == nil {
+		return nil, fmt.Errorf("%w: security scheme %s not defined", ErrSecurity, name)
+	}
+
+	return ref.Value, nil
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2026 Nscale.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helpers_test
+
+import (
+	_ "embed"
+	"net/http"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/stretchr/testify/require"
+
+	"github.com/unikorn-cloud/core/pkg/openapi/helpers"
+)
+
+//go:embed security_test.schema.yaml
+var securitySchema []byte
+
+// getRoute returns a resolved route for a GET on the path.
+func getRoute(t *testing.T, path string) *routers.Route {
+	t.Helper()
+
+	spec, err := openapi3.NewLoader().LoadFromData(securitySchema)
+	require.NoError(t, err)
+
+	item := spec.Paths.Find(path)
+	require.NotNil(t, item)
+
+	return &routers.Route{
+		Spec:      spec,
+		Path:      path,
+		PathItem:  item,
+		Method:    http.MethodGet,
+		Operation: item.GetOperation(http.MethodGet),
+	}
+}
+
+// TestResolveSecuritySecured tests global security is inherited.
+func TestResolveSecuritySecured(t *testing.T) {
+	t.Parallel()
+
+	security, err := helpers.ResolveSecurity(getRoute(t, "/secured"))
+	require.NoError(t, err)
+	require.False(t, security.Public)
+	require.False(t, security.Optional())
+	require.Equal(t, []helpers.SecurityRequirement{
+		{
+			{
+				Name:   "oauth2Authentication",
+				Type:   "oauth2",
+				Scopes: []string{},
+			},
+		},
+	}, security.Requirements)
+}
+
+// TestResolveSecurityPublic tests operations can be explicitly made public.
+func TestResolveSecurityPublic(t *testing.T) {
+	t.Parallel()
+
+	security, err := helpers.ResolveSecurity(getRoute(t, "/public"))
+	require.NoError(t, err)
+	require.True(t, security.Public)
+	require.True(t, security.Optional())
+	require.Empty(t, security.Requirements)
+}
+
+// TestResolveSecurityMultiScheme tests operation security overrides global security,
+// and alternative and combined schemes are reported.
+func TestResolveSecurityMultiScheme(t *testing.T) {
+	t.Parallel()
+
+	security, err := helpers.ResolveSecurity(getRoute(t, "/multi"))
+	require.NoError(t, err)
+	require.False(t, security.Public)
+	require.True(t, security.Optional())
+	require.Len(t, security.Requirements, 3)
+	require.Equal(t, helpers.SecurityRequirement{
+		{
+			Name:   "basicAuthentication",
+			Type:   "http",
+			Scopes: []string{},
+		},
+		{
+			Name:   "oauth2Authentication",
+			Type:   "oauth2",
+			Scopes: []string{"read"},
+		},
+	}, security.Requirements[0])
+	require.Equal(t, []string{"basicAuthentication", "oauth2Authentication", "apiKeyAuthentication"}, security.Schemes())
+}
+
+// TestResolveSecurityUndefinedScheme tests undefined schemes are rejected.
+func TestResolveSecurityUndefinedScheme(t *testing.T) {
+	t.Parallel()
+
+	_, err := helpers.ResolveSecurity(getRoute(t, "/undefined"))
+	require.ErrorIs(t, err, helpers.ErrSecurity)
+}
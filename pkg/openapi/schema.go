@@ -17,63 +17,66 @@ import (
 
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
-
-	"H4sIAAAAAAAC/+xa/W7kRnJ/lQJzB59xFPWxvt14DoYhW3FWiJ0V1rKDxFQGRXbNTHvJbm53c7TjhYA8",
-	"RJ4wTxJUNTnDmeFI2pWz/icwFtaQzer66l/9qrvfJ6WtG2vIBJ9M3icNOqwpkJNfAec/UkVlsO6qf8HP",
-	"FfnS6SZoa5JJcg6eAtgZBJx7CBZqDOUCcI7a+ACOvG1dSR60gbAgmFlXQ54YrOmrJVYt5Umam7BoPdwu",
-	"yACZ0ipSsLItzClAnnwdcP7VzNo/P7soMeTtycnZc35UoPvzswtl53mSJWmiWZu3LblVkor4ZMImJGni",
-	"ywXVyKrrQHW0bdXwex+cNvPkLu0foHO4Su7u7tLEkW+s8STjsSypCaRedw/3/XC9IHD0tiUfYIEeCiID",
-	"/WeARsGtriooCGZtNdNVxU/9ypQLZ41tfbXKcvPvtoUaV9DYqhJv9e4TAbU1OlgHOnhonF1qr63RZi4v",
-	"F4RVWIAPGFqfm2ABb1EH4AhXxEpKkBYEtiGH/CBjwwtUr6PaQ9tKawKZIKY3TaVL+eD4V8+2vk/oHbJU",
-	"+dM565JJos0SK62mnQ+SNL6Zbnup91Bh1Qq6T5I0CQ5LmmqVTJK/vSjK0y/Ul4X64vnp7KT4G744U8U/",
-	"Pjs5/eLL4vkLZJ03Af2To1kySf7heJPIx/GtP46aSSy3lXg9VGKGmkMRPwJRSGxNwbouBHG0suTBWPao",
-	"CahNbnAdpLetdqRgpqlSXtxaWjOrdPlEp/ZSDngTN/lxq8NClPFYE3D6A1aOUK2A3mkf/B/g5U613ggf",
-	"lURjw4JcCq1vsapWEBbaQ01oPBuwggUuadsU8ejMukIrReZpLl2LOeDT1pOD0pEiEzRWHpSVqK+1Wke7",
-	"cXqpK5qT/8My+BY9KDKaFBQrwDYsrNO/dfkb/YorxpwSWx8HsQlbAxkr3pDpjWQ82TLTl7YR2AY0cH51",
-	"uV4Y4ileFeazjXtyY6gk79GtBg4CG8FfUEuRg6bCwJVAIqtNIGew+pHcktw/sdFPi7EXQdP4czzM3bIP",
-	"FqL1ZYW6/uRxPDfQGnrXUMlFwjpozQKNYs3kG7Bl2TpHKoPrQTQRgkPjNZnQjUOjcsNvfVuWxLIMMDoE",
-	"t8oALmcxGbSEigNRoqcUmorQc6gb6wLoAOg5yNr7Nq45Y8N3tjXqaeEwNkxnLOZALAYoS2oDaWvAFQD7",
-	"5LH5yWBREWfITBsFG6wVz9iGjFZXzgaJXQ92H+eorfU4jdnrk8kvySKEZnJ8zO8zLGvKSlsnN2lSEDpy",
-	"05rCwio/9W3DESQl3xAqcjyqVziZiCA/OT4moxqrTdhIYz/ZhnaERPOSNGmcnemKOHI16iq5ebRjD3ho",
-	"zNWvGjKXF1Io9LyN5AQEsIIFpX1pl+QEtRiUox+hc1NkjQsdgjbz3CBjTJwR1sZCXD3a84ponekWPq+D",
-	"ShaRyECzC4xxbWkvpLRllGI8tLFMlWg2ui3sLYscqBjThJXUJf1Mjrnax2VHx2Vbo99YZ44czbU1R9H8",
-	"JE2WUXYySZan2enz7MXjc39XO1Rj0fmm1ZWCbhrQhnE7hmDWU6TWCA/t5InlremdSU+EDybR3k9jnTvE",
-	"hLZzI6L7p4fzMS36chnN6MoNdwj0ruEC23mrcVbqZlHRt9FFT/PalsRp//2DABxJMfOKrl+7ddbMIcb8",
-	"kzv0esMZ1FpDVs6vTMCSPc0MsrTOURmgaGN1487TtaUEgUe3PZTnphCB7BdSoFrBd081ctRiLWX+vtbc",
-	"78FzJCnfc0Xa6wH5KXdZWx+sgSosMAhqzB2asMmHrUZPOtm+S90jC2OCP/OxeYk94sL6EClz+lCT25eR",
-	"H6SK7M/3jbztElYIh9CEWHQ4k0xbDwpOmnDqJGnXg9+MzD+cb9yD692E4r7JPayLVU9pR1B/6Mn7sm/L",
-	"CyNe6lbUrqr/TIZcnzRQ80qbUyr9NgbN+SbtjuWYnWWxlDbkgo47CgeknkMg56mTGl3HSIFG8V8dj355",
-	"fX3VDSmtogyENHtAR1Cgj/yPB75iSIKz7OQMfEOlnnXIkcpa4eFRNqmoLevoNAWm73ErQSbwAvXnV5ce",
-	"pHnjXOYJrKdebgzFZr5skCH7ewM7DH0X4oeMcdADx+hP+S1Wlb2Vsa1ZJ8O0JqVxKgFM+72GKSNyWE2D",
-	"tdMK3ZyS9CA4bprDsfQdgc7d8P1MrmCndOkA8W3RN1UiYXxprnH1/R4P1W8Zp3gAaGlLZ5rcpvhGM0ek",
-	"3kUfcJHhKBxuhzbG2uJXKgMrFPeTfpQcuKDA3G9PtZdtjeZohiXbp2QQYGE7FJbOxYThzhRNAKHGcqEN",
-	"HZUVeq9nmmOQG0forQHl8NbAzNkaEMrKci4vbYlFW6FbpYJxKK3nkccZwUJUcIQqiukd/xfK5hnkydnx",
-	"6RkYSWHOdmVvTZ58nsEFOb0kFWcaAjDDaUx86ak4tyqqGTCiURZ5eQ6983cwxBzQByvlfHeldyqNrfW1",
-	"HemOITAY2e/bDf04mkPRhQ/B3VD11/GL3UzpBKVr1R9KkNfrmXctjCFMYd6hZR/0jiJxsRy6vrPxf/7r",
-	"v2NQ8LZ7lJvSGqXlo6heyn0ZuaO5Qy2MPkLSaIgyeHVr1sUiN/32g6TTeqsJS2e9B6yqzbb1EMZeikQu",
-	"cRc0d6gEfn4yb4y9HQeMN21BzlAg/z0WVP2MVTuaBoKO8C/r0VDxcJDt8RTCqul4jrREvO7XPTKX+gGz",
-	"KCg32ih6R6rnbwoDckmQvMQQyPGc//nLydGX50f/gUe/3fzl68nm19E0u3l/kj4/vRuM+PzrP43lm7Gv",
-	"SYBcXeN8hLB8a5mHISvSnQ2sN9Jc92E0YH/NhE7guoBvv44t0XuuE/HcYmMX6/vXPM/6dqmsbKvyPLNu",
-	"PhmByLuRzN45CxgZcai33XNB19niSOe6bh5X243vvjMObA/cv8wPkda7+3YPHk+UelnuoOnXW6xsCK+P",
-	"ZKv7+xIPdLM8Puq1h2edkukBX45Mdo+bxtDQujma3tssa7AlhOoHCsgLUaJZVa9myeSX+41xY1/fpbsL",
-	"YTjtpRoPwnDMkEBsnS8VVFkji/RhIrEz6b47bnb7uN6Cza5ysdrWS/w/2PzjQhhPUhpnWerv4dRHBmnf",
-	"zZ0Ohzzcvf5dnLuZ6mP92mtzr0vXh4e/B8PbOozseV5uxogefAjPy80hotcT7CcTuX1XfCo6t++0J5C6",
-	"fTOeQu0OSns6wdu3Os3NCJHbV6E7AtkkCWgP9jCt0/5+Zvd3ULZGbY76xjiqk5vYOqCRbSQ0onalZ1Su",
-	"yoqgWaCnz1l6ic7p2GrHprimcoFG+7pLuZhH2DSEjqmtoyGbvBpYmKSbn1J7pJ+Xvy6o2R44eNAPIKPI",
-	"lKt/tYFxbLX18Lt+K3RrnJzqjHLW3j8vB/R5HPWG/ch2nIdmth0/7hsG1k5t6HNsSu9TZAjzD6GfnFJW",
-	"FZxfXe7gXryB4XQYY5v3tvTXQyQfvOoOWK38EHqO7XyDL3IEL3sqtXVyCB7oXRhd4j2bvW+Bj7YSTE07",
-	"onzftwHno7RI5r0ZuPpqb9EdrHc7i/hw/DnpYuLu5PF2FqvdNH84MT6OA7C6uny9m1z7NV9RvDZzresD",
-	"7DbomrYLfTyPryjEwtHtmk8ShYGOePhY+Bc7K+0xtHBrdR7crXnsNkD3xSjyP1ajkdx5gGl8WD3rddyn",
-	"S7vT7nj0Y2nUsFgMuFP/6N8YS/4fm/7PsWnT1R0+LHh1efFtpLvdXgg62nH8sO3bOhl48JDEU708dPGx",
-	"OzfanI1urjguT7Oz7FmWmytHR47kpkWM9BKdRg4FaykXyiKNrlabY42d3Zplnqu/5nk2+N/ojszIge7+",
-	"sc6HHuemDPF1I1tK8bQqN4U2zMAwQGeagGEGcEFLqjh/oeB5fH+5pJ/vJOP/9rK8T7P99O6UgMGxJxzc",
-	"Mlifhd8nqde4G5yC8L0uXg/2Z6LpZqYx+nygxHxw739PcSodYSD1zWrcVLmadbuw0I3bO2Lc85wM/Ihq",
-	"103w+GqnD/TQbTzmWAu/vBjVs7ZKDpsetLxt1OMs7yU+YDlu292Jf6zdO0kkV2u2XP6IKhVvgPV1Rfut",
-	"neAO+H5tfXdfKrbQyprPQg+FuUGzeuDmcDwYLMjQTIe+P+c2SKFT+jdSuVmrEA3PcpOMbaLifPR0E+dQ",
-	"Y9PI5K7QwTGOdNvYNm55e7mwQ57iXrGx8YwRK7klKtd84nXEFaxXj4Ap/9MmkJxn8pDWE5dyMkoSQ6ZA",
-	"pfifjqVRzhW4+smrtTtT+by7VsGvSgw0l9ssoMNj8eu8z2q2+jBojR8LcObJq37DIOD88fAkMm/G43Ko",
-	"mlbd9QWu248+Pec4j96f58oiBVaHiuQ0oK6t3CAFHiwzbO4unWanz7KTfm8dG51MkmfZSfYsFsIF63F3",
-	"978BAAD//01Wp02xMAAA",
+	"H4sIAAAAAAAC/8RbjW7kxpF+lQIvQWIcNZJ2Hfs8QWCsreS8uPh2sVYc3Jl7gyK7ZqZtsprb3ZQ0Xgi4",
+	"h7gnvCcJurrJ4cxwpPHK2CBYREM2q+v3q59uv88q07SGib3L5u+zFi025MnKL4+r76imyhv7un8Rnity",
+	"ldWt14azefYCHHkwS/C4cuANNOirNeAKNTsPlpzpbEUONINfEyyNbaDIGBv60w3WHRVZXrBfdw5u18RA",
+	"XBlFCjamgxV5KLIvPa7+tDTmt8+vKvRFd3Hx7LPwqET72+dXyqyKbJblmQ7cvOvIbrJcyGfzIEKWZ65a",
+	"U4OBde2pibJt2vDeeat5ld3n/QO0FjfZ/f19nllyrWFHsh6rilpP6k16eKiH6zWBpXcdOQ9rdFASMfSf",
+	"AbKCW13XUBIsu3qp6zo8dRuu1taw6Vy9mRX8X6aDBjfQmroWbfXqEwKNYe2NBe0dtNbcaKcNa17JyzVh",
+	"7dfgPPrOFewN4C1qD8HCNQUmxUhrAtOSxfBgFgQvUb2JbI9lqwx7Yh/+xLatdSUfnP/ogqzvM7rDQFX+",
+	"tNbYbJ5pvsFaq0XSQZbHN4tdLaW3UBq1gfRJlmfeYkULrbJ59ofPy+ryU/VFqT797HJ5Uf4BP3+myn97",
+	"fnH56RflZ59jdj826G8sLbN59i/nW0c+j2/deeRMbLnLxJsxE0vUwRTxIxCGRNYcjE0miKuVIQdsgkbZ",
+	"o+aCcTDSu05bUrDUVCsnaq0ML2tdPVGpPZUj2sStf9xqvxZmHDYEwf0Ba0uoNkB32nn3T9ByYq0XwkUm",
+	"kY1fk82hcx3W9Qb8WjtoCNkFATawxhvaFUU0ujS21EoRP02lA5kjOu0cWagsKWKvsXagjFh94Gqwdmv1",
+	"ja5pRe6f5sG36EARa1JQbgA7vzZW/5z8N+oVNwFzKuxcXBRE2FkYsOIn4l7IgCc7YrrKtALbgAwvXr8c",
+	"AkM0FaKCf7dVT8FMFTmHdjNSEJgI/oJaiiy0NfqQCcSymj1Zxvo7sjdk/xyEfpqNnRBaxJ/TZk5h7w1E",
+	"6asadfPR7fiCoWO6a6nypIJeO14jq8CZfAOmqjprSc3gemRNBG+RnSb2aR2yKji8dV1VUaDFgGDJ280M",
+	"4OUyOoMWUwVDVOgoh7YmdASWWmM9aA/ogpG1c12MOTb+L6Zj9TRzsPGLZSBzxBYjlCW1hbQBcAXAPrpt",
+	"/sZY1hQ8ZKlZwRZrRTOmJdbqtTVebNeD3YcpaiceF9F7XTb/IVt7387Pz8P7GVYNzSrTZG/zrCS0ZBcN",
+	"+bVRbuG6NliQlHxDqMiGVT3D2VwIufn5ObFqjWa/pRb0ZFraIxLFy/KstWapawqWa1DX2duTFXtEQ1Oq",
+	"ftUSv7ySRKFXXSxOQADLG1DaVeaGrKAWsU96hKSmWDWutfeaVwUjtP2OMAgLMXq0A0u+s5wCP8RBLUEk",
+	"NJD3gTHGlnZSlHbsSfDQxDRVIW95W5vbQHLEYnSTwKSu6HuyTpsPzFyplu1Y/2Qsn1laacNnUfwsz24i",
+	"7Wye3VzOLj+bfX667+9zh2rKOl91ulaQtgHNAbejCZZ9idSx1KGJnkjeca9MeiJ8YFWRc4uY545VQru+",
+	"EdH948P5FBd9uoxipHQTOgS6a3WA9qit1prwPmDO11FFT9PaDsVF//2jAByL4lsc+rVba3gF0eYfXaHX",
+	"25pBDRwG5tyGPVZB06GCrIy1VHkou5jdNDtvu0qMEFZ3PZQXXBIkvZAC1YWH4KjBYLWYS0P9PnDuDuA5",
+	"Fil/1c4f9oDhaeiydj4YgMqv0QtqrCyy3/rDTqMnnWzfpR4UC1OEf+di8xJ7xLVxPpbM+WNNbp9GvpUs",
+	"crjfV/I2OawUHFImxKQTPIm7ZpRw8iy4TpanHvztxP7j/aY1OEwTyoc2dzAkq76knUD9sSYf8r4dLUxo",
+	"KUXUPqv/Tky2dxpoyDlcUS79Nnod/E3aHRNs9mwWU2lL1mtKtvWo6wkzf4vVWjOdWUIlbptWBq1s29Pk",
+	"qznoJSBvThZWWtQ/x3g7WdQX4Mk6SqJGewb4Qlbhr1Tcf3N9/TotqYyiGcg2DtASlOhI9QtfBZyEZ7OL",
+	"Z+BaqvQywVkuARyWR9qkogqD4qwmH3qKON+QDZzknxevXzqQjjIEWNjAOOrpRv/Y7jcbue3hwGKvbdjP",
+	"O+MydtSYR5dchLdY1+ZW1nY8eOiiIaVxIarO+wHIgthrv1l4YxY12hVl+VHEHnesK/R0i5uF1w2Zrmda",
+	"V7ToGG9Q1+HbxKsOKaEh9sLRu854XNDdGjsXnkzF50Ru2HeF78mWQcHJ3yG+LfuuUShMY8+QON4fFNr6",
+	"XQDisAC09N1LTXZbXUSVTVC9j/rUNhatx/u9rbCm/JEqL/OEbSRMOPx+pAE6ZyqNvndKHHx3SExC8TDS",
+	"5fH0wLDFgBDLlLRkz0gFaLaayQ6zo2ieTHBI+e/riImRknY96cc1GFndkp7SWxw0fidxeCXQdMjBN12D",
+	"fLbEKvhFxC/A0qT0LC0t+/HIkuaA0CTkq2p0Ti918OWCLaEzDMriLcPSmgYQqto4UnBjKiy7Gu0ml+SH",
+	"MpM4c7gkWAsLPYgW3Dvs70WzRfbs/PIZsMAIWgJlbrnIPpnBFVl9QyruNM7MIc9G8JFmO8R3DC8XhTJo",
+	"HcFYO38EptAcOG8sTTjGUQO+2MqR7wkCo5W954z1OOkpUYWPpYYx62/iF/v+kQid7iBvhp33JYwmzGGV",
+	"0mhv9FQ7hypqrPok4///7/9Fo+BtelRwZVhp+Siyl4eGnezZyqKWVi+mhUkTzeDVLQ9VRMH9XErcaZhB",
+	"YmWNcxDmvT1LbpxKvhGKofa5opVFJYD7N/6JzS1PAu1PXUmWyZP7K5ZUfx/OQY6iEPzHsBrqsBzk3CQH",
+	"v2lTASy9csDLnj0Zm45KzpIK1qzojlRf2Cv0GNKy+CV6Tzbs+T8/XJx98eLsv/Hs57e//3K+/XW2mL19",
+	"f5F/dnk/WvHJl7+Z8jc2b0iSqbrG1USJ87Vh54N1hkOjYcJq04dRgMOY8YngUOzsvo698vuQ/+TlSK7A",
+	"778Wxazvo6vadKooZsau5hPAeD/h2XuHRBMrjg095u+nRx44MdIYpgqb3YnIoTKOzI0eDvNj3cz9Q2Ol",
+	"0yvonpY9Kvr1Trk+htcT25jDgdXD7Mn6yNcBniUm8yO6nNjsATVNoaGxK+Re24HWaFaI6lvyGAJRrFnX",
+	"r5bZ/IeHhbFTX9/n+4Ew3vblkepjvGZceO0cPJZUG5Ygfbx82Nv0UB1v9xv8XoLtcUO52eVL9L91E7CE",
+	"6YitxZVmWTTW4i79K/lVUszRbSgAzBIEPLazwHB0ArV2vq/kDiOt1o3201ps8E43XQPcNSXZLXnNw545",
+	"/EzWhFatY6FE41pMs6cVST/GdOdfLZeOjuxl5F2f98PqRB9LN8wx06hfltTo4pLp/cwDe0m66Ldaauu8",
+	"CDaWa5qoNx7raZry6kBTo/QaqLopsnueFvfIk10GQaYCsLUm/PlrxN6JsXwYjYmHY4GYXv8qMbjd6kPD",
+	"r+fmgcgbXT74NRqBMb2hHSh4qh+AX9IOFHysH+j71yfX+4eq+FhV/6HSnlD7H4rxlA7gKLWn9wGHUucF",
+	"T9T7hyykI9Stk4B2YI5X/9o93AD8EZRpUPPZMAcQdgqOHSayjKGRhe1aL6naVDVBu0ZHnwTqFVqr41Qs",
+	"zq8aqtbI2jXJ5aIfYdsSWgdrsjRuOl6PJMzy7U8pUWSuIX9dUbu7cPSgX0CsiKvNfxofcGyz8/Av/VHK",
+	"zjo5FZ5sbXr9fDPqsqZRb9y27tp5LGaX2qi+rwzcqW2XFWc+DzFyvDg4RD+55VDXYai4h3vxBpfVfqop",
+	"eXBidj1G8tGrdEHDyA/p4rBbbfFFrvDIpKkxVi7ReLrzkyHeNz0PBfhkx3mfD/3UQ996XE1Wz7Lv25Gq",
+	"Xx8E3dF8txfEx+0fnC467p4f73qx2nfzxx3jw2qAwK6u3uw712HOVxSv3V3r5kgT5HVDu4k+3uepKRWI",
+	"6dRtnin0dBaWT5l/vRdpp3QPO9F5dKh36rQofTGJ/KdyNOE7j1Qavyyf9Twelkv72+5p9EPLqHGyGNVO",
+	"/aO/W+13sOkUJY3cLdt2tcdP0V69vPo61nFpFoSW9tBu3PbunCI9enroqLk5diM4HahuLw1s7/7eXM6e",
+	"zZ7PCn5twyGXXEGK8HqDVmPAv8Cl3LSM9WG92Z737U2rbopChaHO6P8mJ1ITNx3m7596zyEH57FpZaQW",
+	"j3ELLjWH0gI9JNEkymcAV3RDtWnJQhn2cf2tq36/i1n430Fq6bH9ED0SEzC6DwBHRybDJZGHKPUcp8V5",
+	"PIRI9nq08RBOtztN1YVHsPMXzz4eQN3KEnpSX22mRZU7i7drA2ndwdn7geZk4QfAeNrgdBjXR5rDLh6P",
+	"DcRfXk2fBhklB56PSt616jTJe4qPSI67cifyp8q950Ry52xH5SfAb7wa2WOwdjuT8AR8P3YuXSSMvaEy",
+	"4Spk2rpg5M0jV+rj4XRJTEvt+8bTeWSFVoVLTgUPLETBZwVnU0NkXE2esOMKGmxb2dyW2tuAI2mMb+LI",
+	"38lNNnIUZ+Vs4hkk1nJ9Wu6/xXu6GxiiR8A0/NPsSc7Uw5LOUchRxCr8aWULVCr807EeLTiVnPJqUGcu",
+	"n6f7RuFVhZ5WIYMQaH8qfr3ovTpIfRy0po9FgufJq74T9rg6HZ6E5ttpuxzLpnW61xOK5ZNvWgQ7T/6H",
+	"JSGzhI+99jXJaUjTGLlaHU50Yn+xvdR3Obt8Prvozxaw1dk8ez67mD2PiXAd+Li//8cACDAdScozAAA=",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
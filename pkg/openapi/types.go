@@ -19,11 +19,15 @@ const (
 	AccessDenied          ErrorError = "access_denied"
 	Conflict              ErrorError = "conflict"
 	Forbidden             ErrorError = "forbidden"
+	GatewayTimeout        ErrorError = "gateway_timeout"
 	InvalidRequest        ErrorError = "invalid_request"
 	MethodNotAllowed      ErrorError = "method_not_allowed"
 	NotFound              ErrorError = "not_found"
+	NotImplemented        ErrorError = "not_implemented"
+	QuotaExhausted        ErrorError = "quota_exhausted"
 	RequestEntityTooLarge ErrorError = "request_entity_too_large"
 	ServerError           ErrorError = "server_error"
+	ServiceUnavailable    ErrorError = "service_unavailable"
 	UnprocessableContent  ErrorError = "unprocessable_content"
 	UnsupportedMediaType  ErrorError = "unsupported_media_type"
 )
@@ -75,6 +79,9 @@ type BearerMethodList = []BearerMethod
 
 // Error Generic error message, compatible with oauth2.
 type Error struct {
+	// Details Machine-readable details of validation errors, if any.
+	Details *[]FieldError `json:"details,omitempty"`
+
 	// Error A terse error string expanding on the HTTP error code. Errors are based on the OAuth 2.02 specification, but are expanded with proprietary status codes for APIs other than those specified by OAuth 2.02.
 	Error ErrorError `json:"error"`
 
@@ -88,6 +95,15 @@ type Error struct {
 // ErrorError A terse error string expanding on the HTTP error code. Errors are based on the OAuth 2.02 specification, but are expanded with proprietary status codes for APIs other than those specified by OAuth 2.02.
 type ErrorError string
 
+// FieldError A validation error associated with a specific request field.
+type FieldError struct {
+	// Field The path of the invalid field e.g. spec.name.
+	Field string `json:"field"`
+
+	// Message Why the field is invalid.
+	Message string `json:"message"`
+}
+
 // HealthStatusDetail Human-facing detail about the current health state: a machine-classifiable
 // reason drawn from a closed vocabulary, and a user-safe human-readable
 // message (e.g. "2/12 nodes are down"). Derived from the resource's status and
@@ -185,6 +201,21 @@ type OrganizationScopedResourceReadMetadata struct {
 	Tags *TagList `json:"tags,omitempty"`
 }
 
+// PaginationMetadata Describes the page of items returned by a list request.
+type PaginationMetadata struct {
+	// Limit The maximum number of items in the page, zero if unlimited.
+	Limit int `json:"limit"`
+
+	// NextOffset The offset of the next page, absent when this is the last page.
+	NextOffset *int `json:"nextOffset,omitempty"`
+
+	// Offset The index of the first item in the page.
+	Offset int `json:"offset"`
+
+	// Total The total number of items across all pages.
+	Total int `json:"total"`
+}
+
 // ProjectScopedResourceReadMetadata defines model for projectScopedResourceReadMetadata.
 type ProjectScopedResourceReadMetadata struct {
 	// CreatedBy The user who created the resource.
@@ -19,13 +19,23 @@ package options
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
 	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
 	"github.com/spf13/pflag"
 	prombridge "go.opentelemetry.io/contrib/bridges/prometheus"
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
 	"go.opentelemetry.io/otel/propagation"
 	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
@@ -38,6 +48,21 @@ import (
 	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
 )
 
+const (
+	// OTLPProtocolHTTP exports telemetry using OTLP/HTTP.
+	OTLPProtocolHTTP = "http"
+	// OTLPProtocolGRPC exports telemetry using OTLP/gRPC.
+	OTLPProtocolGRPC = "grpc"
+)
+
+var (
+	// ErrOTLPProtocol is raised when the OTLP protocol is not supported.
+	ErrOTLPProtocol = errors.New("unsupported OTLP protocol")
+
+	// ErrTLS is raised when the server TLS configuration is invalid.
+	ErrTLS = errors.New("invalid TLS configuration")
+)
+
 // CoreOptions are things all controllers, message consumers and servers will need.
 // There is a corresponding Helm include that matches this type.
 type CoreOptions struct {
@@ -45,6 +70,8 @@ type CoreOptions struct {
 	Namespace string
 	// OTLPEndpoint is used by OpenTelemetry.
 	OTLPEndpoint string
+	// OTLPProtocol selects the OTLP transport, either http or grpc.
+	OTLPProtocol string
 	// TraceSampingRatio is the number percentage of trace samples to take
 	// as a value between 0.0-1.0.
 	TraceSampingRatio float64
@@ -55,6 +82,7 @@ type CoreOptions struct {
 func (o *CoreOptions) AddFlags(flags *pflag.FlagSet) {
 	flags.StringVar(&o.Namespace, "namespace", "", "Namespace the process is running in.")
 	flags.StringVar(&o.OTLPEndpoint, "otlp-endpoint", "", "An optional OTLP endpoint.")
+	flags.StringVar(&o.OTLPProtocol, "otlp-protocol", OTLPProtocolHTTP, "OTLP protocol, either http or grpc.")
 	flags.Float64Var(&o.TraceSampingRatio, "trace-sampling-ratio", 0.0, "OpenTelemetry trace sampling ratio, this affects console logging")
 
 	z := flag.NewFlagSet("", flag.ExitOnError)
@@ -71,11 +99,35 @@ func (o *CoreOptions) SetupLogging() {
 	otel.SetLogger(logr)
 }
 
+// traceExporter creates a trace exporter for the selected protocol.
+func (o *CoreOptions) traceExporter(ctx context.Context) (trace.SpanExporter, error) {
+	switch o.OTLPProtocol {
+	case "", OTLPProtocolHTTP:
+		return otlptracehttp.New(ctx, otlptracehttp.WithEndpoint(o.OTLPEndpoint), otlptracehttp.WithInsecure())
+	case OTLPProtocolGRPC:
+		return otlptracegrpc.New(ctx, otlptracegrpc.WithEndpoint(o.OTLPEndpoint), otlptracegrpc.WithInsecure())
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrOTLPProtocol, o.OTLPProtocol)
+}
+
+// metricExporter creates a metric exporter for the selected protocol.
+func (o *CoreOptions) metricExporter(ctx context.Context) (sdkmetric.Exporter, error) {
+	switch o.OTLPProtocol {
+	case "", OTLPProtocolHTTP:
+		return otlpmetrichttp.New(ctx, otlpmetrichttp.WithEndpoint(o.OTLPEndpoint), otlpmetrichttp.WithInsecure())
+	case OTLPProtocolGRPC:
+		return otlpmetricgrpc.New(ctx, otlpmetricgrpc.WithEndpoint(o.OTLPEndpoint), otlpmetricgrpc.WithInsecure())
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrOTLPProtocol, o.OTLPProtocol)
+}
+
 func (o *CoreOptions) SetupOpenTelemetry(ctx context.Context, opts ...trace.TracerProviderOption) error {
 	otel.SetTextMapPropagator(propagation.TraceContext{})
 
 	if o.OTLPEndpoint != "" {
-		traceExporter, err := otlptracehttp.New(ctx, otlptracehttp.WithEndpoint(o.OTLPEndpoint), otlptracehttp.WithInsecure())
+		traceExporter, err := o.traceExporter(ctx)
 		if err != nil {
 			return err
 		}
@@ -97,10 +149,7 @@ func (o *CoreOptions) SetupOpenTelemetry(ctx context.Context, opts ...trace.Trac
 	meterOpts := []sdkmetric.Option{}
 
 	if o.OTLPEndpoint != "" {
-		metricExporter, err := otlpmetrichttp.New(ctx,
-			otlpmetrichttp.WithEndpoint(o.OTLPEndpoint),
-			otlpmetrichttp.WithInsecure(),
-		)
+		metricExporter, err := o.metricExporter(ctx)
 		if err != nil {
 			return err
 		}
@@ -141,6 +190,21 @@ type ServerOptions struct {
 
 	// RequestTimeout places a hard limit on all requests lengths.
 	RequestTimeout time.Duration
+
+	// ShutdownTimeout is how long we allow in-flight requests to complete
+	// when terminating before forcibly closing connections.
+	ShutdownTimeout time.Duration
+
+	// TLSCertFile is a PEM encoded server certificate, if set the server
+	// should serve TLS rather than rely on a gateway to terminate it.
+	TLSCertFile string
+
+	// TLSKeyFile is a PEM encoded private key for the server certificate.
+	TLSKeyFile string
+
+	// TLSClientCAFile is an optional PEM encoded CA bundle, if set clients
+	// must present a certificate signed by it i.e. mTLS.
+	TLSClientCAFile string
 }
 
 func (o *ServerOptions) AddFlags(f *pflag.FlagSet) {
@@ -149,4 +213,102 @@ func (o *ServerOptions) AddFlags(f *pflag.FlagSet) {
 	f.DurationVar(&o.ReadHeaderTimeout, "server-read-header-timeout", time.Second, "How long to wait for the client to send headers.")
 	f.DurationVar(&o.WriteTimeout, "server-write-timeout", 10*time.Second, "How long to wait for the API to respond to the client.")
 	f.DurationVar(&o.RequestTimeout, "server-request-timeout", 30*time.Second, "How long to wait of a request to be serviced.")
+	f.DurationVar(&o.ShutdownTimeout, "server-shutdown-timeout", 30*time.Second, "How long to wait for in-flight requests to complete on shutdown.")
+	f.StringVar(&o.TLSCertFile, "server-tls-cert-file", "", "Optional PEM encoded server certificate to serve TLS.")
+	f.StringVar(&o.TLSKeyFile, "server-tls-key-file", "", "Optional PEM encoded server private key to serve TLS.")
+	f.StringVar(&o.TLSClientCAFile, "server-tls-client-ca-file", "", "Optional PEM encoded CA bundle used to verify client certificates.")
+}
+
+// TLSConfig returns the server TLS configuration, or nil if TLS is not configured
+// and the server should serve plaintext e.g. behind a gateway.
+func (o *ServerOptions) TLSConfig() (*tls.Config, error) {
+	if o.TLSCertFile == "" && o.TLSKeyFile == "" {
+		if o.TLSClientCAFile != "" {
+			return nil, fmt.Errorf("%w: client CA requires a server certificate and key", ErrTLS)
+		}
+
+		return nil, nil
+	}
+
+	if o.TLSCertFile == "" || o.TLSKeyFile == "" {
+		return nil, fmt.Errorf("%w: both a server certificate and key are required", ErrTLS)
+	}
+
+	certificate, err := tls.LoadX509KeyPair(o.TLSCertFile, o.TLSKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("%w: loading server certificate: %w", ErrTLS, err)
+	}
+
+	config := &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{certificate},
+	}
+
+	if o.TLSClientCAFile != "" {
+		ca, err := os.ReadFile(o.TLSClientCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("%w: reading client CA: %w", ErrTLS, err)
+		}
+
+		pool := x509.NewCertPool()
+
+		if !pool.AppendCertsFromPEM(ca) {
+			return nil, fmt.Errorf("%w: no valid CA certificates in %s", ErrTLS, o.TLSClientCAFile)
+		}
+
+		config.ClientCAs = pool
+		config.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	return config, nil
+}
+
+// ListenAndServe runs the server with the configured shutdown timeout, see ListenAndServe.
+func (o *ServerOptions) ListenAndServe(ctx context.Context, server *http.Server) error {
+	return ListenAndServe(ctx, server, o.ShutdownTimeout)
+}
+
+// ListenAndServe runs the server until it fails, the context is cancelled, or
+// the process receives SIGINT or SIGTERM.  On termination in-flight requests are
+// given the shutdown timeout to complete.  TLS is served if the server has a TLS
+// configuration.  A clean shutdown returns nil.
+func ListenAndServe(ctx context.Context, server *http.Server, shutdownTimeout time.Duration) error {
+	ctx, stop := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	errs := make(chan error, 1)
+
+	go func() {
+		if server.TLSConfig != nil {
+			errs <- server.ListenAndServeTLS("", "")
+			return
+		}
+
+		errs <- server.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errs:
+		if errors.Is(err, http.ErrServerClosed) {
+			return nil
+		}
+
+		return err
+	case <-ctx.Done():
+	}
+
+	log.FromContext(ctx).Info("server shutting down", "timeout", shutdownTimeout)
+
+	shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), shutdownTimeout)
+	defer cancel()
+
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		return errors.Join(fmt.Errorf("server shutdown failed: %w", err), server.Close())
+	}
+
+	if err := <-errs; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+
+	return nil
 }
@@ -17,12 +17,25 @@ limitations under the License.
 package options_test
 
 import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
 	"io"
+	"math/big"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"strings"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stretchr/testify/assert"
@@ -30,6 +43,7 @@ import (
 	"go.opentelemetry.io/otel"
 	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
 	collectormetrics "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	"google.golang.org/grpc"
 	"google.golang.org/protobuf/proto"
 
 	"github.com/unikorn-cloud/core/pkg/options"
@@ -106,6 +120,51 @@ func (c *otlpCollector) close() {
 	c.server.Close()
 }
 
+type grpcCollector struct {
+	collectormetrics.UnimplementedMetricsServiceServer
+
+	otlpCollector
+
+	listener net.Listener
+	grpc     *grpc.Server
+}
+
+func newGRPCCollector(t *testing.T) *grpcCollector {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	c := &grpcCollector{
+		listener: listener,
+		grpc:     grpc.NewServer(),
+	}
+
+	collectormetrics.RegisterMetricsServiceServer(c.grpc, c)
+
+	go func() {
+		_ = c.grpc.Serve(listener)
+	}()
+
+	return c
+}
+
+func (c *grpcCollector) Export(_ context.Context, req *collectormetrics.ExportMetricsServiceRequest) (*collectormetrics.ExportMetricsServiceResponse, error) {
+	c.mu.Lock()
+	c.metrics = append(c.metrics, req)
+	c.mu.Unlock()
+
+	return &collectormetrics.ExportMetricsServiceResponse{}, nil
+}
+
+func (c *grpcCollector) endpoint() string {
+	return c.listener.Addr().String()
+}
+
+func (c *grpcCollector) close() {
+	c.grpc.Stop()
+}
+
 func TestSetupOpenTelemetryWithoutEndpointSucceeds(t *testing.T) {
 	t.Parallel()
 
@@ -140,3 +199,304 @@ func TestSetupOpenTelemetryBridgesControllerRuntimeMetrics(t *testing.T) {
 
 	assert.Contains(t, collector.metricNames(), "test_bridge_verify_total")
 }
+
+//nolint:paralleltest // the global meter provider is shared with other tests
+func TestSetupOpenTelemetryGRPC(t *testing.T) {
+	counter := prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "test_grpc_verify_total",
+		Help: "Synthetic metric to verify export over gRPC.",
+	})
+	require.NoError(t, ctrlmetrics.Registry.Register(counter))
+	t.Cleanup(func() { ctrlmetrics.Registry.Unregister(counter) })
+	counter.Inc()
+
+	collector := newGRPCCollector(t)
+	defer collector.close()
+
+	o := &options.CoreOptions{
+		OTLPEndpoint: collector.endpoint(),
+		OTLPProtocol: options.OTLPProtocolGRPC,
+	}
+	require.NoError(t, o.SetupOpenTelemetry(t.Context()))
+
+	provider, ok := otel.GetMeterProvider().(*sdkmetric.MeterProvider)
+	require.True(t, ok)
+	require.NoError(t, provider.Shutdown(t.Context()))
+
+	assert.Contains(t, collector.metricNames(), "test_grpc_verify_total")
+}
+
+func TestSetupOpenTelemetryInvalidProtocol(t *testing.T) {
+	t.Parallel()
+
+	o := &options.CoreOptions{
+		OTLPEndpoint: "localhost:4317",
+		OTLPProtocol: "carrier-pigeon",
+	}
+
+	require.ErrorIs(t, o.SetupOpenTelemetry(t.Context()), options.ErrOTLPProtocol)
+}
+
+// writeCertificate generates a self-signed certificate for localhost and writes
+// it and the private key as PEM files, returning their paths.
+func writeCertificate(t *testing.T) (string, string, *x509.Certificate) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "localhost"},
+		DNSNames:              []string{"localhost"},
+		IPAddresses:           []net.IP{net.IPv4(127, 0, 0, 1)},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	certificate, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+
+	certFile := filepath.Join(dir, "tls.crt")
+	keyFile := filepath.Join(dir, "tls.key")
+
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}), 0o600))
+
+	return certFile, keyFile, certificate
+}
+
+func TestTLSConfigUnset(t *testing.T) {
+	t.Parallel()
+
+	config, err := (&options.ServerOptions{}).TLSConfig()
+	require.NoError(t, err)
+	require.Nil(t, config)
+}
+
+func TestTLSConfig(t *testing.T) {
+	t.Parallel()
+
+	certFile, keyFile, certificate := writeCertificate(t)
+
+	o := &options.ServerOptions{
+		TLSCertFile: certFile,
+		TLSKeyFile:  keyFile,
+	}
+
+	config, err := o.TLSConfig()
+	require.NoError(t, err)
+	require.NotNil(t, config)
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	server.TLS = config
+	server.StartTLS()
+
+	t.Cleanup(server.Close)
+
+	pool := x509.NewCertPool()
+	pool.AddCert(certificate)
+
+	client := &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{
+				MinVersion: tls.VersionTLS12,
+				RootCAs:    pool,
+			},
+		},
+	}
+
+	request, err := http.NewRequestWithContext(t.Context(), http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+
+	response, err := client.Do(request)
+	require.NoError(t, err)
+	require.NoError(t, response.Body.Close())
+	require.Equal(t, http.StatusOK, response.StatusCode)
+}
+
+func TestTLSConfigClientCA(t *testing.T) {
+	t.Parallel()
+
+	certFile, keyFile, _ := writeCertificate(t)
+
+	o := &options.ServerOptions{
+		TLSCertFile:     certFile,
+		TLSKeyFile:      keyFile,
+		TLSClientCAFile: certFile,
+	}
+
+	config, err := o.TLSConfig()
+	require.NoError(t, err)
+	require.Equal(t, tls.RequireAndVerifyClientCert, config.ClientAuth)
+	require.NotNil(t, config.ClientCAs)
+}
+
+func TestTLSConfigInvalid(t *testing.T) {
+	t.Parallel()
+
+	certFile, keyFile, _ := writeCertificate(t)
+
+	missing := filepath.Join(t.TempDir(), "missing")
+
+	invalid := []*options.ServerOptions{
+		{TLSCertFile: certFile},
+		{TLSKeyFile: keyFile},
+		{TLSClientCAFile: certFile},
+		{TLSCertFile: missing, TLSKeyFile: keyFile},
+		{TLSCertFile: certFile, TLSKeyFile: missing},
+		{TLSCertFile: certFile, TLSKeyFile: keyFile, TLSClientCAFile: missing},
+		{TLSCertFile: certFile, TLSKeyFile: keyFile, TLSClientCAFile: keyFile},
+	}
+
+	for _, o := range invalid {
+		_, err := o.TLSConfig()
+		require.ErrorIs(t, err, options.ErrTLS)
+	}
+}
+
+// freeAddress returns a loopback address that is free to listen on.
+func freeAddress(t *testing.T) string {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	address := listener.Addr().String()
+
+	require.NoError(t, listener.Close())
+
+	return address
+}
+
+// get issues a GET request to the server at the given address.
+func get(t *testing.T, address string) (*http.Response, error) {
+	t.Helper()
+
+	request, err := http.NewRequestWithContext(t.Context(), http.MethodGet, "http://"+address, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return http.DefaultClient.Do(request)
+}
+
+func TestListenAndServeShutdown(t *testing.T) {
+	t.Parallel()
+
+	address := freeAddress(t)
+
+	server := &http.Server{
+		Addr:              address,
+		ReadHeaderTimeout: time.Second,
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}),
+	}
+
+	ctx, cancel := context.WithCancel(t.Context())
+
+	errs := make(chan error, 1)
+
+	go func() {
+		errs <- options.ListenAndServe(ctx, server, time.Second)
+	}()
+
+	require.Eventually(t, func() bool {
+		response, err := get(t, address)
+		if err != nil {
+			return false
+		}
+
+		return response.Body.Close() == nil && response.StatusCode == http.StatusOK
+	}, 5*time.Second, 10*time.Millisecond)
+
+	cancel()
+
+	require.NoError(t, <-errs)
+}
+
+func TestListenAndServeShutdownTimeout(t *testing.T) {
+	t.Parallel()
+
+	address := freeAddress(t)
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+
+	t.Cleanup(func() { close(release) })
+
+	o := &options.ServerOptions{
+		ShutdownTimeout: 100 * time.Millisecond,
+	}
+
+	server := &http.Server{
+		Addr:              address,
+		ReadHeaderTimeout: time.Second,
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			close(started)
+			<-release
+		}),
+	}
+
+	ctx, cancel := context.WithCancel(t.Context())
+
+	errs := make(chan error, 1)
+
+	go func() {
+		errs <- o.ListenAndServe(ctx, server)
+	}()
+
+	go func() {
+		for {
+			response, err := get(t, address)
+			if err == nil {
+				_ = response.Body.Close()
+				return
+			}
+
+			select {
+			case <-started:
+				return
+			case <-time.After(10 * time.Millisecond):
+			}
+		}
+	}()
+
+	<-started
+
+	cancel()
+
+	require.ErrorIs(t, <-errs, context.DeadlineExceeded)
+}
+
+func TestListenAndServeError(t *testing.T) {
+	t.Parallel()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = listener.Close() })
+
+	server := &http.Server{
+		Addr:              listener.Addr().String(),
+		ReadHeaderTimeout: time.Second,
+	}
+
+	require.Error(t, options.ListenAndServe(t.Context(), server, time.Second))
+}
@@ -36,9 +36,9 @@ type ReleaseNamer interface {
 }
 
 // Paramterizer is an interface that allows generators to supply a list of parameters
-// to Helm.  These are in addition to those defined by the application template.  At
-// present, there is nothing special about overriding, it just appends, so ensure the
-// explicit and implicit sets don't overlap.
+// to Helm.  These are in addition to those defined by the application template.  There
+// is no overriding, the explicit and implicit sets must not overlap, and the provisioner
+// will raise an error if they do.
 type Paramterizer interface {
 	Parameters(ctx context.Context, version unikornv1.SemanticVersion) (map[string]string, error)
 }
@@ -21,7 +21,9 @@ package application
 import (
 	"context"
 	"errors"
+	"fmt"
 	"slices"
+	"strings"
 
 	unikornv1 "github.com/unikorn-cloud/core/pkg/apis/unikorn/v1alpha1"
 	"github.com/unikorn-cloud/core/pkg/cd"
@@ -34,6 +36,11 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
+var (
+	// ErrParameterConflict is raised when a Helm parameter is defined more than once.
+	ErrParameterConflict = errors.New("helm parameter conflict")
+)
+
 // Provisioner deploys an application that is keyed to a specific resource.
 // For example, ArgoCD dictates that applications be installed in the same
 // namespace, so we use the resource to define a unique set of labels that
@@ -145,11 +152,29 @@ func (p *Provisioner) getReleaseName(ctx context.Context) string {
 }
 
 // getParameters constructs a full list of Helm parameters by taking those provided
-// in the application spec, and appending any that the generator yields.
+// in the application spec, and appending any that the generator yields.  As Helm
+// precedence for duplicate parameters is undefined, any duplicates are an error.
 func (p *Provisioner) getParameters(ctx context.Context) ([]cd.HelmApplicationParameter, error) {
 	parameters := make([]cd.HelmApplicationParameter, 0, len(p.applicationVersion.Parameters))
 
+	// sources records where each parameter came from for error reporting.
+	sources := map[string]string{}
+
+	addSource := func(name, source string) error {
+		if existing, ok := sources[name]; ok {
+			return fmt.Errorf("%w: parameter %q defined by both %s and %s", ErrParameterConflict, name, existing, source)
+		}
+
+		sources[name] = source
+
+		return nil
+	}
+
 	for _, parameter := range p.applicationVersion.Parameters {
+		if err := addSource(parameter.Name, "application template"); err != nil {
+			return nil, err
+		}
+
 		parameters = append(parameters, cd.HelmApplicationParameter{
 			Name:  parameter.Name,
 			Value: parameter.Value,
@@ -164,6 +189,10 @@ func (p *Provisioner) getParameters(ctx context.Context) ([]cd.HelmApplicationPa
 			}
 
 			for name, value := range p {
+				if err := addSource(name, "generator"); err != nil {
+					return nil, err
+				}
+
 				parameters = append(parameters, cd.HelmApplicationParameter{
 					Name:  name,
 					Value: value,
@@ -334,6 +363,23 @@ func (p *Provisioner) initialize(ctx context.Context) error {
 	return nil
 }
 
+// skipped returns true if the resource's skip annotation lists this application,
+// in which case it must be left untouched.
+func (p *Provisioner) skipped(ctx context.Context) bool {
+	value, ok := FromContext(ctx).GetAnnotations()[constants.SkipProvisionersAnnotation]
+	if !ok {
+		return false
+	}
+
+	for _, name := range strings.Split(value, ",") {
+		if strings.TrimSpace(name) == p.Name {
+			return true
+		}
+	}
+
+	return false
+}
+
 // Provision implements the Provision interface.
 func (p *Provisioner) Provision(ctx context.Context) error {
 	log := log.FromContext(ctx)
@@ -342,6 +388,12 @@ func (p *Provisioner) Provision(ctx context.Context) error {
 		return err
 	}
 
+	if p.skipped(ctx) {
+		log.Info("skipping application provisioning", "application", p.Name)
+
+		return nil
+	}
+
 	log.V(1).Info("provisioning application", "application", p.Name)
 
 	// Convert the generic object type into what's expected by the driver interface.
@@ -514,6 +514,62 @@ func TestApplicationCreateMutate(t *testing.T) {
 	assert.True(t, mutator.postProvisionCalled)
 }
 
+// TestApplicationCreateParameterConflict tests that parameters defined by both the
+// application template and generator are rejected.
+func TestApplicationCreateParameterConflict(t *testing.T) {
+	t.Parallel()
+
+	app := &unikornv1.HelmApplication{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: baseNamespace,
+			Name:      applicationID,
+			Labels: map[string]string{
+				constants.NameLabel: applicationName,
+			},
+		},
+		Spec: unikornv1.HelmApplicationSpec{
+			Versions: []unikornv1.HelmApplicationVersion{
+				{
+					Repo:    ptr.To(repo),
+					Chart:   ptr.To(chart),
+					Version: version,
+					Parameters: []unikornv1.HelmApplicationParameter{
+						{
+							Name:  mutatorParameter,
+							Value: "conflict",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	tc := mustNewTestContext(t)
+
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	driver := mock.NewMockDriver(c)
+	owner := newManagedResource()
+
+	clusterContext := &coreclient.ClusterContext{
+		Client: tc.client,
+	}
+
+	ctx := t.Context()
+	ctx = coreclient.NewContextWithNamespace(ctx, baseNamespace)
+	ctx = coreclient.NewContext(ctx, tc.client)
+	ctx = coreclient.NewContextWithCluster(ctx, clusterContext)
+	ctx = cd.NewContext(ctx, driver)
+	ctx = application.NewContext(ctx, owner)
+
+	provisioner := application.New(applicationGetter(app)).WithGenerator(&mutator{})
+
+	err := provisioner.Provision(ctx)
+	assert.ErrorIs(t, err, application.ErrParameterConflict)
+	assert.ErrorContains(t, err, mutatorParameter)
+}
+
 // TestApplicationDeleteNotFound tests the provisioner returns nil when an application
 // doesn't exist.
 func TestApplicationDeleteNotFound(t *testing.T) {
@@ -564,3 +620,83 @@ func TestApplicationDeleteNotFound(t *testing.T) {
 
 	assert.ErrorIs(t, provisioner.Deprovision(ctx), provisioners.ErrYield)
 }
+
+// TestApplicationSkip tests the provisioner leaves the application untouched when
+// named by the skip annotation, and provisions it when others are named.  Skipped
+// applications are still deprovisioned so they are not orphaned.
+func TestApplicationSkip(t *testing.T) {
+	t.Parallel()
+
+	app := &unikornv1.HelmApplication{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: baseNamespace,
+			Name:      applicationID,
+			Labels: map[string]string{
+				constants.NameLabel: applicationName,
+			},
+		},
+		Spec: unikornv1.HelmApplicationSpec{
+			Versions: []unikornv1.HelmApplicationVersion{
+				{
+					Repo:    ptr.To(repo),
+					Chart:   ptr.To(chart),
+					Version: version,
+				},
+			},
+		},
+	}
+
+	tc := mustNewTestContext(t)
+
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	driverAppID := &cd.ResourceIdentifier{
+		Name:   applicationName,
+		Labels: newManagedResourceLabels(),
+	}
+
+	driverApp := &cd.HelmApplication{
+		Repo:      repo,
+		Chart:     chart,
+		Version:   version.Original(),
+		Namespace: "default",
+	}
+
+	driver := mock.NewMockDriver(c)
+
+	owner := newManagedResource()
+	owner.Annotations = map[string]string{
+		constants.SkipProvisionersAnnotation: "cluster-credentials, " + applicationName,
+	}
+
+	clusterContext := &coreclient.ClusterContext{
+		Client: tc.client,
+	}
+
+	ctx := t.Context()
+	ctx = coreclient.NewContextWithNamespace(ctx, baseNamespace)
+	ctx = coreclient.NewContext(ctx, tc.client)
+	ctx = coreclient.NewContextWithCluster(ctx, clusterContext)
+	ctx = cd.NewContext(ctx, driver)
+
+	// The driver must not be called to provision while skipped.
+	skipCtx := application.NewContext(ctx, owner)
+
+	assert.NoError(t, application.New(applicationGetter(app)).Provision(skipCtx))
+
+	driver.EXPECT().DeleteHelmApplication(skipCtx, driverAppID, false).Return(nil)
+
+	assert.NoError(t, application.New(applicationGetter(app)).Deprovision(skipCtx))
+
+	other := newManagedResource()
+	other.Annotations = map[string]string{
+		constants.SkipProvisionersAnnotation: "cluster-credentials",
+	}
+
+	runCtx := application.NewContext(ctx, other)
+
+	driver.EXPECT().CreateOrUpdateHelmApplication(runCtx, driverAppID, driverApp).Return(nil)
+
+	assert.NoError(t, application.New(applicationGetter(app)).Provision(runCtx))
+}
@@ -26,6 +26,7 @@ import (
 	unikornv1 "github.com/unikorn-cloud/core/pkg/apis/unikorn/v1alpha1"
 	"github.com/unikorn-cloud/core/pkg/cd"
 
+	corev1 "k8s.io/api/core/v1"
 	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 )
 
@@ -68,3 +69,14 @@ type ManagerProvisioner interface {
 	// the provisioner will have a type specific version.
 	Object() unikornv1.ManagableResourceInterface
 }
+
+// HealthReporter may be implemented by a ManagerProvisioner to report the
+// health of a resource independently of its provisioning progress e.g. a
+// provisioned cluster whose workloads are degraded.  It is only consulted
+// after a successful provision, provisioners that don't implement it are
+// considered healthy.
+type HealthReporter interface {
+	// Health returns the status, reason and message of the resource's
+	// Healthy condition.
+	Health(ctx context.Context) (corev1.ConditionStatus, unikornv1.HealthConditionReason, string)
+}
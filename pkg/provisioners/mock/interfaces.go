@@ -15,6 +15,7 @@ import (
 	v1alpha1 "github.com/unikorn-cloud/core/pkg/apis/unikorn/v1alpha1"
 	cd "github.com/unikorn-cloud/core/pkg/cd"
 	gomock "go.uber.org/mock/gomock"
+	v1 "k8s.io/api/core/v1"
 	api "k8s.io/client-go/tools/clientcmd/api"
 )
 
@@ -213,3 +214,42 @@ func (mr *MockManagerProvisionerMockRecorder) ProvisionerName() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProvisionerName", reflect.TypeOf((*MockManagerProvisioner)(nil).ProvisionerName))
 }
+
+// MockHealthReporter is a mock of HealthReporter interface.
+type MockHealthReporter struct {
+	ctrl     *gomock.Controller
+	recorder *MockHealthReporterMockRecorder
+}
+
+// MockHealthReporterMockRecorder is the mock recorder for MockHealthReporter.
+type MockHealthReporterMockRecorder struct {
+	mock *MockHealthReporter
+}
+
+// NewMockHealthReporter creates a new mock instance.
+func NewMockHealthReporter(ctrl *gomock.Controller) *MockHealthReporter {
+	mock := &MockHealthReporter{ctrl: ctrl}
+	mock.recorder = &MockHealthReporterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHealthReporter) EXPECT() *MockHealthReporterMockRecorder {
+	return m.recorder
+}
+
+// Health mocks base method.
+func (m *MockHealthReporter) Health(ctx context.Context) (v1.ConditionStatus, v1alpha1.HealthConditionReason, string) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Health", ctx)
+	ret0, _ := ret[0].(v1.ConditionStatus)
+	ret1, _ := ret[1].(v1alpha1.HealthConditionReason)
+	ret2, _ := ret[2].(string)
+	return ret0, ret1, ret2
+}
+
+// Health indicates an expected call of Health.
+func (mr *MockHealthReporterMockRecorder) Health(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Health", reflect.TypeOf((*MockHealthReporter)(nil).Health), ctx)
+}
@@ -22,6 +22,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
 	"time"
 
 	jsonpatch "github.com/evanphx/json-patch"
@@ -32,6 +33,7 @@ import (
 	"github.com/unikorn-cloud/core/pkg/openapi"
 	"github.com/unikorn-cloud/core/pkg/util"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
 
@@ -83,6 +85,14 @@ func convertStatusCondition(in metav1.Object) openapi.ResourceProvisioningStatus
 	case unikornv1.ConditionReasonDeprovisioned, unikornv1.ConditionReasonDeprovisioning:
 		return openapi.ResourceProvisioningStatusDeprovisioning
 	case unikornv1.ConditionReasonDependencyNotReady, unikornv1.ConditionReasonDependencyFailed:
+		return openapi.ResourceProvisioningStatusProvisioning
+	case unikornv1.ConditionReasonPaused:
+		// Paused preserves the status, so a paused resource that was
+		// provisioned remains so.
+		if condition.Status == corev1.ConditionTrue {
+			return openapi.ResourceProvisioningStatusProvisioned
+		}
+
 		return openapi.ResourceProvisioningStatusProvisioning
 	}
 
@@ -316,6 +326,29 @@ func (o *ObjectMetadata) WithLabel(key, value string) *ObjectMetadata {
 	return o
 }
 
+// WithCreator records the principal that created the resource.
+func (o *ObjectMetadata) WithCreator(principal string) *ObjectMetadata {
+	o.Annotations[constants.CreatorAnnotation] = principal
+
+	return o
+}
+
+// WithLabels merges non-generic labels into those attached to a resource,
+// overriding any existing keys.
+func (o *ObjectMetadata) WithLabels(labels map[string]string) *ObjectMetadata {
+	maps.Copy(o.Labels, labels)
+
+	return o
+}
+
+// WithAnnotations merges non-generic annotations into those attached to a
+// resource, overriding any existing keys.
+func (o *ObjectMetadata) WithAnnotations(annotations map[string]string) *ObjectMetadata {
+	maps.Copy(o.Annotations, annotations)
+
+	return o
+}
+
 // Get renders the object metadata ready for inclusion into a Kubernetes resource.
 func (o *ObjectMetadata) Get() metav1.ObjectMeta {
 	return metav1.ObjectMeta(*o)
@@ -324,7 +357,25 @@ func (o *ObjectMetadata) Get() metav1.ObjectMeta {
 // MetadataMutationFunc is used to mutate metadata on update.
 type MetadataMutationFunc func(required, current metav1.Object) error
 
-// UpdateObjectMetadata abstracts away metadata updates.
+// WithModifier records the principal that modified the resource on update.
+func WithModifier(principal string) MetadataMutationFunc {
+	return func(required, current metav1.Object) error {
+		req := required.GetAnnotations()
+		if req == nil {
+			req = map[string]string{}
+		}
+
+		req[constants.ModifierAnnotation] = principal
+
+		required.SetAnnotations(req)
+
+		return nil
+	}
+}
+
+// UpdateObjectMetadata abstracts away metadata updates.  The creator is preserved
+// from the current resource, as the required resource is typically generated from
+// an API request and will not contain it.
 func UpdateObjectMetadata(required, current metav1.Object, mutators ...MetadataMutationFunc) error {
 	req := required.GetAnnotations()
 	if req == nil {
@@ -333,6 +384,12 @@ func UpdateObjectMetadata(required, current metav1.Object, mutators ...MetadataM
 
 	req[constants.ModifiedTimestampAnnotation] = time.Now().UTC().Format(time.RFC3339)
 
+	if v, ok := current.GetAnnotations()[constants.CreatorAnnotation]; ok {
+		if _, ok := req[constants.CreatorAnnotation]; !ok {
+			req[constants.CreatorAnnotation] = v
+		}
+	}
+
 	required.SetAnnotations(req)
 
 	for _, m := range mutators {
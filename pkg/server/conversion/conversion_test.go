@@ -178,6 +178,77 @@ func TestNewDeterministicObjectMetadata(t *testing.T) {
 	require.NotEqual(t, a.Name, c.Name)
 }
 
+// TestObjectMetadataWithLabels checks bulk labels and annotations are merged,
+// with later calls overriding earlier keys.
+func TestObjectMetadataWithLabels(t *testing.T) {
+	t.Parallel()
+
+	meta := &openapi.ResourceWriteMetadata{Name: name, Description: ptr.To(description)}
+
+	out := conversion.NewObjectMetadata(meta, "default").
+		WithOrganization(organization).
+		WithLabels(map[string]string{
+			"kind":    "cluster",
+			"manager": "foo",
+		}).
+		WithLabel("cluster", "bar").
+		WithLabels(map[string]string{
+			"manager": "baz",
+		}).
+		WithAnnotations(map[string]string{
+			"a": "1",
+			"b": "2",
+		}).
+		WithAnnotations(map[string]string{
+			"b": "3",
+		}).
+		Get()
+
+	expectedLabels := map[string]string{
+		constants.NameLabel:         name,
+		constants.OrganizationLabel: organization,
+		"kind":                      "cluster",
+		"cluster":                   "bar",
+		"manager":                   "baz",
+	}
+
+	expectedAnnotations := map[string]string{
+		constants.DescriptionAnnotation: description,
+		"a":                             "1",
+		"b":                             "3",
+	}
+
+	require.Equal(t, expectedLabels, out.Labels)
+	require.Equal(t, expectedAnnotations, out.Annotations)
+}
+
+// TestCreatorModifierRoundTrip checks the creator and modifier are recorded on
+// create and update, and reported by the read metadata.
+func TestCreatorModifierRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	meta := &openapi.ResourceWriteMetadata{Name: name}
+
+	current := &basicObject{
+		ObjectMeta: conversion.NewObjectMetadata(meta, "default").WithCreator(createdBy).Get(),
+	}
+
+	out := conversion.ResourceReadMetadata(current, nil)
+	require.Equal(t, ptr.To(createdBy), out.CreatedBy)
+	require.Nil(t, out.ModifiedBy)
+
+	required := &basicObject{
+		ObjectMeta: conversion.NewObjectMetadata(meta, "default").Get(),
+	}
+
+	require.NoError(t, conversion.UpdateObjectMetadata(required, current, conversion.WithModifier(modifiedBy)))
+
+	out = conversion.ResourceReadMetadata(required, nil)
+	require.Equal(t, ptr.To(createdBy), out.CreatedBy)
+	require.Equal(t, ptr.To(modifiedBy), out.ModifiedBy)
+	require.NotNil(t, out.ModifiedTime)
+}
+
 // TestResourceReadMetadataBasic checks that a minimal input yields a minimal output.
 func TestResourceReadMetadataBasic(t *testing.T) {
 	t.Parallel()
@@ -229,6 +300,7 @@ func TestResourceReadMetadataProvisioningStatus(t *testing.T) {
 		unikornv1.ConditionReasonDependencyNotReady: openapi.ResourceProvisioningStatusProvisioning,
 		unikornv1.ConditionReasonDependencyFailed:   openapi.ResourceProvisioningStatusProvisioning,
 		unikornv1.ConditionReasonDependencyNotFound: openapi.ResourceProvisioningStatusError,
+		unikornv1.ConditionReasonPaused:             openapi.ResourceProvisioningStatusProvisioning,
 	}
 
 	for reason, want := range cases {
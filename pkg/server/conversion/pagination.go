@@ -0,0 +1,57 @@
+/*
+Copyright 2026 Nscale.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conversion
+
+import (
+	"github.com/unikorn-cloud/core/pkg/openapi"
+)
+
+// NewPaginationMetadata describes a page of a list of total items.  Negative limits
+// and offsets are treated as zero, and a zero limit means all remaining items are
+// returned.
+func NewPaginationMetadata(total, limit, offset int) openapi.PaginationMetadata {
+	limit = max(limit, 0)
+	offset = max(offset, 0)
+
+	out := openapi.PaginationMetadata{
+		Total:  total,
+		Limit:  limit,
+		Offset: offset,
+	}
+
+	if limit > 0 && offset+limit < total {
+		next := offset + limit
+		out.NextOffset = &next
+	}
+
+	return out
+}
+
+// Paginate returns the requested page of items along with its metadata.  An offset
+// past the end of the items returns an empty page rather than an error.
+func Paginate[T any](items []T, limit, offset int) ([]T, openapi.PaginationMetadata) {
+	metadata := NewPaginationMetadata(len(items), limit, offset)
+
+	start := min(metadata.Offset, len(items))
+	end := len(items)
+
+	if metadata.Limit > 0 {
+		end = min(start+metadata.Limit, end)
+	}
+
+	return items[start:end], metadata
+}
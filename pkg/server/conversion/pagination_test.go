@@ -0,0 +1,98 @@
+/*
+Copyright 2026 Nscale.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conversion_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/unikorn-cloud/core/pkg/openapi"
+	"github.com/unikorn-cloud/core/pkg/server/conversion"
+
+	"k8s.io/utils/ptr"
+)
+
+// items returns a list to paginate.
+func items() []string {
+	return []string{"a", "b", "c", "d", "e"}
+}
+
+// TestPaginateFirstPage tests the first page links to the next.
+func TestPaginateFirstPage(t *testing.T) {
+	t.Parallel()
+
+	page, metadata := conversion.Paginate(items(), 2, 0)
+
+	expected := openapi.PaginationMetadata{
+		Total:      5,
+		Limit:      2,
+		Offset:     0,
+		NextOffset: ptr.To(2),
+	}
+
+	require.Equal(t, []string{"a", "b"}, page)
+	require.Equal(t, expected, metadata)
+}
+
+// TestPaginateLastPartialPage tests the last page may be short and has no next page.
+func TestPaginateLastPartialPage(t *testing.T) {
+	t.Parallel()
+
+	page, metadata := conversion.Paginate(items(), 2, 4)
+
+	expected := openapi.PaginationMetadata{
+		Total:  5,
+		Limit:  2,
+		Offset: 4,
+	}
+
+	require.Equal(t, []string{"e"}, page)
+	require.Equal(t, expected, metadata)
+}
+
+// TestPaginateOutOfRange tests an offset past the end returns an empty page.
+func TestPaginateOutOfRange(t *testing.T) {
+	t.Parallel()
+
+	page, metadata := conversion.Paginate(items(), 2, 10)
+
+	expected := openapi.PaginationMetadata{
+		Total:  5,
+		Limit:  2,
+		Offset: 10,
+	}
+
+	require.Empty(t, page)
+	require.Equal(t, expected, metadata)
+}
+
+// TestPaginateUnlimited tests a zero limit returns all remaining items.
+func TestPaginateUnlimited(t *testing.T) {
+	t.Parallel()
+
+	page, metadata := conversion.Paginate(items(), 0, -1)
+
+	expected := openapi.PaginationMetadata{
+		Total:  5,
+		Limit:  0,
+		Offset: 0,
+	}
+
+	require.Equal(t, items(), page)
+	require.Equal(t, expected, metadata)
+}
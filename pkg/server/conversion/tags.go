@@ -0,0 +1,167 @@
+/*
+Copyright 2026 Nscale.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conversion
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/spf13/pflag"
+
+	unikornv1 "github.com/unikorn-cloud/core/pkg/apis/unikorn/v1alpha1"
+	"github.com/unikorn-cloud/core/pkg/openapi"
+	"github.com/unikorn-cloud/core/pkg/server/errors"
+)
+
+// DefaultTagMaxCount is the default maximum number of tags on a resource.  Tags
+// are embedded in every read, so this bounds resource and list response sizes.
+const DefaultTagMaxCount = 64
+
+// TagRule identifies a tag validation rule.
+type TagRule string
+
+const (
+	// TagRuleCount is violated when there are too many tags.
+	TagRuleCount TagRule = "count"
+	// TagRuleDuplicate is violated when a tag name is used more than once.
+	TagRuleDuplicate TagRule = "duplicate"
+	// TagRuleKeyLength is violated when a tag name is too long.
+	TagRuleKeyLength TagRule = "key-length"
+	// TagRuleKeyFormat is violated when a tag name contains illegal characters.
+	TagRuleKeyFormat TagRule = "key-format"
+	// TagRuleValueLength is violated when a tag value is too long.
+	TagRuleValueLength TagRule = "value-length"
+	// TagRuleReservedPrefix is violated when a tag name uses a reserved prefix.
+	TagRuleReservedPrefix TagRule = "reserved-prefix"
+)
+
+// TagPolicy defines constraints on tags.  Zero values disable the
+// corresponding check.
+type TagPolicy struct {
+	// MaxCount is the maximum number of tags.
+	MaxCount int
+	// MaxKeyLength is the maximum length of a tag name.
+	MaxKeyLength int
+	// MaxValueLength is the maximum length of a tag value.
+	MaxValueLength int
+	// KeyPattern, if set, must match every tag name.
+	KeyPattern *regexp.Regexp
+	// ReservedPrefixes are tag name prefixes reserved for use by the platform.
+	ReservedPrefixes []string
+}
+
+// DefaultTagPolicy returns a policy that keeps tags compatible with
+// Kubernetes label selection, and reserves the platform's own namespaces.
+func DefaultTagPolicy() *TagPolicy {
+	return &TagPolicy{
+		MaxCount:       DefaultTagMaxCount,
+		MaxKeyLength:   63,
+		MaxValueLength: 256,
+		KeyPattern:     regexp.MustCompile(`^[a-zA-Z0-9]([-_.a-zA-Z0-9]*[a-zA-Z0-9])?$`),
+		ReservedPrefixes: []string{
+			"unikorn-cloud.org",
+			"kubernetes.io",
+			"k8s.io",
+		},
+	}
+}
+
+// AddFlags allows the tag limits to be configured per service.
+func (p *TagPolicy) AddFlags(f *pflag.FlagSet) {
+	f.IntVar(&p.MaxCount, "tag-max-count", DefaultTagMaxCount, "Maximum number of tags per resource, 0 disables the limit")
+}
+
+// tagError returns a client facing error naming the offending tag and rule.
+func tagError(name string, rule TagRule, a ...any) *errors.Error {
+	args := append([]any{"tag", name, "violates rule", string(rule) + ":"}, a...)
+
+	return errors.HTTPUnprocessableContent(args...).WithValues("tag", name, "rule", rule)
+}
+
+// ValidateTags checks tags conform to the policy, returning an error describing
+// the first violation.  This should be called on create and update.
+//
+//nolint:cyclop
+func ValidateTags(tags *openapi.TagList, policy *TagPolicy) error {
+	if tags == nil {
+		return nil
+	}
+
+	if policy.MaxCount > 0 && len(*tags) > policy.MaxCount {
+		return errors.HTTPUnprocessableContent("tags violate rule", string(TagRuleCount)+":", "at most", policy.MaxCount, "tags are allowed").WithValues("rule", TagRuleCount)
+	}
+
+	seen := make(map[string]bool, len(*tags))
+
+	for _, tag := range *tags {
+		if seen[tag.Name] {
+			return tagError(tag.Name, TagRuleDuplicate, "name must be unique")
+		}
+
+		seen[tag.Name] = true
+
+		if policy.MaxKeyLength > 0 && len(tag.Name) > policy.MaxKeyLength {
+			return tagError(tag.Name, TagRuleKeyLength, "name must be at most", policy.MaxKeyLength, "characters")
+		}
+
+		if policy.KeyPattern != nil && !policy.KeyPattern.MatchString(tag.Name) {
+			return tagError(tag.Name, TagRuleKeyFormat, "name must match", policy.KeyPattern.String())
+		}
+
+		if policy.MaxValueLength > 0 && len(tag.Value) > policy.MaxValueLength {
+			return tagError(tag.Name, TagRuleValueLength, "value must be at most", policy.MaxValueLength, "characters")
+		}
+
+		for _, prefix := range policy.ReservedPrefixes {
+			if strings.HasPrefix(tag.Name, prefix) {
+				return tagError(tag.Name, TagRuleReservedPrefix, "prefix", prefix, "is reserved")
+			}
+		}
+	}
+
+	return nil
+}
+
+// GenerateValidatedTagList checks tags conform to the policy before converting them
+// to their Kubernetes form.
+func GenerateValidatedTagList(in *openapi.TagList, policy *TagPolicy) (unikornv1.TagList, error) {
+	if err := ValidateTags(in, policy); err != nil {
+		return nil, err
+	}
+
+	return GenerateTagList(in), nil
+}
+
+// MatchTags returns whether the resource's tags contain every required tag.
+// An empty requirement matches everything.
+func MatchTags(resource unikornv1.TagList, required openapi.TagList) bool {
+	return resource.ContainsAll(GenerateTagList(&required))
+}
+
+// FilterByTags returns the items whose tags, as returned by the accessor, match
+// every required tag.  This is typically used by list handlers to implement tag
+// selection.
+func FilterByTags[T any](items []T, tags func(T) unikornv1.TagList, required openapi.TagList) []T {
+	if len(required) == 0 {
+		return items
+	}
+
+	return slices.DeleteFunc(slices.Clone(items), func(item T) bool {
+		return !MatchTags(tags(item), required)
+	})
+}
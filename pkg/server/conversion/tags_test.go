@@ -0,0 +1,257 @@
+/*
+Copyright 2026 Nscale.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conversion_test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/require"
+
+	unikornv1 "github.com/unikorn-cloud/core/pkg/apis/unikorn/v1alpha1"
+	"github.com/unikorn-cloud/core/pkg/openapi"
+	"github.com/unikorn-cloud/core/pkg/server/conversion"
+	"github.com/unikorn-cloud/core/pkg/server/errors"
+)
+
+// requireTagError checks the error is unprocessable and names the tag and rule.
+func requireTagError(t *testing.T, err error, name string, rule conversion.TagRule) {
+	t.Helper()
+
+	require.True(t, errors.IsUnprocessableContent(err))
+	require.Contains(t, err.Error(), name)
+	require.Contains(t, err.Error(), string(rule))
+}
+
+// TestValidateTags tests valid tags are accepted.
+func TestValidateTags(t *testing.T) {
+	t.Parallel()
+
+	tags := &openapi.TagList{
+		{
+			Name:  "environment",
+			Value: "production",
+		},
+		{
+			Name:  "cost.centre_id",
+			Value: "",
+		},
+	}
+
+	require.NoError(t, conversion.ValidateTags(nil, conversion.DefaultTagPolicy()))
+	require.NoError(t, conversion.ValidateTags(tags, conversion.DefaultTagPolicy()))
+}
+
+// makeTags returns a list of unique, valid tags.
+func makeTags(n int) *openapi.TagList {
+	tags := make(openapi.TagList, n)
+
+	for i := range tags {
+		tags[i] = openapi.Tag{
+			Name:  "tag" + strconv.Itoa(i),
+			Value: "foo",
+		}
+	}
+
+	return &tags
+}
+
+// TestValidateTagsCount tests the number of tags is limited.
+func TestValidateTagsCount(t *testing.T) {
+	t.Parallel()
+
+	policy := conversion.DefaultTagPolicy()
+
+	require.NoError(t, conversion.ValidateTags(makeTags(policy.MaxCount), policy))
+
+	err := conversion.ValidateTags(makeTags(policy.MaxCount+1), policy)
+	require.True(t, errors.IsUnprocessableContent(err))
+	require.Contains(t, err.Error(), string(conversion.TagRuleCount))
+}
+
+// TestGenerateValidatedTagListCount tests a configured tag limit is enforced when
+// generating tags, and a list just under it is accepted.
+func TestGenerateValidatedTagListCount(t *testing.T) {
+	t.Parallel()
+
+	policy := conversion.DefaultTagPolicy()
+
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	policy.AddFlags(flags)
+	require.NoError(t, flags.Parse([]string{"--tag-max-count=4"}))
+	require.Equal(t, 4, policy.MaxCount)
+
+	tags, err := conversion.GenerateValidatedTagList(makeTags(3), policy)
+	require.NoError(t, err)
+	require.Len(t, tags, 3)
+
+	_, err = conversion.GenerateValidatedTagList(makeTags(5), policy)
+	require.True(t, errors.IsUnprocessableContent(err))
+	require.Contains(t, err.Error(), string(conversion.TagRuleCount))
+}
+
+// TestValidateTagsKey tests illegal tag names are rejected.
+func TestValidateTagsKey(t *testing.T) {
+	t.Parallel()
+
+	for _, name := range []string{"", "-foo", "foo bar", "foo/bar"} {
+		tags := &openapi.TagList{
+			{
+				Name:  name,
+				Value: "foo",
+			},
+		}
+
+		requireTagError(t, conversion.ValidateTags(tags, conversion.DefaultTagPolicy()), name, conversion.TagRuleKeyFormat)
+	}
+}
+
+// TestValidateTagsReservedPrefix tests platform tag names are rejected.
+func TestValidateTagsReservedPrefix(t *testing.T) {
+	t.Parallel()
+
+	tags := &openapi.TagList{
+		{
+			Name:  "environment",
+			Value: "production",
+		},
+		{
+			Name:  "unikorn-cloud.org.name",
+			Value: "foo",
+		},
+	}
+
+	requireTagError(t, conversion.ValidateTags(tags, conversion.DefaultTagPolicy()), "unikorn-cloud.org.name", conversion.TagRuleReservedPrefix)
+}
+
+// TestValidateTagsDuplicate tests tag names must be unique.
+func TestValidateTagsDuplicate(t *testing.T) {
+	t.Parallel()
+
+	tags := &openapi.TagList{
+		{
+			Name:  "environment",
+			Value: "production",
+		},
+		{
+			Name:  "environment",
+			Value: "staging",
+		},
+	}
+
+	requireTagError(t, conversion.ValidateTags(tags, conversion.DefaultTagPolicy()), "environment", conversion.TagRuleDuplicate)
+}
+
+// resourceTags are tags attached to a resource for matching tests.
+func resourceTags() unikornv1.TagList {
+	return unikornv1.TagList{
+		{
+			Name:  "environment",
+			Value: "production",
+		},
+		{
+			Name:  "team",
+			Value: "compute",
+		},
+	}
+}
+
+// TestMatchTags tests a resource matches when it has all the required tags.
+func TestMatchTags(t *testing.T) {
+	t.Parallel()
+
+	required := openapi.TagList{
+		{
+			Name:  "team",
+			Value: "compute",
+		},
+		{
+			Name:  "environment",
+			Value: "production",
+		},
+	}
+
+	require.True(t, conversion.MatchTags(resourceTags(), required))
+}
+
+// TestMatchTagsMissing tests a resource doesn't match when a tag is missing
+// or has a different value.
+func TestMatchTagsMissing(t *testing.T) {
+	t.Parallel()
+
+	missing := openapi.TagList{
+		{
+			Name:  "environment",
+			Value: "production",
+		},
+		{
+			Name:  "region",
+			Value: "eu",
+		},
+	}
+
+	require.False(t, conversion.MatchTags(resourceTags(), missing))
+
+	mismatch := openapi.TagList{
+		{
+			Name:  "environment",
+			Value: "staging",
+		},
+	}
+
+	require.False(t, conversion.MatchTags(resourceTags(), mismatch))
+}
+
+// TestMatchTagsEmpty tests an empty requirement matches everything.
+func TestMatchTagsEmpty(t *testing.T) {
+	t.Parallel()
+
+	require.True(t, conversion.MatchTags(resourceTags(), nil))
+	require.True(t, conversion.MatchTags(nil, openapi.TagList{}))
+}
+
+// TestFilterByTags tests only matching items are returned.
+func TestFilterByTags(t *testing.T) {
+	t.Parallel()
+
+	items := []unikornv1.TagList{
+		resourceTags(),
+		nil,
+		{
+			{
+				Name:  "team",
+				Value: "compute",
+			},
+		},
+	}
+
+	identity := func(tags unikornv1.TagList) unikornv1.TagList {
+		return tags
+	}
+
+	required := openapi.TagList{
+		{
+			Name:  "team",
+			Value: "compute",
+		},
+	}
+
+	require.Equal(t, []unikornv1.TagList{items[0], items[2]}, conversion.FilterByTags(items, identity, required))
+	require.Equal(t, items, conversion.FilterByTags(items, identity, nil))
+	require.Len(t, items, 3)
+}
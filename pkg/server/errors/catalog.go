@@ -0,0 +1,90 @@
+/*
+Copyright 2026 Nscale.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package errors
+
+import (
+	"net/http"
+	"sync/atomic"
+
+	"golang.org/x/text/language"
+
+	"github.com/unikorn-cloud/core/pkg/openapi"
+)
+
+// MessageCatalog maps from a language tag e.g. "fr" or "fr-CA" to localized error
+// descriptions for each error code.
+type MessageCatalog map[string]map[openapi.ErrorError]string
+
+// catalog is the message catalog registered at startup.
+//
+//nolint:gochecknoglobals
+var catalog atomic.Pointer[MessageCatalog]
+
+// RegisterMessageCatalog registers a catalog of localized error descriptions,
+// this is typically done once at startup.  Languages are canonicalized so
+// that they can be matched against the Accept-Language header.
+func RegisterMessageCatalog(c MessageCatalog) error {
+	canonical := MessageCatalog{}
+
+	for tag, messages := range c {
+		t, err := language.Parse(tag)
+		if err != nil {
+			return err
+		}
+
+		canonical[t.String()] = messages
+	}
+
+	catalog.Store(&canonical)
+
+	return nil
+}
+
+// localize returns a localized description for the error code, in the client's
+// preferred language as defined by the Accept-Language header, and the language
+// that was used.  The description is empty if no translation is available.
+func localize(r *http.Request, code openapi.ErrorError) (string, string) {
+	c := catalog.Load()
+	if c == nil {
+		return "", ""
+	}
+
+	// Tags are returned in order of preference.
+	tags, _, err := language.ParseAcceptLanguage(r.Header.Get("Accept-Language"))
+	if err != nil {
+		return "", ""
+	}
+
+	for _, tag := range tags {
+		candidates := []string{
+			tag.String(),
+		}
+
+		// Fall back from regional variants e.g. fr-CA to fr.
+		if base, confidence := tag.Base(); confidence != language.No {
+			candidates = append(candidates, base.String())
+		}
+
+		for _, candidate := range candidates {
+			if message, ok := (*c)[candidate][code]; ok {
+				return message, candidate
+			}
+		}
+	}
+
+	return "", ""
+}
@@ -19,6 +19,7 @@ limitations under the License.
 package errors
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -26,12 +27,13 @@ import (
 	"net/http"
 	"reflect"
 	"slices"
+	"strconv"
 	"strings"
-
-	"go.opentelemetry.io/otel/trace"
+	"time"
 
 	coreerrors "github.com/unikorn-cloud/core/pkg/errors"
 	"github.com/unikorn-cloud/core/pkg/openapi"
+	"github.com/unikorn-cloud/core/pkg/server/middleware/requestid"
 
 	"k8s.io/utils/ptr"
 
@@ -41,8 +43,24 @@ import (
 const (
 	// Defined by RFC7235 and RFC6750.
 	AuthenticateHeader = "WWW-Authenticate"
+
+	// RetryAfterHeader is defined by RFC9110.
+	RetryAfterHeader = "Retry-After"
+
+	// RequestIDHeader is a de facto standard header used to correlate
+	// requests when tracing is unavailable.
+	RequestIDHeader = requestid.Header
+
+	// fallbackBody is returned when the error response cannot be marshaled,
+	// so clients always receive a parseable error.
+	fallbackBody = `{"error":"server_error","error_description":"failed to render error response"}`
 )
 
+// marshal allows error response marshaling failures to be tested.
+//
+//nolint:gochecknoglobals
+var marshal = json.Marshal
+
 // Error wraps ErrRequest with more contextual information that is used to
 // propagate and create suitable responses.
 type Error struct {
@@ -64,6 +82,10 @@ type Error struct {
 
 	// values are arbitrary key value pairs for logging.
 	values []any
+
+	// fieldErrors are machine-readable validation errors returned to
+	// the client.
+	fieldErrors []openapi.FieldError
 }
 
 // newError returns a new HTTP error.
@@ -92,12 +114,29 @@ func (e *Error) WithValues(values ...any) *Error {
 	return e
 }
 
+// WithFieldErrors augments the error with per-field validation errors that
+// are returned to the client e.g. so a user interface can highlight invalid
+// form inputs.
+func (e *Error) WithFieldErrors(fieldErrors ...openapi.FieldError) *Error {
+	e.fieldErrors = append(e.fieldErrors, fieldErrors...)
+
+	return e
+}
+
 // withHeader allows headers to be sent with the error.
 func (e *Error) withHeader(key, value string) *Error {
 	e.header.Set(key, value)
 	return e
 }
 
+// WithRetryAfter tells the client how long to wait before retrying the request,
+// this is rounded up to whole seconds.
+func (e *Error) WithRetryAfter(d time.Duration) *Error {
+	seconds := int64((d + time.Second - 1) / time.Second)
+
+	return e.withHeader(RetryAfterHeader, strconv.FormatInt(seconds, 10))
+}
+
 // Unwrap implements Go 1.13 errors.
 func (e *Error) Unwrap() error {
 	return e.err
@@ -115,7 +154,13 @@ func (e *Error) Write(w http.ResponseWriter, r *http.Request) {
 	// and return.
 	log := log.FromContext(r.Context())
 
-	var details []any
+	// The correlation ID is what a user can quote to support in order
+	// to find the logs and traces for a failed request.
+	id := requestid.FromRequest(r)
+
+	details := []any{
+		"correlationID", id,
+	}
 
 	if e.description != "" {
 		details = append(details, "detail", e.description)
@@ -141,23 +186,33 @@ func (e *Error) Write(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	// Descriptions are in English unless a translation is available.
+	description := e.description
+
+	if message, lang := localize(r, e.code); message != "" {
+		description = message
+
+		w.Header().Set("Content-Language", lang)
+	}
+
 	w.WriteHeader(e.status)
 
 	// Emit the response body.
 	ge := &openapi.Error{
 		Error:            e.code,
-		ErrorDescription: e.description,
+		ErrorDescription: description,
+		TraceId:          ptr.To(id),
 	}
 
-	if id := trace.SpanContextFromContext(r.Context()).TraceID().String(); id != "" {
-		ge.TraceId = ptr.To(id)
+	if len(e.fieldErrors) > 0 {
+		ge.Details = &e.fieldErrors
 	}
 
-	body, err := json.Marshal(ge)
+	body, err := marshal(ge)
 	if err != nil {
 		log.Error(err, "failed to marshal error response")
 
-		return
+		body = []byte(fallbackBody)
 	}
 
 	if _, err := w.Write(body); err != nil {
@@ -196,7 +251,13 @@ func isErrorType(err error, code int) bool {
 
 // FromOpenAPIError allows propagation across API calls.
 func FromOpenAPIError(code int, header http.Header, err *openapi.Error) *Error {
-	return newError(code, err.Error, err.ErrorDescription)
+	e := newError(code, err.Error, err.ErrorDescription)
+
+	if err.Details != nil {
+		e = e.WithFieldErrors(*err.Details...)
+	}
+
+	return e
 }
 
 // HTTPForbidden is raised when a user isn't permitted to do something by RBAC.
@@ -239,6 +300,29 @@ func IsConflict(err error) bool {
 	return isErrorType(err, http.StatusConflict)
 }
 
+// HTTPQuotaExhausted is raised when a request would exceed a quota.
+func HTTPQuotaExhausted(resource string, desired, limit int64) *Error {
+	return newError(http.StatusConflict, openapi.QuotaExhausted, fmt.Sprintf("quota exhausted for resource %s: requested %d, limit %d", resource, desired, limit))
+}
+
+// IsQuotaExhausted checks if the error is as described.
+func IsQuotaExhausted(err error) bool {
+	httpError := asError(err)
+
+	return httpError != nil && httpError.status == http.StatusConflict && httpError.code == openapi.QuotaExhausted
+}
+
+// HTTPNotImplemented is raised when an endpoint is defined by the API, but
+// not yet implemented by the server.
+func HTTPNotImplemented() *Error {
+	return newError(http.StatusNotImplemented, openapi.NotImplemented, "not implemented")
+}
+
+// IsNotImplemented checks if the error is as described.
+func IsNotImplemented(err error) bool {
+	return isErrorType(err, http.StatusNotImplemented)
+}
+
 // HTTPRequestEntityTooLarge is raised when the request body is too large and
 // overlows internal size limits.
 func HTTPRequestEntityTooLarge(a ...any) *Error {
@@ -250,6 +334,29 @@ func IsRequestEntityTooLarge(err error) bool {
 	return isErrorType(err, http.StatusRequestEntityTooLarge)
 }
 
+// HTTPGatewayTimeout is raised when the request could not be serviced
+// within the server's request timeout.
+func HTTPGatewayTimeout(a ...any) *Error {
+	return newError(http.StatusGatewayTimeout, openapi.GatewayTimeout, a...)
+}
+
+// IsGatewayTimeout checks if the error is as described.
+func IsGatewayTimeout(err error) bool {
+	return isErrorType(err, http.StatusGatewayTimeout)
+}
+
+// HTTPServiceUnavailable is raised when the request cannot be serviced
+// temporarily e.g. an upstream service is down.  Use WithRetryAfter to tell
+// the client when to try again.
+func HTTPServiceUnavailable(a ...any) *Error {
+	return newError(http.StatusServiceUnavailable, openapi.ServiceUnavailable, a...)
+}
+
+// IsServiceUnavailable checks if the error is as described.
+func IsServiceUnavailable(err error) bool {
+	return isErrorType(err, http.StatusServiceUnavailable)
+}
+
 // HTTPUnprocessableContent is used when everything is syntactically correct but
 // semantically makes no sense.
 func HTTPUnprocessableContent(a ...any) *Error {
@@ -392,5 +499,23 @@ func HandleError(w http.ResponseWriter, r *http.Request, err error) {
 		return
 	}
 
+	// Request timeouts are enforced by context deadlines, so translate them
+	// into the correct response.
+	if errors.Is(err, context.DeadlineExceeded) {
+		HTTPGatewayTimeout("the request timed out").WithError(err).Write(w, r)
+
+		return
+	}
+
+	// Request body limits are enforced by the standard library, so
+	// translate them into the correct response.
+	var maxBytesError *http.MaxBytesError
+
+	if errors.As(err, &maxBytesError) {
+		HTTPRequestEntityTooLarge("the request body exceeds the maximum size").WithError(err).Write(w, r)
+
+		return
+	}
+
 	newError(http.StatusInternalServerError, openapi.ServerError, "an internal error has occurred, please contact support").WithError(err).Write(w, r)
 }
@@ -0,0 +1,59 @@
+/*
+Copyright 2026 Nscale.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package errors
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/unikorn-cloud/core/pkg/openapi"
+)
+
+var errMarshal = errors.New("marshal failed")
+
+// TestWriteMarshalFailure tests a parseable error is returned to the client even
+// if the error response cannot be marshaled.
+//
+//nolint:paralleltest
+func TestWriteMarshalFailure(t *testing.T) {
+	defer func() {
+		marshal = json.Marshal
+	}()
+
+	marshal = func(any) ([]byte, error) {
+		return nil, errMarshal
+	}
+
+	r := httptest.NewRequest(http.MethodGet, "http://acme.com", nil)
+	w := httptest.NewRecorder()
+
+	HTTPForbidden("you shall not pass!").Write(w, r)
+
+	require.Equal(t, http.StatusForbidden, w.Code)
+	require.Equal(t, "application/json", w.Header().Get("Content-Type"))
+
+	var oapiErr openapi.Error
+
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &oapiErr))
+	require.Equal(t, openapi.ServerError, oapiErr.Error)
+	require.NotEmpty(t, oapiErr.ErrorDescription)
+}
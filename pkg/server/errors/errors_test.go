@@ -19,12 +19,15 @@ package errors_test
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	goerrors "errors"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/otel/trace"
@@ -100,6 +103,69 @@ func TestDefault(t *testing.T) {
 	test.validate(t, w)
 }
 
+// TestMaxBytes tests request body limit errors are handled as a 413.
+func TestMaxBytes(t *testing.T) {
+	t.Parallel()
+
+	w := httptest.NewRecorder()
+
+	r := request(t)
+	r.Body = http.MaxBytesReader(w, io.NopCloser(bytes.NewReader([]byte("too long"))), 1)
+
+	_, err := io.ReadAll(r.Body)
+	require.Error(t, err)
+
+	errors.HandleError(w, r, fmt.Errorf("%w: unable to read request body", err))
+
+	test := &testCase{
+		code:        http.StatusRequestEntityTooLarge,
+		header:      defaultheader(),
+		errorString: openapi.RequestEntityTooLarge,
+	}
+
+	test.validate(t, w)
+}
+
+// TestDeadlineExceeded tests request timeouts are handled as a 504.
+func TestDeadlineExceeded(t *testing.T) {
+	t.Parallel()
+
+	w := httptest.NewRecorder()
+
+	errors.HandleError(w, request(t), fmt.Errorf("%w: unable to list resources", context.DeadlineExceeded))
+
+	test := &testCase{
+		code:        http.StatusGatewayTimeout,
+		header:      defaultheader(),
+		errorString: openapi.GatewayTimeout,
+	}
+
+	test.validate(t, w)
+}
+
+// TestQuotaExhausted tests quota errors are distinguishable from other conflicts.
+func TestQuotaExhausted(t *testing.T) {
+	t.Parallel()
+
+	err := errors.HTTPQuotaExhausted("servers", 5, 4)
+	require.True(t, errors.IsQuotaExhausted(err))
+	require.True(t, errors.IsConflict(err))
+	require.False(t, errors.IsQuotaExhausted(errors.HTTPConflict()))
+
+	w := httptest.NewRecorder()
+
+	errors.HandleError(w, request(t), err)
+
+	test := &testCase{
+		code:        http.StatusConflict,
+		header:      defaultheader(),
+		errorString: openapi.QuotaExhausted,
+		description: "quota exhausted for resource servers: requested 5, limit 4",
+	}
+
+	test.validate(t, w)
+}
+
 // TestFormatting tests argument formatting works like Sprintln without the ln.
 func TestFormatting(t *testing.T) {
 	t.Parallel()
@@ -135,6 +201,70 @@ func TestTraceID(t *testing.T) {
 	require.Equal(t, "0123456789abcdef0123456789abcdef", *oapiErr.TraceId)
 }
 
+// TestCorrelationIDRequestID tests that if no span context is available the
+// client provided request ID is returned to the user.
+func TestCorrelationIDRequestID(t *testing.T) {
+	t.Parallel()
+
+	r := httptest.NewRequest(http.MethodGet, "http://acme.com", nil)
+	r.Header.Set(errors.RequestIDHeader, "cat")
+
+	w := httptest.NewRecorder()
+
+	errors.HandleError(w, r, errors.HTTPForbidden("you shall not pass!"))
+
+	var oapiErr openapi.Error
+
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &oapiErr))
+	require.NotNil(t, oapiErr.TraceId)
+	require.Equal(t, "cat", *oapiErr.TraceId)
+}
+
+// TestCorrelationIDRequestIDInvalid tests that an unsafe client provided request
+// ID is not logged or returned to the user.
+func TestCorrelationIDRequestIDInvalid(t *testing.T) {
+	t.Parallel()
+
+	r := httptest.NewRequest(http.MethodGet, "http://acme.com", nil)
+	r.Header.Set(errors.RequestIDHeader, "cat\ndog")
+
+	w := httptest.NewRecorder()
+
+	errors.HandleError(w, r, errors.HTTPForbidden("you shall not pass!"))
+
+	var oapiErr openapi.Error
+
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &oapiErr))
+	require.NotNil(t, oapiErr.TraceId)
+	require.NotEmpty(t, *oapiErr.TraceId)
+	require.NotEqual(t, "cat\ndog", *oapiErr.TraceId)
+}
+
+// TestCorrelationIDGenerated tests that if no span context or request ID is
+// available a unique ID is still returned to the user.
+func TestCorrelationIDGenerated(t *testing.T) {
+	t.Parallel()
+
+	ids := map[string]bool{}
+
+	for range 2 {
+		r := httptest.NewRequest(http.MethodGet, "http://acme.com", nil)
+		w := httptest.NewRecorder()
+
+		errors.HandleError(w, r, errors.HTTPForbidden("you shall not pass!"))
+
+		var oapiErr openapi.Error
+
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &oapiErr))
+		require.NotNil(t, oapiErr.TraceId)
+		require.NotEmpty(t, *oapiErr.TraceId)
+
+		ids[*oapiErr.TraceId] = true
+	}
+
+	require.Len(t, ids, 2)
+}
+
 // TestNoContext tests handlers that provide no further context.
 func TestNoContext(t *testing.T) {
 	t.Parallel()
@@ -164,6 +294,14 @@ func TestNoContext(t *testing.T) {
 			validator:   errors.IsConflict,
 			errorString: openapi.Conflict,
 		},
+		{
+			name:        "NotImplemented",
+			f:           errors.HTTPNotImplemented,
+			code:        http.StatusNotImplemented,
+			header:      defaultheader(),
+			validator:   errors.IsNotImplemented,
+			errorString: openapi.NotImplemented,
+		},
 	}
 
 	for i := range tests {
@@ -208,6 +346,22 @@ func TestWithContext(t *testing.T) {
 			validator:   errors.IsRequestEntityTooLarge,
 			errorString: openapi.RequestEntityTooLarge,
 		},
+		{
+			name:        "GatewayTimeout",
+			f:           withContextWrapper(errors.HTTPGatewayTimeout),
+			code:        http.StatusGatewayTimeout,
+			header:      defaultheader(),
+			validator:   errors.IsGatewayTimeout,
+			errorString: openapi.GatewayTimeout,
+		},
+		{
+			name:        "ServiceUnavailable",
+			f:           withContextWrapper(errors.HTTPServiceUnavailable),
+			code:        http.StatusServiceUnavailable,
+			header:      defaultheader(),
+			validator:   errors.IsServiceUnavailable,
+			errorString: openapi.ServiceUnavailable,
+		},
 		{
 			name:        "InvalidRequest",
 			f:           withContextWrapper(errors.OAuth2InvalidRequest),
@@ -276,6 +430,127 @@ func httpResponseFixture(statusCode int) *http.Response {
 	}
 }
 
+// TestRetryAfter tests the Retry-After header is rounded up to whole seconds.
+func TestRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	w := httptest.NewRecorder()
+
+	errors.HandleError(w, request(t), errors.HTTPServiceUnavailable("upstream unavailable").WithRetryAfter(1500*time.Millisecond))
+
+	require.Equal(t, http.StatusServiceUnavailable, w.Code)
+	require.Equal(t, "2", w.Header().Get(errors.RetryAfterHeader))
+}
+
+// TestFieldErrors tests validation errors are returned to the client.
+func TestFieldErrors(t *testing.T) {
+	t.Parallel()
+
+	w := httptest.NewRecorder()
+
+	err := errors.HTTPUnprocessableContent("validation failed").WithFieldErrors(
+		openapi.FieldError{Field: "metadata.name", Message: "must not be empty"},
+		openapi.FieldError{Field: "spec.size", Message: "must be positive"},
+	)
+
+	errors.HandleError(w, request(t), err)
+
+	require.Equal(t, http.StatusUnprocessableEntity, w.Code)
+
+	var body map[string]any
+
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	require.Equal(t, "unprocessable_content", body["error"])
+	require.Equal(t, "validation failed", body["error_description"])
+	require.Equal(t, []any{
+		map[string]any{"field": "metadata.name", "message": "must not be empty"},
+		map[string]any{"field": "spec.size", "message": "must be positive"},
+	}, body["details"])
+}
+
+// TestNoFieldErrors tests the details are omitted when there are none.
+func TestNoFieldErrors(t *testing.T) {
+	t.Parallel()
+
+	w := httptest.NewRecorder()
+
+	errors.HandleError(w, request(t), errors.HTTPUnprocessableContent("validation failed"))
+
+	var body map[string]any
+
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	require.NotContains(t, body, "details")
+}
+
+// TestLocalization tests error descriptions are localized according to the
+// Accept-Language header, falling back to English.
+func TestLocalization(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, errors.RegisterMessageCatalog(errors.MessageCatalog{
+		"fr": {
+			openapi.NotFound: "ressource introuvable",
+		},
+	}))
+
+	tests := []struct {
+		name           string
+		acceptLanguage string
+		err            *errors.Error
+		description    string
+		language       string
+	}{
+		{
+			name:           "Translated",
+			acceptLanguage: "de;q=0.5, fr-CA;q=0.9",
+			err:            errors.HTTPNotFound(),
+			description:    "ressource introuvable",
+			language:       "fr",
+		},
+		{
+			name:           "UnknownLanguage",
+			acceptLanguage: "es",
+			err:            errors.HTTPNotFound(),
+			description:    "resource not found",
+		},
+		{
+			name:           "UnknownCode",
+			acceptLanguage: "fr",
+			err:            errors.HTTPConflict(),
+			description:    "the requested resource already exists",
+		},
+		{
+			name:        "NoAcceptLanguage",
+			err:         errors.HTTPNotFound(),
+			description: "resource not found",
+		},
+	}
+
+	for i := range tests {
+		test := &tests[i]
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := request(t)
+
+			if test.acceptLanguage != "" {
+				r.Header.Set("Accept-Language", test.acceptLanguage)
+			}
+
+			w := httptest.NewRecorder()
+
+			errors.HandleError(w, r, test.err)
+
+			var body openapi.Error
+
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+			require.Equal(t, test.description, body.ErrorDescription)
+			require.Equal(t, test.language, w.Header().Get("Content-Language"))
+		})
+	}
+}
+
 // TestPropagateError ensures errors are correctly extracted an propagated.
 func TestPropagateError(t *testing.T) {
 	t.Parallel()
@@ -295,6 +570,36 @@ func TestPropagateError(t *testing.T) {
 	require.True(t, errors.IsBadRequest(err))
 }
 
+// TestPropagateErrorFieldErrors tests validation errors are propagated.
+func TestPropagateErrorFieldErrors(t *testing.T) {
+	t.Parallel()
+
+	fieldErrors := []openapi.FieldError{
+		{Field: "spec.size", Message: "must be positive"},
+	}
+
+	resp := &openapiResponseFixture{
+		JSON400: &openapi.Error{
+			Error:            openapi.InvalidRequest,
+			ErrorDescription: messageFixture,
+			Details:          &fieldErrors,
+		},
+	}
+
+	httpResponse := httpResponseFixture(http.StatusBadRequest)
+	defer httpResponse.Body.Close()
+
+	w := httptest.NewRecorder()
+
+	errors.HandleError(w, request(t), errors.PropagateError(httpResponse, resp))
+
+	var body openapi.Error
+
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	require.NotNil(t, body.Details)
+	require.Equal(t, fieldErrors, *body.Details)
+}
+
 // TestPropagateErrorUnknownCode ensures we can handle something that is unexpected
 // e.g. an ingress going wrong.
 func TestPropagateErrorUnknownCode(t *testing.T) {
@@ -0,0 +1,85 @@
+/*
+Copyright 2026 Nscale.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiversion
+
+import (
+	"net/http"
+	"slices"
+
+	"github.com/spf13/pflag"
+
+	"github.com/unikorn-cloud/core/pkg/server/errors"
+	"github.com/unikorn-cloud/core/pkg/util"
+)
+
+const (
+	// Header is used by clients to optionally pin the API version they
+	// expect, and is always returned with the version that served the request.
+	Header = "X-API-Version"
+
+	// ServerHeader is always returned with the full service version string.
+	ServerHeader = "Server"
+)
+
+type Options struct {
+	// SupportedVersions are the versions a client may request.  If empty
+	// then only the version of the service itself is supported.
+	SupportedVersions []string
+}
+
+func (o *Options) AddFlags(f *pflag.FlagSet) {
+	f.StringSliceVar(&o.SupportedVersions, "api-supported-versions", nil, "API versions a client may pin via the X-API-Version header, defaults to the service version")
+}
+
+// APIVersion echoes the served version on every response, and rejects requests
+// for versions of the API that cannot be served.
+type APIVersion struct {
+	options *Options
+	service util.ServiceDescriptor
+}
+
+func New(options *Options, service util.ServiceDescriptor) *APIVersion {
+	return &APIVersion{
+		options: options,
+		service: service,
+	}
+}
+
+// supported returns whether the requested version can be served.
+func (a *APIVersion) supported(version string) bool {
+	if len(a.options.SupportedVersions) == 0 {
+		return version == a.service.Version
+	}
+
+	return slices.Contains(a.options.SupportedVersions, version)
+}
+
+func (a *APIVersion) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Always add the headers first so they are present on any error
+		// responses too.
+		w.Header().Set(Header, a.service.Version)
+		w.Header().Set(ServerHeader, a.service.VersionString())
+
+		if version := r.Header.Get(Header); version != "" && !a.supported(version) {
+			errors.HandleError(w, r, errors.OAuth2InvalidRequest("requested API version", version, "is not supported"))
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
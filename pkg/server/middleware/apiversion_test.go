@@ -0,0 +1,115 @@
+/*
+Copyright 2026 Nscale.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/unikorn-cloud/core/pkg/server/middleware/apiversion"
+	"github.com/unikorn-cloud/core/pkg/util"
+)
+
+func getAPIVersionHandler(t *testing.T, supported ...string) http.Handler {
+	t.Helper()
+
+	options := &apiversion.Options{
+		SupportedVersions: supported,
+	}
+
+	service := util.ServiceDescriptor{
+		Name:     "test",
+		Version:  "1.2.0",
+		Revision: "abcdef",
+	}
+
+	return apiversion.New(options, service).Middleware(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+}
+
+func apiVersionRequest(t *testing.T, version string) *http.Request {
+	t.Helper()
+
+	r := httptest.NewRequestWithContext(t.Context(), http.MethodGet, path, nil)
+
+	if version != "" {
+		r.Header.Set(apiversion.Header, version)
+	}
+
+	return r
+}
+
+func requireAPIVersionHeaders(t *testing.T, w *httptest.ResponseRecorder) {
+	t.Helper()
+
+	require.Equal(t, "1.2.0", w.Header().Get(apiversion.Header))
+	require.Equal(t, "test/1.2.0 (revision/abcdef)", w.Header().Get(apiversion.ServerHeader))
+}
+
+// TestAPIVersionAbsent checks requests without a pinned version are served.
+func TestAPIVersionAbsent(t *testing.T) {
+	t.Parallel()
+
+	w := httptest.NewRecorder()
+
+	getAPIVersionHandler(t).ServeHTTP(w, apiVersionRequest(t, ""))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	requireAPIVersionHeaders(t, w)
+}
+
+// TestAPIVersionSupported checks requests for a supported version are served.
+func TestAPIVersionSupported(t *testing.T) {
+	t.Parallel()
+
+	w := httptest.NewRecorder()
+
+	getAPIVersionHandler(t, "1.1.0", "1.2.0").ServeHTTP(w, apiVersionRequest(t, "1.1.0"))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	requireAPIVersionHeaders(t, w)
+}
+
+// TestAPIVersionDefaultSupported checks the service version is supported when
+// no explicit set is configured.
+func TestAPIVersionDefaultSupported(t *testing.T) {
+	t.Parallel()
+
+	w := httptest.NewRecorder()
+
+	getAPIVersionHandler(t).ServeHTTP(w, apiVersionRequest(t, "1.2.0"))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	requireAPIVersionHeaders(t, w)
+}
+
+// TestAPIVersionUnsupported checks requests for an unsupported version are
+// rejected, and still report the served version.
+func TestAPIVersionUnsupported(t *testing.T) {
+	t.Parallel()
+
+	w := httptest.NewRecorder()
+
+	getAPIVersionHandler(t, "1.1.0", "1.2.0").ServeHTTP(w, apiVersionRequest(t, "2.0.0"))
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	requireAPIVersionHeaders(t, w)
+}
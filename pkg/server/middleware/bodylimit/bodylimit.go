@@ -0,0 +1,90 @@
+/*
+Copyright 2026 Nscale.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bodylimit
+
+import (
+	"net/http"
+
+	"github.com/spf13/pflag"
+
+	"github.com/unikorn-cloud/core/pkg/server/errors"
+	"github.com/unikorn-cloud/core/pkg/server/middleware/routeresolver"
+)
+
+// MaxBodySizeExtension allows an operation to override the default maximum
+// request body size in bytes e.g. for file uploads.
+const MaxBodySizeExtension = "x-max-body-size"
+
+type Options struct {
+	// MaxSize is the default maximum request body size in bytes.
+	MaxSize int64
+}
+
+func (o *Options) AddFlags(f *pflag.FlagSet) {
+	f.Int64Var(&o.MaxSize, "max-request-body-size", 1<<20, "Default maximum request body size in bytes")
+}
+
+// BodyLimit limits the size of request bodies so a client cannot exhaust
+// memory before a handler runs.
+type BodyLimit struct {
+	options *Options
+}
+
+func New(options *Options) *BodyLimit {
+	return &BodyLimit{
+		options: options,
+	}
+}
+
+// limit returns the maximum body size for the request, as overridden by the
+// OpenAPI operation if the route has been resolved.
+func (m *BodyLimit) limit(r *http.Request) int64 {
+	info, err := routeresolver.FromContext(r.Context())
+	if err != nil || info.Route.Operation == nil {
+		return m.options.MaxSize
+	}
+
+	// Numbers are decoded from the specification as float64.
+	switch t := info.Route.Operation.Extensions[MaxBodySizeExtension].(type) {
+	case float64:
+		return int64(t)
+	case int:
+		return int64(t)
+	case int64:
+		return t
+	}
+
+	return m.options.MaxSize
+}
+
+// Middleware provides an adaptor into chi's routing stack.  Bodies that are
+// known to be too large are rejected immediately, otherwise reads beyond the
+// limit will fail, and are reported by errors.HandleError as too large.
+func (m *BodyLimit) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		limit := m.limit(r)
+
+		if r.ContentLength > limit {
+			errors.HandleError(w, r, errors.HTTPRequestEntityTooLarge("the request body exceeds the maximum size").WithValues("limit", limit, "length", r.ContentLength))
+			return
+		}
+
+		r.Body = http.MaxBytesReader(w, r.Body, limit)
+
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2026 Nscale.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package middleware_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+
+	"github.com/unikorn-cloud/core/pkg/openapi"
+	servererrors "github.com/unikorn-cloud/core/pkg/server/errors"
+	"github.com/unikorn-cloud/core/pkg/server/middleware/bodylimit"
+	"github.com/unikorn-cloud/core/pkg/server/middleware/routeresolver"
+)
+
+const (
+	bodyLimit = 8
+)
+
+// bodyLimitHandler reads the whole body, as a handler would, reporting any errors.
+func bodyLimitHandler(w http.ResponseWriter, r *http.Request) {
+	if _, err := io.ReadAll(r.Body); err != nil {
+		servererrors.HandleError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// doBodyLimitRequest performs a request with a body of the given size, hiding
+// the content length if requested e.g. for chunked encoding.
+func doBodyLimitRequest(t *testing.T, handler http.Handler, path string, size int, chunked bool) *httptest.ResponseRecorder {
+	t.Helper()
+
+	var body io.Reader = bytes.NewReader(bytes.Repeat([]byte("a"), size))
+
+	// Hide the concrete type so the content length cannot be derived.
+	if chunked {
+		body = io.MultiReader(body)
+	}
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequestWithContext(t.Context(), http.MethodPost, path, body))
+
+	return w
+}
+
+// requireTooLarge checks the response is a standard too large error.
+func requireTooLarge(t *testing.T, w *httptest.ResponseRecorder) {
+	t.Helper()
+
+	require.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+
+	var body openapi.Error
+
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	require.Equal(t, openapi.RequestEntityTooLarge, body.Error)
+}
+
+// TestBodyLimit tests bodies under the limit are accepted, and those over it
+// are rejected, whether the length is known up front or not.
+func TestBodyLimit(t *testing.T) {
+	t.Parallel()
+
+	handler := bodylimit.New(&bodylimit.Options{MaxSize: bodyLimit}).Middleware(http.HandlerFunc(bodyLimitHandler))
+
+	for _, chunked := range []bool{false, true} {
+		require.Equal(t, http.StatusOK, doBodyLimitRequest(t, handler, path, bodyLimit, chunked).Code)
+
+		requireTooLarge(t, doBodyLimitRequest(t, handler, path, bodyLimit+1, chunked))
+	}
+}
+
+// TestBodyLimitOperation tests the limit can be overridden by the OpenAPI operation.
+func TestBodyLimitOperation(t *testing.T) {
+	t.Parallel()
+
+	r := chi.NewRouter()
+	r.Use(routeresolver.New(getSchema(t)).Middleware)
+	r.Use(bodylimit.New(&bodylimit.Options{MaxSize: bodyLimit}).Middleware)
+	r.Post("/upload", bodyLimitHandler)
+
+	require.Equal(t, http.StatusOK, doBodyLimitRequest(t, r, "/upload", 16, false).Code)
+
+	requireTooLarge(t, doBodyLimitRequest(t, r, "/upload", 17, false))
+}
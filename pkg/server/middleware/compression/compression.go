@@ -0,0 +1,318 @@
+/*
+Copyright 2026 Nscale.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compression
+
+import (
+	"compress/flate"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/felixge/httpsnoop"
+	"github.com/spf13/pflag"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	encodingGzip    = "gzip"
+	encodingDeflate = "deflate"
+)
+
+type Options struct {
+	// MinSize is the minimum response size to compress, as compressing small
+	// responses costs more than it saves.
+	MinSize int
+	// Level is the compression level, from 1 (best speed) to 9 (best
+	// compression), or -1 for the default.
+	Level int
+}
+
+func (o *Options) AddFlags(f *pflag.FlagSet) {
+	f.IntVar(&o.MinSize, "compression-min-size", 1024, "Minimum response size in bytes to compress")
+	f.IntVar(&o.Level, "compression-level", gzip.DefaultCompression, "Compression level from 1 (best speed) to 9 (best compression), or -1 for the default")
+}
+
+// Compression transparently compresses responses.
+type Compression struct {
+	options *Options
+}
+
+func New(options *Options) *Compression {
+	return &Compression{
+		options: options,
+	}
+}
+
+// negotiate selects a content encoding from the Accept-Encoding header,
+// preferring gzip, and honoring explicit refusals e.g. "gzip;q=0".  A wildcard
+// only selects encodings that have not been refused.
+func negotiate(r *http.Request) string {
+	accepted := map[string]bool{}
+	refused := map[string]bool{}
+
+	for _, field := range strings.Split(r.Header.Get("Accept-Encoding"), ",") {
+		encoding, params, _ := strings.Cut(strings.TrimSpace(field), ";")
+
+		encoding = strings.ToLower(strings.TrimSpace(encoding))
+
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if value, err := strconv.ParseFloat(q, 64); err == nil && value == 0 {
+				refused[encoding] = true
+				continue
+			}
+		}
+
+		accepted[encoding] = true
+	}
+
+	for _, encoding := range []string{encodingGzip, encodingDeflate} {
+		if refused[encoding] {
+			continue
+		}
+
+		if accepted[encoding] || accepted["*"] {
+			return encoding
+		}
+	}
+
+	return ""
+}
+
+// compressible returns false for content types that are already compressed,
+// as compressing them again wastes CPU for no gain.
+func compressible(contentType string) bool {
+	contentType = strings.ToLower(contentType)
+
+	for _, prefix := range []string{"image/", "video/", "audio/", "font/woff"} {
+		if strings.HasPrefix(contentType, prefix) && contentType != "image/svg+xml" {
+			return false
+		}
+	}
+
+	for _, t := range []string{"application/gzip", "application/zip", "application/zstd", "application/x-bzip2", "application/x-xz", "application/x-7z-compressed"} {
+		if strings.HasPrefix(contentType, t) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// writer buffers the response until it knows whether it's large enough to be
+// worth compressing, then either compresses it, or passes it through.
+type writer struct {
+	w        http.ResponseWriter
+	options  *Options
+	encoding string
+
+	// status is the status code written by the handler.
+	status int
+	// buffer holds data until a decision is made.
+	buffer []byte
+	// decided is set once the headers have been written.
+	decided bool
+	// compressor, if set, compresses the response.
+	compressor io.WriteCloser
+}
+
+// newCompressor creates a compressor for the negotiated encoding.
+func (c *writer) newCompressor() (io.WriteCloser, error) {
+	if c.encoding == encodingDeflate {
+		return flate.NewWriter(c.w, c.options.Level)
+	}
+
+	return gzip.NewWriterLevel(c.w, c.options.Level)
+}
+
+// decide writes out the headers, deciding whether to compress based on the
+// buffered data, then flushes the buffer.
+func (c *writer) decide() error {
+	c.decided = true
+
+	// Writing without an explicit status implies a success.
+	if c.status == 0 {
+		c.status = http.StatusOK
+	}
+
+	header := c.w.Header()
+
+	// Anything that's already encoded must be left alone, otherwise the response
+	// varies based on the requested encoding.
+	if header.Get("Content-Encoding") == "" {
+		header.Add("Vary", "Accept-Encoding")
+
+		if header.Get("Content-Type") == "" && len(c.buffer) > 0 {
+			header.Set("Content-Type", http.DetectContentType(c.buffer))
+		}
+
+		if c.encoding != "" && len(c.buffer) >= c.options.MinSize && compressible(header.Get("Content-Type")) && c.status != http.StatusNoContent && c.status != http.StatusNotModified {
+			compressor, err := c.newCompressor()
+			if err != nil {
+				return err
+			}
+
+			c.compressor = compressor
+
+			header.Set("Content-Encoding", c.encoding)
+			header.Del("Content-Length")
+		}
+	}
+
+	c.w.WriteHeader(c.status)
+
+	buffer := c.buffer
+	c.buffer = nil
+
+	if len(buffer) == 0 {
+		return nil
+	}
+
+	_, err := c.writeThrough(buffer)
+
+	return err
+}
+
+// writeThrough writes data once a decision has been made.
+func (c *writer) writeThrough(p []byte) (int, error) {
+	if c.compressor != nil {
+		return c.compressor.Write(p)
+	}
+
+	return c.w.Write(p)
+}
+
+func (c *writer) Write(p []byte) (int, error) {
+	if c.decided {
+		return c.writeThrough(p)
+	}
+
+	c.buffer = append(c.buffer, p...)
+
+	if len(c.buffer) < c.options.MinSize {
+		return len(p), nil
+	}
+
+	if err := c.decide(); err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+func (c *writer) WriteHeader(status int) {
+	// Informational responses are passed straight through.
+	if status < http.StatusOK {
+		c.w.WriteHeader(status)
+		return
+	}
+
+	if c.status == 0 {
+		c.status = status
+	}
+}
+
+func (c *writer) ReadFrom(src io.Reader) (int64, error) {
+	return io.Copy(writerFunc(c.Write), src)
+}
+
+func (c *writer) Flush() error {
+	if !c.decided {
+		if err := c.decide(); err != nil {
+			return err
+		}
+	}
+
+	if flusher, ok := c.compressor.(interface{ Flush() error }); ok {
+		if err := flusher.Flush(); err != nil {
+			return err
+		}
+	}
+
+	if flusher, ok := c.w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+
+	return nil
+}
+
+// Close completes the response.
+func (c *writer) Close() error {
+	// Nothing was written, leave the default behaviour in place.
+	if c.status == 0 && !c.decided && len(c.buffer) == 0 {
+		return nil
+	}
+
+	if !c.decided {
+		if err := c.decide(); err != nil {
+			return err
+		}
+	}
+
+	if c.compressor != nil {
+		return c.compressor.Close()
+	}
+
+	return nil
+}
+
+// writerFunc allows a function to be used as an io.Writer.
+type writerFunc func(p []byte) (int, error)
+
+func (f writerFunc) Write(p []byte) (int, error) {
+	return f(p)
+}
+
+// Middleware provides an adaptor into chi's routing stack.  This should be
+// used inside the logging middleware, so that the bytes written on the wire
+// are recorded.
+func (m *Compression) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c := &writer{
+			w:        w,
+			options:  m.options,
+			encoding: negotiate(r),
+		}
+
+		wrapped := httpsnoop.Wrap(w, httpsnoop.Hooks{
+			Write: func(httpsnoop.WriteFunc) httpsnoop.WriteFunc {
+				return c.Write
+			},
+			WriteHeader: func(httpsnoop.WriteHeaderFunc) httpsnoop.WriteHeaderFunc {
+				return c.WriteHeader
+			},
+			ReadFrom: func(httpsnoop.ReadFromFunc) httpsnoop.ReadFromFunc {
+				return c.ReadFrom
+			},
+			Flush: func(httpsnoop.FlushFunc) httpsnoop.FlushFunc {
+				return func() {
+					if err := c.Flush(); err != nil {
+						log.FromContext(r.Context()).Error(err, "failed to flush compressed response")
+					}
+				}
+			},
+		})
+
+		next.ServeHTTP(wrapped, r)
+
+		if err := c.Close(); err != nil {
+			log.FromContext(r.Context()).Error(err, "failed to complete compressed response")
+		}
+	})
+}
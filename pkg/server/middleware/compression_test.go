@@ -0,0 +1,188 @@
+/*
+Copyright 2026 Nscale.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package middleware_test
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/unikorn-cloud/core/pkg/server/middleware"
+	"github.com/unikorn-cloud/core/pkg/server/middleware/compression"
+)
+
+const (
+	compressionMinSize = 64
+)
+
+// compressionBody returns a body of the requested size.
+func compressionBody(size int) []byte {
+	return bytes.Repeat([]byte("a"), size)
+}
+
+// doCompressionRequest performs a request against a handler that returns the
+// body with the content type.
+func doCompressionRequest(t *testing.T, acceptEncoding, contentType string, body []byte) *httptest.ResponseRecorder {
+	t.Helper()
+
+	options := &compression.Options{
+		MinSize: compressionMinSize,
+		Level:   gzip.DefaultCompression,
+	}
+
+	handler := compression.New(options).Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", contentType)
+		w.WriteHeader(http.StatusCreated)
+
+		// Split writes must be reassembled.
+		_, _ = w.Write(body[:len(body)/2])
+		_, _ = w.Write(body[len(body)/2:])
+	}))
+
+	r := httptest.NewRequestWithContext(t.Context(), http.MethodGet, path, nil)
+
+	if acceptEncoding != "" {
+		r.Header.Set("Accept-Encoding", acceptEncoding)
+	}
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+
+	require.Equal(t, http.StatusCreated, w.Code)
+	require.Equal(t, "Accept-Encoding", w.Header().Get("Vary"))
+
+	return w
+}
+
+// TestCompressionGzip tests large responses are gzipped when accepted.
+func TestCompressionGzip(t *testing.T) {
+	t.Parallel()
+
+	body := compressionBody(compressionMinSize * 4)
+
+	w := doCompressionRequest(t, "deflate, gzip;q=0.5", "application/json", body)
+	require.Equal(t, "gzip", w.Header().Get("Content-Encoding"))
+
+	reader, err := gzip.NewReader(w.Body)
+	require.NoError(t, err)
+
+	data, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	require.Equal(t, body, data)
+}
+
+// TestCompressionDeflate tests large responses are deflated when accepted,
+// and gzip is refused, even when a wildcard is accepted.
+func TestCompressionDeflate(t *testing.T) {
+	t.Parallel()
+
+	body := compressionBody(compressionMinSize * 4)
+
+	for _, acceptEncoding := range []string{"gzip;q=0, deflate", "gzip;q=0, *"} {
+		w := doCompressionRequest(t, acceptEncoding, "application/json", body)
+		require.Equal(t, "deflate", w.Header().Get("Content-Encoding"), acceptEncoding)
+
+		data, err := io.ReadAll(flate.NewReader(w.Body))
+		require.NoError(t, err)
+		require.Equal(t, body, data)
+	}
+}
+
+// TestCompressionSkipped tests responses are passed through when they are
+// small, compression isn't accepted, or the content is already compressed.
+func TestCompressionSkipped(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		acceptEncoding string
+		contentType    string
+		size           int
+	}{
+		{
+			name:           "Small",
+			acceptEncoding: "gzip",
+			contentType:    "application/json",
+			size:           compressionMinSize - 1,
+		},
+		{
+			name:        "NotAccepted",
+			contentType: "application/json",
+			size:        compressionMinSize * 4,
+		},
+		{
+			name:           "Refused",
+			acceptEncoding: "gzip;q=0, deflate;q=0, *",
+			contentType:    "application/json",
+			size:           compressionMinSize * 4,
+		},
+		{
+			name:           "Compressed",
+			acceptEncoding: "gzip",
+			contentType:    "image/png",
+			size:           compressionMinSize * 4,
+		},
+	}
+
+	for i := range tests {
+		test := &tests[i]
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			body := compressionBody(test.size)
+
+			w := doCompressionRequest(t, test.acceptEncoding, test.contentType, body)
+			require.Empty(t, w.Header().Get("Content-Encoding"))
+			require.Equal(t, body, w.Body.Bytes())
+		})
+	}
+}
+
+// TestCompressionCapture tests capture middleware outside of compression
+// observes the bytes written on the wire.
+func TestCompressionCapture(t *testing.T) {
+	t.Parallel()
+
+	body := compressionBody(compressionMinSize * 4)
+
+	options := &compression.Options{
+		MinSize: compressionMinSize,
+		Level:   gzip.BestCompression,
+	}
+
+	handler := compression.New(options).Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(w, bytes.NewReader(body))
+	}))
+
+	r := httptest.NewRequestWithContext(t.Context(), http.MethodGet, path, nil)
+	r.Header.Set("Accept-Encoding", "gzip")
+
+	w := httptest.NewRecorder()
+
+	capture := middleware.CaptureResponse(w, r, handler)
+	require.Equal(t, http.StatusOK, capture.StatusCode())
+	require.Equal(t, "gzip", w.Header().Get("Content-Encoding"))
+	require.Equal(t, w.Body.Bytes(), capture.Body().Bytes())
+	require.Less(t, capture.Body().Len(), len(body))
+}
@@ -18,10 +18,13 @@ limitations under the License.
 package cors
 
 import (
+	goerrors "errors"
+	"fmt"
 	"net/http"
 	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/spf13/pflag"
 
@@ -30,14 +33,42 @@ import (
 	"github.com/unikorn-cloud/core/pkg/util"
 )
 
+var (
+	// ErrInvalidOptions is raised when the CORS options are invalid.
+	ErrInvalidOptions = goerrors.New("invalid CORS options")
+)
+
 type Options struct {
 	AllowedOrigins []string
-	MaxAge         int
+	// AllowedHeaders are additional headers allowed in requests, these are
+	// in addition to those required by the platform.
+	AllowedHeaders []string
+	// AllowCredentials allows browsers to expose responses to credentialed
+	// requests.  Per the CORS specification this cannot be used with a
+	// wildcard origin, only with explicitly allowed origins.
+	AllowCredentials bool
+	// MaxAge is how long a browser may cache preflight responses for,
+	// this is reported with second granularity.  Zero disables caching.
+	MaxAge time.Duration
 }
 
 func (o *Options) AddFlags(f *pflag.FlagSet) {
 	f.StringSliceVar(&o.AllowedOrigins, "cors-allow-origin", []string{"*"}, "CORS allowed origins")
-	f.IntVar(&o.MaxAge, "cors-max-age", 86400, "CORS maximum age (may be overridden by the browser)")
+	f.StringSliceVar(&o.AllowedHeaders, "cors-allow-header", nil, "CORS additional allowed headers")
+	f.BoolVar(&o.AllowCredentials, "cors-allow-credentials", false, "CORS allow credentials for explicitly allowed origins")
+	f.DurationVar(&o.MaxAge, "cors-max-age", 0, "CORS maximum age (may be overridden by the browser)")
+}
+
+// Validate checks the options are consistent, and should be called once
+// flags have been parsed.
+func (o *Options) Validate() error {
+	// Credentials with a wildcard origin are forbidden by the specification
+	// and will be rejected by the browser, so fail fast.
+	if o.AllowCredentials && slices.Contains(o.AllowedOrigins, "*") {
+		return fmt.Errorf("%w: credentials cannot be allowed with a wildcard origin", ErrInvalidOptions)
+	}
+
+	return nil
 }
 
 type CORS struct {
@@ -50,15 +81,54 @@ func New(options *Options) *CORS {
 	}
 }
 
-func (c *CORS) setAllowOrigin(w http.ResponseWriter, r *http.Request) {
+func (c *CORS) allowOrigin(r *http.Request) string {
 	if origin := r.Header.Get("Origin"); origin != "" {
 		if index := slices.IndexFunc(c.options.AllowedOrigins, func(s string) bool { return s == origin }); index >= 0 {
-			w.Header().Add("Access-Control-Allow-Origin", origin)
-			return
+			return origin
 		}
 	}
 
-	w.Header().Add("Access-Control-Allow-Origin", c.options.AllowedOrigins[0])
+	return c.options.AllowedOrigins[0]
+}
+
+func (c *CORS) setAllowOrigin(w http.ResponseWriter, r *http.Request) {
+	origin := c.allowOrigin(r)
+
+	w.Header().Add("Access-Control-Allow-Origin", origin)
+
+	// Anything other than a wildcard depends on the request origin, so
+	// tell shared caches not to serve this response to other origins.
+	if origin != "*" {
+		w.Header().Add("Vary", "Origin")
+	}
+
+	// Options that have not been validated must still never grant
+	// credentials to any origin.
+	if c.options.AllowCredentials && origin != "*" {
+		w.Header().Add("Access-Control-Allow-Credentials", "true")
+	}
+}
+
+// allowHeaders returns the headers required by the platform, plus any
+// additional ones requested by the operator.
+func (c *CORS) allowHeaders() []string {
+	// TODO: I've tried adding them to the schema, but the generator
+	// adds them to the hander function signatures, which is superfluous
+	// to requirements.
+	headers := []string{
+		"Authorization",
+		"Content-Type",
+		"traceparent",
+		"tracestate",
+	}
+
+	for _, header := range c.options.AllowedHeaders {
+		if !slices.ContainsFunc(headers, func(s string) bool { return strings.EqualFold(s, header) }) {
+			headers = append(headers, header)
+		}
+	}
+
+	return headers
 }
 
 func (c *CORS) Middleware(next http.Handler) http.Handler {
@@ -94,19 +164,9 @@ func (c *CORS) Middleware(next http.Handler) http.Handler {
 		methods := util.Keys(route.Route.PathItem.Operations())
 		methods = append(methods, http.MethodOptions)
 
-		// TODO: I've tried adding them to the schema, but the generator
-		// adds them to the hander function signatures, which is superfluous
-		// to requirements.
-		headers := []string{
-			"Authorization",
-			"Content-Type",
-			"traceparent",
-			"tracestate",
-		}
-
 		w.Header().Add("Access-Control-Allow-Methods", strings.Join(methods, ", "))
-		w.Header().Add("Access-Control-Allow-Headers", strings.Join(headers, ", "))
-		w.Header().Add("Access-Control-Max-Age", strconv.Itoa(c.options.MaxAge))
+		w.Header().Add("Access-Control-Allow-Headers", strings.Join(c.allowHeaders(), ", "))
+		w.Header().Add("Access-Control-Max-Age", strconv.Itoa(int(c.options.MaxAge.Seconds())))
 		w.WriteHeader(http.StatusNoContent)
 	})
 }
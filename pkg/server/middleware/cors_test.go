@@ -22,6 +22,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/go-chi/chi/v5"
@@ -103,6 +104,11 @@ func defaultExpectedHeadersWithOrigin(t *testing.T, origin string) http.Header {
 
 	header := http.Header{}
 	header.Add("Access-Control-Allow-Origin", origin)
+
+	if origin != "*" {
+		header.Add("Vary", "Origin")
+	}
+
 	header.Add("Access-Control-Allow-Methods", "GET, OPTIONS")
 	header.Add("Access-Control-Allow-Headers", "Authorization, Content-Type, traceparent, tracestate")
 	header.Add("Access-Control-Max-Age", "0")
@@ -173,3 +179,97 @@ func TestCORSBadRequestMethod(t *testing.T) {
 	handler.ServeHTTP(w, r)
 	require.Equal(t, http.StatusBadRequest, w.Code)
 }
+
+// TestCORSAllowedHeaders checks additional headers are allowed, without
+// duplicating those required by the platform.
+func TestCORSAllowedHeaders(t *testing.T) {
+	t.Parallel()
+
+	options := getOptions(t)
+	options.AllowedHeaders = []string{
+		"X-Tenant-ID",
+		"content-type",
+	}
+
+	handler := getHandler(t, options)
+
+	w := httptest.NewRecorder()
+
+	handler.ServeHTTP(w, defaultRequestWithOrigin(t, origin))
+	require.Equal(t, http.StatusNoContent, w.Code)
+
+	expected := defaultExpectedHeadersWithOrigin(t, "*")
+	expected.Set("Access-Control-Allow-Headers", "Authorization, Content-Type, traceparent, tracestate, X-Tenant-ID")
+
+	require.Equal(t, expected, w.Header())
+}
+
+// TestCORSAllowCredentials checks credentials are allowed for explicit origins.
+func TestCORSAllowCredentials(t *testing.T) {
+	t.Parallel()
+
+	options := getOptions(t, origin)
+	options.AllowCredentials = true
+
+	handler := getHandler(t, options)
+
+	w := httptest.NewRecorder()
+
+	handler.ServeHTTP(w, defaultRequestWithOrigin(t, origin))
+	require.Equal(t, http.StatusNoContent, w.Code)
+
+	expected := defaultExpectedHeadersWithOrigin(t, origin)
+	expected.Add("Access-Control-Allow-Credentials", "true")
+
+	require.Equal(t, expected, w.Header())
+
+	// Non-preflight requests need the header too.
+	w = httptest.NewRecorder()
+
+	r := httptest.NewRequestWithContext(t.Context(), http.MethodGet, path, nil)
+	r.Header.Add("Origin", origin)
+
+	handler.ServeHTTP(w, r)
+	require.Equal(t, "true", w.Header().Get("Access-Control-Allow-Credentials"))
+}
+
+// TestCORSAllowCredentialsWildcard checks credentials are never allowed with
+// a wildcard origin.
+func TestCORSAllowCredentialsWildcard(t *testing.T) {
+	t.Parallel()
+
+	options := getOptions(t, "*", origin)
+	options.AllowCredentials = true
+
+	require.ErrorIs(t, options.Validate(), cors.ErrInvalidOptions)
+
+	// Even if not validated, credentials must not be granted.
+	handler := getHandler(t, options)
+
+	w := httptest.NewRecorder()
+
+	handler.ServeHTTP(w, defaultRequestWithOrigin(t, "https://other.com"))
+	require.Equal(t, http.StatusNoContent, w.Code)
+	require.Equal(t, "*", w.Header().Get("Access-Control-Allow-Origin"))
+	require.Empty(t, w.Header().Get("Access-Control-Allow-Credentials"))
+}
+
+// TestCORSMaxAge checks the preflight maximum age is reported in seconds.
+func TestCORSMaxAge(t *testing.T) {
+	t.Parallel()
+
+	options := getOptions(t)
+	options.MaxAge = 10 * time.Minute
+
+	handler := getHandler(t, options)
+
+	w := httptest.NewRecorder()
+
+	handler.ServeHTTP(w, defaultRequestWithOrigin(t, origin))
+	require.Equal(t, http.StatusNoContent, w.Code)
+
+	expected := defaultExpectedHeadersWithOrigin(t, "*")
+	expected.Set("Access-Control-Max-Age", "600")
+
+	require.Equal(t, expected, w.Header())
+}
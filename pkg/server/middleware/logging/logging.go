@@ -18,8 +18,13 @@ package logging
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/felixge/httpsnoop"
+	"github.com/spf13/pflag"
+
+	"github.com/unikorn-cloud/core/pkg/server/middleware/requestid"
+	"github.com/unikorn-cloud/core/pkg/server/middleware/routeresolver"
 
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
@@ -30,11 +35,30 @@ import (
 // alloate it all the time, and it actually shows up in pprof traces
 // rather than some anonymous closure.
 type Middleware struct {
+	options *Options
+}
+
+// Options allows logging to be tuned.
+type Options struct {
+	// SlowRequestThreshold, when non-zero, logs any response that takes
+	// longer than this to process, regardless of status code or log level.
+	SlowRequestThreshold time.Duration
+}
+
+func (o *Options) AddFlags(f *pflag.FlagSet) {
+	f.DurationVar(&o.SlowRequestThreshold, "slow-request-threshold", 0, "Always log responses that take longer than this, 0 to disable")
 }
 
 // New creates a new logging middleware.
 func New() *Middleware {
-	return &Middleware{}
+	return NewWithOptions(&Options{})
+}
+
+// NewWithOptions creates a new logging middleware with the provided options.
+func NewWithOptions(options *Options) *Middleware {
+	return &Middleware{
+		options: options,
+	}
 }
 
 // headers processes HTTP headers and removes any that are commonly considers
@@ -85,23 +109,30 @@ type RequestLog struct {
 	// Address is the address that made the connection.
 	// NOTE: this should always be an API gateway of some variety.
 	Address string `json:"address,omitempty"`
+	// RequestID is the ID that correlates the request with its response,
+	// and logs, when the requestid middleware has run before this one.
+	RequestID string `json:"requestId,omitempty"`
 	// Headers is the set of HTTP headers requested by the client.
 	Headers http.Header `json:"headers,omitempty"`
 }
 
 // request creates a log request object from a HTTP request.
 func request(r *http.Request) *RequestLog {
+	// Not all servers generate request IDs, so this may be empty.
+	id, _ := requestid.FromContext(r.Context())
+
 	return &RequestLog{
-		Protocol: r.Proto,
-		Scheme:   r.URL.Scheme,
-		Method:   r.Method,
-		Path:     r.URL.Path,
-		Host:     r.URL.Host,
-		Query:    r.URL.RawQuery,
-		Fragment: r.URL.Fragment,
-		Length:   r.ContentLength,
-		Address:  r.RemoteAddr,
-		Headers:  headers(r.Header),
+		Protocol:  r.Proto,
+		Scheme:    r.URL.Scheme,
+		Method:    r.Method,
+		Path:      r.URL.Path,
+		Host:      r.URL.Host,
+		Query:     r.URL.RawQuery,
+		Fragment:  r.URL.Fragment,
+		Length:    r.ContentLength,
+		Address:   r.RemoteAddr,
+		RequestID: id,
+		Headers:   headers(r.Header),
 	}
 }
 
@@ -129,6 +160,11 @@ func response(w http.ResponseWriter, metrics httpsnoop.Metrics) *ResponseLog {
 	}
 }
 
+// slow indicates whether the request exceeded the slow request threshold.
+func (m *Middleware) slow(metrics httpsnoop.Metrics) bool {
+	return m.options.SlowRequestThreshold > 0 && metrics.Duration > m.options.SlowRequestThreshold
+}
+
 // logRequest logs the request to the console.  In general this is unnecessary as
 // all the data is also captured in the response, and as such is disabled by
 // default to reduce log noise and improve performance.
@@ -148,19 +184,32 @@ func (m *Middleware) logRequest(r *http.Request) {
 
 // logResponse logs the response to the console.  Like requests, most good responses
 // are unnecessary by default.  What is always useful is when an error occurs so we
-// capture any 4XX and 5XX errors unconditionally.
+// capture any 4XX and 5XX errors unconditionally.  Likewise slow responses are
+// captured unconditionally when configured, as latency problems are otherwise
+// invisible, and are marked as such.
 func (m *Middleware) logResponse(r *http.Request, w http.ResponseWriter, metrics httpsnoop.Metrics) {
 	log := log.FromContext(r.Context())
 
+	slow := m.slow(metrics)
+
 	// Reject anything that doesn't meet our logging threshold criteria.
 	// This avoids doing any work in gathering log data, that would be
 	// done if we used log.V().Info directly.
-	if !log.V(1).Enabled() && metrics.Code < 400 {
+	if !log.V(1).Enabled() && metrics.Code < 400 && !slow {
 		return
 	}
 
+	keysAndValues := []any{
+		"request", request(r),
+		"response", response(w, metrics),
+	}
+
+	if slow {
+		keysAndValues = append(keysAndValues, "slow", true, "route", routeresolver.Route(r), "duration", metrics.Duration.String())
+	}
+
 	// Ignore verbosity in case we filter on something other than log level,
-	log.Info("http response", "request", request(r), "response", response(w, metrics))
+	log.Info("http response", keysAndValues...)
 }
 
 // Middleware provides an adaptor into chi's routing stack.
@@ -0,0 +1,114 @@
+/*
+Copyright 2026 Nscale.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-logr/logr/funcr"
+	"github.com/stretchr/testify/require"
+
+	"github.com/unikorn-cloud/core/pkg/server/middleware/logging"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	slowPath = "/slow/{id}"
+)
+
+// responses returns the response log lines.
+func (l *logCapture) responses() []string {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	var lines []string
+
+	for _, line := range l.lines {
+		if strings.Contains(line, `"msg"="http response"`) {
+			lines = append(lines, line)
+		}
+	}
+
+	return lines
+}
+
+func doSlowRequest(t *testing.T, delay time.Duration, status int) *logCapture {
+	t.Helper()
+
+	r := chi.NewRouter()
+	r.Use(logging.NewWithOptions(&logging.Options{SlowRequestThreshold: 10 * time.Millisecond}).Middleware)
+	r.Get(slowPath, func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(delay)
+		w.WriteHeader(status)
+	})
+
+	capture := &logCapture{}
+
+	// NOTE: the default verbosity is 0, so successful responses are not logged.
+	ctx := log.IntoContext(t.Context(), funcr.New(capture.write, funcr.Options{}))
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequestWithContext(ctx, http.MethodGet, "/slow/foo", nil))
+
+	require.Equal(t, status, w.Code)
+
+	return capture
+}
+
+// TestSlowRequestLogged tests successful responses exceeding the slow request
+// threshold are logged at the default log level.
+func TestSlowRequestLogged(t *testing.T) {
+	t.Parallel()
+
+	capture := doSlowRequest(t, 50*time.Millisecond, http.StatusOK)
+
+	lines := capture.responses()
+	require.Len(t, lines, 1)
+	require.Contains(t, lines[0], `"slow"=true`)
+	require.Contains(t, lines[0], `"route"="`+slowPath+`"`)
+	require.Contains(t, lines[0], `"duration"=`)
+	require.Contains(t, lines[0], `"code"=200`)
+}
+
+// TestSlowRequestErrorLogged tests error responses exceeding the slow request
+// threshold are logged once, as an error response marked as slow.
+func TestSlowRequestErrorLogged(t *testing.T) {
+	t.Parallel()
+
+	capture := doSlowRequest(t, 50*time.Millisecond, http.StatusInternalServerError)
+
+	lines := capture.responses()
+	require.Len(t, lines, 1)
+	require.Contains(t, lines[0], `"slow"=true`)
+	require.Contains(t, lines[0], `"code"=500`)
+}
+
+// TestFastRequestNotLogged tests successful responses within the slow request
+// threshold are not logged at the default log level.
+func TestFastRequestNotLogged(t *testing.T) {
+	t.Parallel()
+
+	capture := doSlowRequest(t, 0, http.StatusOK)
+
+	require.Empty(t, capture.lines)
+}
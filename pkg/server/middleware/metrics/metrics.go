@@ -0,0 +1,83 @@
+/*
+Copyright 2026 Nscale.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/felixge/httpsnoop"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/unikorn-cloud/core/pkg/server/middleware/routeresolver"
+)
+
+// Middleware records Prometheus metrics for HTTP requests.
+type Middleware struct {
+	// requests counts requests by method, route and status code.
+	requests *prometheus.CounterVec
+	// inFlight records the number of requests being handled by method and route.
+	inFlight *prometheus.GaugeVec
+	// duration records the wall clock time of requests by method and route.
+	duration *prometheus.HistogramVec
+}
+
+// New creates a new metrics middleware, registering its collectors with the
+// provided registerer.
+func New(registerer prometheus.Registerer) (*Middleware, error) {
+	m := &Middleware{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "unikorn_http_requests_total",
+			Help: "Total number of HTTP requests by method, route and status code.",
+		}, []string{"method", "route", "code"}),
+		inFlight: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "unikorn_http_requests_in_flight",
+			Help: "Number of HTTP requests being handled by method and route.",
+		}, []string{"method", "route"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "unikorn_http_request_duration_seconds",
+			Help:    "Wall clock time of HTTP requests by method and route.",
+			Buckets: prometheus.ExponentialBuckets(0.001, 2, 16),
+		}, []string{"method", "route"}),
+	}
+
+	for _, collector := range []prometheus.Collector{m.requests, m.inFlight, m.duration} {
+		if err := registerer.Register(collector); err != nil {
+			return nil, err
+		}
+	}
+
+	return m, nil
+}
+
+// Middleware provides an adaptor into chi's routing stack.  This must be used
+// after the route resolver middleware.
+func (m *Middleware) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		route := routeresolver.Route(r)
+
+		inFlight := m.inFlight.WithLabelValues(r.Method, route)
+		inFlight.Inc()
+
+		defer inFlight.Dec()
+
+		metrics := httpsnoop.CaptureMetrics(next, w, r)
+
+		m.requests.WithLabelValues(r.Method, route, strconv.Itoa(metrics.Code)).Inc()
+		m.duration.WithLabelValues(r.Method, route).Observe(metrics.Duration.Seconds())
+	})
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2026 Nscale.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/unikorn-cloud/core/pkg/server/middleware/metrics"
+	"github.com/unikorn-cloud/core/pkg/server/middleware/routeresolver"
+)
+
+// gatherLabels returns the labels of each metric in the named family.
+func gatherLabels(t *testing.T, registry *prometheus.Registry, name string) []map[string]string {
+	t.Helper()
+
+	families, err := registry.Gather()
+	require.NoError(t, err)
+
+	var result []map[string]string
+
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+
+		for _, metric := range family.GetMetric() {
+			labels := map[string]string{}
+
+			for _, label := range metric.GetLabel() {
+				labels[label.GetName()] = label.GetValue()
+			}
+
+			result = append(result, labels)
+		}
+	}
+
+	return result
+}
+
+// TestMetrics tests requests are recorded by method, OpenAPI route and status
+// code, without resource IDs in the labels.
+func TestMetrics(t *testing.T) {
+	t.Parallel()
+
+	registry := prometheus.NewRegistry()
+
+	m, err := metrics.New(registry)
+	require.NoError(t, err)
+
+	r := chi.NewRouter()
+	r.Use(routeresolver.New(getSchema(t)).Middleware)
+	r.Use(m.Middleware)
+	r.Get("/api/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+	})
+
+	for _, id := range []string{"foo", "bar"} {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequestWithContext(t.Context(), http.MethodGet, "/api/"+id, nil))
+
+		require.Equal(t, http.StatusAccepted, w.Code)
+	}
+
+	expected := map[string]string{
+		"method": http.MethodGet,
+		"route":  "/api/{id}",
+		"code":   "202",
+	}
+
+	require.Equal(t, []map[string]string{expected}, gatherLabels(t, registry, "unikorn_http_requests_total"))
+
+	delete(expected, "code")
+
+	require.Equal(t, []map[string]string{expected}, gatherLabels(t, registry, "unikorn_http_request_duration_seconds"))
+	require.Equal(t, []map[string]string{expected}, gatherLabels(t, registry, "unikorn_http_requests_in_flight"))
+}
+
+// TestMetricsUnresolved tests requests without a resolved route are recorded
+// as unknown, rather than by path.
+func TestMetricsUnresolved(t *testing.T) {
+	t.Parallel()
+
+	registry := prometheus.NewRegistry()
+
+	m, err := metrics.New(registry)
+	require.NoError(t, err)
+
+	handler := m.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequestWithContext(t.Context(), http.MethodGet, "/missing/foo", nil))
+
+	expected := map[string]string{
+		"method": http.MethodGet,
+		"route":  "unknown",
+		"code":   "404",
+	}
+
+	require.Equal(t, []map[string]string{expected}, gatherLabels(t, registry, "unikorn_http_requests_total"))
+}
@@ -19,19 +19,24 @@ limitations under the License.
 package opentelemetry
 
 import (
+	"context"
 	"net/http"
 	"slices"
 	"strconv"
 	"strings"
 
 	"github.com/felixge/httpsnoop"
+	"github.com/spf13/pflag"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/propagation"
 	semconv "go.opentelemetry.io/otel/semconv/v1.22.0"
 	"go.opentelemetry.io/otel/trace"
 
+	"github.com/unikorn-cloud/core/pkg/server/middleware/routeresolver"
+
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
@@ -187,9 +192,42 @@ func httpStatusToOtelCode(status int) (codes.Code, string) {
 	return code, http.StatusText(status)
 }
 
+// metricAttributeKeys are the request attributes that are safe to use for
+// metrics, everything else has unbounded cardinality.
+func metricAttributeKeys() []attribute.Key {
+	return []attribute.Key{
+		semconv.HTTPRequestMethodKey,
+		semconv.NetworkProtocolNameKey,
+		semconv.NetworkProtocolVersionKey,
+		semconv.URLSchemeKey,
+	}
+}
+
+// statusClass returns the status class e.g. 2xx, that keeps cardinality down
+// while still allowing error rates to be derived.
+func statusClass(status int) string {
+	return strconv.Itoa(status/100) + "xx"
+}
+
+// Options allows the middleware to be tuned.
+type Options struct {
+	// Metrics enables HTTP server metrics.
+	Metrics bool
+	// MeterProvider is used to create metric instruments, defaulting to
+	// the global provider.
+	MeterProvider metric.MeterProvider
+}
+
+func (o *Options) AddFlags(f *pflag.FlagSet) {
+	f.BoolVar(&o.Metrics, "otel-http-metrics", false, "Record OpenTelemetry HTTP server metrics")
+}
+
 type OpenTelemetry struct {
 	serviceName string
 	version     string
+	// duration records request durations, and by extension counts, this is
+	// nil when metrics are disabled.
+	duration metric.Float64Histogram
 }
 
 func New(serviceName, version string) *OpenTelemetry {
@@ -199,6 +237,57 @@ func New(serviceName, version string) *OpenTelemetry {
 	}
 }
 
+// NewWithOptions creates a new middleware, creating any metric instruments
+// that are enabled.
+func NewWithOptions(serviceName, version string, options *Options) (*OpenTelemetry, error) {
+	o := New(serviceName, version)
+
+	if !options.Metrics {
+		return o, nil
+	}
+
+	provider := options.MeterProvider
+	if provider == nil {
+		provider = otel.GetMeterProvider()
+	}
+
+	duration, err := provider.Meter("opentelemetry middleware").Float64Histogram("http.server.request.duration",
+		metric.WithUnit("s"),
+		metric.WithDescription("Duration of HTTP server requests."),
+		metric.WithExplicitBucketBoundaries(0.005, 0.01, 0.025, 0.05, 0.075, 0.1, 0.25, 0.5, 0.75, 1, 2.5, 5, 7.5, 10),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	o.duration = duration
+
+	return o, nil
+}
+
+// recordMetrics records the request duration, the histogram count also serves
+// as the request count.
+func (o *OpenTelemetry) recordMetrics(ctx context.Context, r *http.Request, m httpsnoop.Metrics) {
+	if o.duration == nil {
+		return
+	}
+
+	attr := []attribute.KeyValue{
+		semconv.ServiceName(o.serviceName),
+		semconv.ServiceVersion(o.version),
+		semconv.HTTPRoute(routeresolver.Route(r)),
+		attribute.String("http.response.status_class", statusClass(m.Code)),
+	}
+
+	for _, kv := range httpRequestAttributes(r) {
+		if slices.Contains(metricAttributeKeys(), kv.Key) {
+			attr = append(attr, kv)
+		}
+	}
+
+	o.duration.Record(ctx, m.Duration.Seconds(), metric.WithAttributes(attr...))
+}
+
 // Middleware attaches logging context to the request.
 func (o *OpenTelemetry) Middleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -231,5 +320,7 @@ func (o *OpenTelemetry) Middleware(next http.Handler) http.Handler {
 		// Extract HTTP response information for logging purposes.
 		span.SetAttributes(httpResponseAttributes(metrics, w)...)
 		span.SetStatus(httpStatusToOtelCode(metrics.Code))
+
+		o.recordMetrics(ctx, request, metrics)
 	})
 }
@@ -0,0 +1,112 @@
+/*
+Copyright 2026 Nscale.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	"github.com/unikorn-cloud/core/pkg/server/middleware/opentelemetry"
+)
+
+// doOpenTelemetryRequest performs a request through the OpenTelemetry middleware
+// and returns any collected metrics.
+func doOpenTelemetryRequest(t *testing.T, enabled bool) metricdata.ResourceMetrics {
+	t.Helper()
+
+	reader := sdkmetric.NewManualReader()
+
+	options := &opentelemetry.Options{
+		Metrics:       enabled,
+		MeterProvider: sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)),
+	}
+
+	o, err := opentelemetry.NewWithOptions("test", "v1.0.0", options)
+	require.NoError(t, err)
+
+	r := chi.NewRouter()
+	r.Use(o.Middleware)
+	r.Get("/api/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequestWithContext(t.Context(), http.MethodGet, "/api/foo", nil))
+
+	require.Equal(t, http.StatusNotFound, w.Code)
+
+	var metrics metricdata.ResourceMetrics
+
+	require.NoError(t, reader.Collect(t.Context(), &metrics))
+
+	return metrics
+}
+
+// TestOpenTelemetryMetrics tests request durations are recorded with bounded
+// cardinality attributes.
+func TestOpenTelemetryMetrics(t *testing.T) {
+	t.Parallel()
+
+	metrics := doOpenTelemetryRequest(t, true)
+
+	require.Len(t, metrics.ScopeMetrics, 1)
+	require.Len(t, metrics.ScopeMetrics[0].Metrics, 1)
+
+	duration := metrics.ScopeMetrics[0].Metrics[0]
+	require.Equal(t, "http.server.request.duration", duration.Name)
+
+	histogram, ok := duration.Data.(metricdata.Histogram[float64])
+	require.True(t, ok)
+	require.Len(t, histogram.DataPoints, 1)
+
+	point := histogram.DataPoints[0]
+	require.Equal(t, uint64(1), point.Count)
+
+	expected := map[attribute.Key]string{
+		"service.name":               "test",
+		"service.version":            "v1.0.0",
+		"http.request.method":        http.MethodGet,
+		"http.route":                 "/api/{id}",
+		"http.response.status_class": "4xx",
+		"url.scheme":                 "http",
+	}
+
+	for key, value := range expected {
+		v, ok := point.Attributes.Value(key)
+		require.True(t, ok, key)
+		require.Equal(t, value, v.AsString())
+	}
+
+	_, ok = point.Attributes.Value("url.path")
+	require.False(t, ok)
+}
+
+// TestOpenTelemetryMetricsDisabled tests no metrics are recorded by default.
+func TestOpenTelemetryMetricsDisabled(t *testing.T) {
+	t.Parallel()
+
+	metrics := doOpenTelemetryRequest(t, false)
+
+	require.Empty(t, metrics.ScopeMetrics)
+}
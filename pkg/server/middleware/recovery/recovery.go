@@ -0,0 +1,104 @@
+/*
+Copyright 2026 Nscale.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package recovery
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"runtime/debug"
+
+	"github.com/felixge/httpsnoop"
+
+	servererrors "github.com/unikorn-cloud/core/pkg/server/errors"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var (
+	// ErrPanic is used to report handler panics.
+	ErrPanic = errors.New("handler panic")
+)
+
+// Middleware recovers handler panics and reports them to the client as
+// internal errors, provided the handler has not started writing a response.
+type Middleware struct{}
+
+// New creates a new recovery middleware.
+func New() *Middleware {
+	return &Middleware{}
+}
+
+// Middleware provides an adaptor into chi's routing stack.  This should be used
+// after the opentelemetry middleware so panics can be correlated with traces.
+func (m *Middleware) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var written bool
+
+		wrapped := httpsnoop.Wrap(w, httpsnoop.Hooks{
+			WriteHeader: func(next httpsnoop.WriteHeaderFunc) httpsnoop.WriteHeaderFunc {
+				return func(code int) {
+					written = true
+
+					next(code)
+				}
+			},
+			Write: func(next httpsnoop.WriteFunc) httpsnoop.WriteFunc {
+				return func(p []byte) (int, error) {
+					written = true
+
+					return next(p)
+				}
+			},
+			ReadFrom: func(next httpsnoop.ReadFromFunc) httpsnoop.ReadFromFunc {
+				return func(src io.Reader) (int64, error) {
+					written = true
+
+					return next(src)
+				}
+			},
+		})
+
+		defer func() {
+			x := recover()
+			if x == nil {
+				return
+			}
+
+			// This is used by the standard library to abort a response, and
+			// must be allowed to propagate.
+			if x == http.ErrAbortHandler {
+				panic(x)
+			}
+
+			err := fmt.Errorf("%w: %v", ErrPanic, x)
+
+			log.FromContext(r.Context()).Error(err, "caught unhandled exception", "stack", string(debug.Stack()))
+
+			// Once the response has started, the status cannot be changed and
+			// an error body would corrupt what has been written, so just log.
+			if written {
+				return
+			}
+
+			servererrors.HandleError(w, r, err)
+		}()
+
+		next.ServeHTTP(wrapped, r)
+	})
+}
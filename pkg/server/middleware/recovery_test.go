@@ -0,0 +1,94 @@
+/*
+Copyright 2026 Nscale.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package middleware_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/unikorn-cloud/core/pkg/openapi"
+	"github.com/unikorn-cloud/core/pkg/server/middleware/recovery"
+)
+
+// TestRecovery tests a panicking handler yields a standard internal error,
+// correlated with the request's trace.
+func TestRecovery(t *testing.T) {
+	t.Parallel()
+
+	handler := recovery.New().Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	}))
+
+	spanContext := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: trace.TraceID{0x01},
+		SpanID:  trace.SpanID{0x01},
+	})
+
+	ctx := trace.ContextWithSpanContext(t.Context(), spanContext)
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequestWithContext(ctx, http.MethodGet, path, nil))
+
+	require.Equal(t, http.StatusInternalServerError, w.Code)
+	require.Equal(t, "application/json", w.Header().Get("Content-Type"))
+
+	var body openapi.Error
+
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	require.Equal(t, openapi.ServerError, body.Error)
+	require.NotNil(t, body.TraceId)
+	require.Equal(t, spanContext.TraceID().String(), *body.TraceId)
+}
+
+// TestRecoveryAbort tests http.ErrAbortHandler is propagated.
+func TestRecoveryAbort(t *testing.T) {
+	t.Parallel()
+
+	handler := recovery.New().Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic(http.ErrAbortHandler)
+	}))
+
+	require.PanicsWithValue(t, http.ErrAbortHandler, func() {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequestWithContext(t.Context(), http.MethodGet, path, nil))
+	})
+}
+
+// TestRecoveryResponseStarted tests a handler that panics after starting its
+// response has it left untouched.
+func TestRecoveryResponseStarted(t *testing.T) {
+	t.Parallel()
+
+	handler := recovery.New().Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+
+		_, _ = w.Write([]byte("partial"))
+
+		panic("boom")
+	}))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequestWithContext(t.Context(), http.MethodGet, path, nil))
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "partial", w.Body.String())
+	require.Empty(t, w.Header().Get("Content-Type"))
+}
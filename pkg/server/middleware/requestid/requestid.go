@@ -0,0 +1,136 @@
+/*
+Copyright 2026 Nscale.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestid
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/unikorn-cloud/core/pkg/errors"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	// Header is a de facto standard header used to correlate requests
+	// when tracing is unavailable.
+	Header = "X-Request-ID"
+
+	// maxLength limits the size of client provided request IDs so they cannot
+	// be used to bloat logs.
+	maxLength = 128
+)
+
+type RequestIDKeyType int
+
+const (
+	RequestIDKey RequestIDKeyType = iota
+)
+
+// NewContext returns a new context with the request ID attached.
+func NewContext(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, RequestIDKey, id)
+}
+
+// FromContext returns the request ID from the context.
+func FromContext(ctx context.Context) (string, error) {
+	v, ok := ctx.Value(RequestIDKey).(string)
+	if !ok {
+		return "", fmt.Errorf("%w: request ID not in context", errors.ErrKey)
+	}
+
+	return v, nil
+}
+
+// valid checks a client provided request ID is of a sane length and only
+// contains printable ASCII characters, so it's safe to log and echo back.
+func valid(id string) bool {
+	if id == "" || len(id) > maxLength {
+		return false
+	}
+
+	for i := range len(id) {
+		if id[i] < ' ' || id[i] > '~' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// requestID returns the request ID.  This is preferably the trace ID so that
+// it correlates with telemetry, then any request ID provided by the client,
+// otherwise a randomly generated ID.
+func requestID(r *http.Request) string {
+	if spanContext := trace.SpanContextFromContext(r.Context()); spanContext.HasTraceID() {
+		return spanContext.TraceID().String()
+	}
+
+	if id := r.Header.Get(Header); valid(id) {
+		return id
+	}
+
+	var id [16]byte
+
+	// This never returns an error.
+	_, _ = rand.Read(id[:])
+
+	return hex.EncodeToString(id[:])
+}
+
+// FromRequest returns the request ID attached by the middleware, or if that
+// hasn't run, derives one in the same way so it is always safe to log and
+// echo back to the client.
+func FromRequest(r *http.Request) string {
+	if id, err := FromContext(r.Context()); err == nil {
+		return id
+	}
+
+	return requestID(r)
+}
+
+// RequestID ensures every request has an ID a client can quote to support,
+// regardless of whether tracing is enabled.
+type RequestID struct{}
+
+func New() *RequestID {
+	return &RequestID{}
+}
+
+// Middleware provides an adaptor into chi's routing stack.  The request ID is
+// added to the context, the request logger and the response headers.  It is also
+// set on the request so errors report the same correlation ID.
+func (m *RequestID) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := requestID(r)
+
+		ctx := NewContext(r.Context(), id)
+		ctx = log.IntoContext(ctx, log.FromContext(ctx).WithValues("requestID", id))
+
+		request := r.Clone(ctx)
+		request.Header.Set(Header, id)
+
+		w.Header().Set(Header, id)
+
+		next.ServeHTTP(w, request)
+	})
+}
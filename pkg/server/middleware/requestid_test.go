@@ -0,0 +1,141 @@
+/*
+Copyright 2026 Nscale.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package middleware_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr/funcr"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/unikorn-cloud/core/pkg/server/errors"
+	"github.com/unikorn-cloud/core/pkg/server/middleware/logging"
+	"github.com/unikorn-cloud/core/pkg/server/middleware/requestid"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// doRequestID performs a request through the request ID middleware, returning
+// the ID seen by the handler and the response.
+func doRequestID(t *testing.T, ctx context.Context, id string) (string, *httptest.ResponseRecorder) {
+	t.Helper()
+
+	var seen string
+
+	handler := requestid.New().Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		v, err := requestid.FromContext(r.Context())
+		require.NoError(t, err)
+
+		seen = v
+	}))
+
+	r := httptest.NewRequestWithContext(ctx, http.MethodGet, path, nil)
+
+	if id != "" {
+		r.Header.Set(errors.RequestIDHeader, id)
+	}
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+
+	return seen, w
+}
+
+// TestRequestIDInbound tests a client provided request ID is used and echoed.
+func TestRequestIDInbound(t *testing.T) {
+	t.Parallel()
+
+	id, w := doRequestID(t, t.Context(), "cat")
+	require.Equal(t, "cat", id)
+	require.Equal(t, "cat", w.Header().Get(errors.RequestIDHeader))
+}
+
+// TestRequestIDGenerated tests a request ID is generated when none is provided.
+func TestRequestIDGenerated(t *testing.T) {
+	t.Parallel()
+
+	id, w := doRequestID(t, t.Context(), "")
+	require.NotEmpty(t, id)
+	require.Equal(t, id, w.Header().Get(errors.RequestIDHeader))
+}
+
+// TestRequestIDInvalid tests an unsafe client provided request ID is replaced.
+func TestRequestIDInvalid(t *testing.T) {
+	t.Parallel()
+
+	for _, invalid := range []string{"cat\tdog", strings.Repeat("a", 129)} {
+		id, w := doRequestID(t, t.Context(), invalid)
+		require.NotEqual(t, invalid, id)
+		require.NotEmpty(t, id)
+		require.Equal(t, id, w.Header().Get(errors.RequestIDHeader))
+	}
+}
+
+// TestRequestIDTrace tests the trace ID is preferred for continuity with telemetry.
+func TestRequestIDTrace(t *testing.T) {
+	t.Parallel()
+
+	spanContext := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: trace.TraceID{0x01},
+		SpanID:  trace.SpanID{0x01},
+	})
+
+	ctx := trace.ContextWithSpanContext(t.Context(), spanContext)
+
+	id, w := doRequestID(t, ctx, "cat")
+	require.Equal(t, spanContext.TraceID().String(), id)
+	require.Equal(t, id, w.Header().Get(errors.RequestIDHeader))
+}
+
+// TestRequestIDLogging tests the request ID is included in request logs and
+// correlates with error responses.
+func TestRequestIDLogging(t *testing.T) {
+	t.Parallel()
+
+	handler := requestid.New().Middleware(logging.New().Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errors.HandleError(w, r, errors.HTTPNotFound())
+	})))
+
+	capture := &logCapture{}
+
+	ctx := log.IntoContext(t.Context(), funcr.New(capture.write, funcr.Options{}))
+
+	r := httptest.NewRequestWithContext(ctx, http.MethodGet, path, nil)
+	r.Header.Set(errors.RequestIDHeader, "cat")
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+
+	require.Equal(t, http.StatusNotFound, w.Code)
+
+	line := capture.errorDetail()
+	require.Contains(t, line, `"requestID"="cat"`)
+	require.Contains(t, line, `"correlationID"="cat"`)
+
+	capture.lock.Lock()
+	defer capture.lock.Unlock()
+
+	require.True(t, slices.ContainsFunc(capture.lines, func(line string) bool {
+		return strings.Contains(line, `"msg"="http response"`) && strings.Contains(line, `"requestId"="cat"`)
+	}))
+}
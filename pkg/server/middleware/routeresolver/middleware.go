@@ -22,10 +22,22 @@ import (
 	"net/http"
 
 	"github.com/getkin/kin-openapi/routers"
+	"github.com/go-chi/chi/v5"
+	"github.com/spf13/pflag"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/unikorn-cloud/core/pkg/errors"
 	"github.com/unikorn-cloud/core/pkg/openapi/helpers"
 	servererrors "github.com/unikorn-cloud/core/pkg/server/errors"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	// UnknownRoute is used when the route cannot be determined, this keeps
+	// metric cardinality bounded in the face of arbitrary paths.
+	UnknownRoute = "unknown"
 )
 
 type RouteInfo struct {
@@ -48,18 +60,65 @@ func FromContext(ctx context.Context) (*RouteInfo, error) {
 	return v, nil
 }
 
+// Route returns a low cardinality route for the request, for use in metrics,
+// logs and traces.  This is the resolved OpenAPI path, then the chi routing
+// pattern for routes outside of the schema, otherwise UnknownRoute.
+func Route(r *http.Request) string {
+	if info, err := FromContext(r.Context()); err == nil {
+		return info.Route.Path
+	}
+
+	if rctx := chi.RouteContext(r.Context()); rctx != nil {
+		if pattern := rctx.RoutePattern(); pattern != "" {
+			return pattern
+		}
+	}
+
+	return UnknownRoute
+}
+
+type Options struct {
+	// LogOperationID adds the resolved OpenAPI operationId to the request
+	// logger, so it appears in error and audit logs, and the trace span.
+	LogOperationID bool
+}
+
+func (o *Options) AddFlags(f *pflag.FlagSet) {
+	f.BoolVar(&o.LogOperationID, "log-operation-id", false, "Include the OpenAPI operationId in request logs and traces")
+}
+
 // RouteResolver performs the relatively costly translation from request URL to an
 // OpenAPI route once and stashes it in the context for easy use by other middlewares.
 type RouteResolver struct {
-	schema *helpers.Schema
+	schema  *helpers.Schema
+	options *Options
 }
 
 func New(schema *helpers.Schema) *RouteResolver {
+	return NewWithOptions(schema, &Options{})
+}
+
+func NewWithOptions(schema *helpers.Schema, options *Options) *RouteResolver {
 	return &RouteResolver{
-		schema: schema,
+		schema:  schema,
+		options: options,
 	}
 }
 
+// withOperationID annotates the request logger and trace span with the operationId
+// as it is far easier to correlate with an API endpoint than the raw path.
+func (m *RouteResolver) withOperationID(ctx context.Context, route *routers.Route) context.Context {
+	if !m.options.LogOperationID || route.Operation == nil || route.Operation.OperationID == "" {
+		return ctx
+	}
+
+	id := route.Operation.OperationID
+
+	trace.SpanFromContext(ctx).SetAttributes(attribute.String("openapi.operation_id", id))
+
+	return log.IntoContext(ctx, log.FromContext(ctx).WithValues("operationID", id))
+}
+
 func (m *RouteResolver) Middleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		routeRequest := r
@@ -84,7 +143,7 @@ func (m *RouteResolver) Middleware(next http.Handler) http.Handler {
 			return
 		}
 
-		ctx := context.WithValue(r.Context(), RouteInfoKey, &RouteInfo{
+		ctx := context.WithValue(m.withOperationID(r.Context(), route), RouteInfoKey, &RouteInfo{
 			Route:      route,
 			Parameters: parameters,
 		})
@@ -0,0 +1,133 @@
+/*
+Copyright 2026 Nscale.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-logr/logr/funcr"
+	"github.com/stretchr/testify/require"
+
+	servererrors "github.com/unikorn-cloud/core/pkg/server/errors"
+	"github.com/unikorn-cloud/core/pkg/server/middleware/routeresolver"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// logCapture records formatted log lines.
+type logCapture struct {
+	lock  sync.Mutex
+	lines []string
+}
+
+func (l *logCapture) write(prefix, args string) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	l.lines = append(l.lines, prefix+args)
+}
+
+// errorDetail returns the error detail log line, if one was logged.
+func (l *logCapture) errorDetail() string {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	for _, line := range l.lines {
+		if strings.Contains(line, `"msg"="error detail"`) {
+			return line
+		}
+	}
+
+	return ""
+}
+
+func getOperationIDHandler(t *testing.T, options *routeresolver.Options) http.Handler {
+	t.Helper()
+
+	r := chi.NewRouter()
+	r.Use(routeresolver.NewWithOptions(getSchema(t), options).Middleware)
+	r.Get(path, func(w http.ResponseWriter, r *http.Request) {
+		servererrors.HandleError(w, r, servererrors.HTTPConflict())
+	})
+
+	return r
+}
+
+func doOperationIDRequest(t *testing.T, handler http.Handler) *logCapture {
+	t.Helper()
+
+	capture := &logCapture{}
+
+	ctx := log.IntoContext(t.Context(), funcr.New(capture.write, funcr.Options{}))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequestWithContext(ctx, http.MethodGet, path, nil))
+
+	require.Equal(t, http.StatusConflict, w.Code)
+
+	return capture
+}
+
+// TestOperationIDLogged tests errors for a known route log the operationId.
+func TestOperationIDLogged(t *testing.T) {
+	t.Parallel()
+
+	capture := doOperationIDRequest(t, getOperationIDHandler(t, &routeresolver.Options{LogOperationID: true}))
+
+	require.Contains(t, capture.errorDetail(), `"operationID"="getApi"`)
+}
+
+// TestOperationIDNotLogged tests the operationId is omitted unless requested.
+func TestOperationIDNotLogged(t *testing.T) {
+	t.Parallel()
+
+	capture := doOperationIDRequest(t, getOperationIDHandler(t, &routeresolver.Options{}))
+
+	detail := capture.errorDetail()
+	require.NotEmpty(t, detail)
+	require.NotContains(t, detail, "operationID")
+}
+
+// TestRoute tests route labels prefer the OpenAPI path, then the chi pattern,
+// and are otherwise unknown rather than the raw path.
+func TestRoute(t *testing.T) {
+	t.Parallel()
+
+	var routes []string
+
+	record := func(w http.ResponseWriter, r *http.Request) {
+		routes = append(routes, routeresolver.Route(r))
+	}
+
+	resolved := chi.NewRouter()
+	resolved.Use(routeresolver.New(getSchema(t)).Middleware)
+	resolved.Get("/api/{id}", record)
+
+	unresolved := chi.NewRouter()
+	unresolved.Get("/other/{id}", record)
+
+	resolved.ServeHTTP(httptest.NewRecorder(), httptest.NewRequestWithContext(t.Context(), http.MethodGet, "/api/foo", nil))
+	unresolved.ServeHTTP(httptest.NewRecorder(), httptest.NewRequestWithContext(t.Context(), http.MethodGet, "/other/foo", nil))
+	http.HandlerFunc(record).ServeHTTP(httptest.NewRecorder(), httptest.NewRequestWithContext(t.Context(), http.MethodGet, "/missing/foo", nil))
+
+	require.Equal(t, []string{"/api/{id}", "/other/{id}", routeresolver.UnknownRoute}, routes)
+}
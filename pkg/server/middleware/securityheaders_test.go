@@ -0,0 +1,83 @@
+/*
+Copyright 2026 Nscale.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/require"
+
+	"github.com/unikorn-cloud/core/pkg/server/errors"
+	"github.com/unikorn-cloud/core/pkg/server/middleware/securityheaders"
+)
+
+func getSecurityHeadersOptions(t *testing.T, args ...string) *securityheaders.Options {
+	t.Helper()
+
+	options := &securityheaders.Options{}
+
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	options.AddFlags(flags)
+
+	require.NoError(t, flags.Parse(args))
+
+	return options
+}
+
+// TestSecurityHeaders tests the default header set is present on error responses.
+func TestSecurityHeaders(t *testing.T) {
+	t.Parallel()
+
+	handler := securityheaders.New(getSecurityHeadersOptions(t)).Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errors.HandleError(w, r, errors.HTTPNotFound())
+	}))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequestWithContext(t.Context(), http.MethodGet, path, nil))
+
+	require.Equal(t, http.StatusNotFound, w.Code)
+	require.Equal(t, "nosniff", w.Header().Get("X-Content-Type-Options"))
+	require.Equal(t, "DENY", w.Header().Get("X-Frame-Options"))
+	require.Equal(t, "no-referrer", w.Header().Get("Referrer-Policy"))
+	require.Equal(t, "max-age=31536000; includeSubDomains", w.Header().Get("Strict-Transport-Security"))
+}
+
+// TestSecurityHeadersDisabled tests individual headers can be tuned or disabled.
+func TestSecurityHeadersDisabled(t *testing.T) {
+	t.Parallel()
+
+	options := getSecurityHeadersOptions(t,
+		"--security-header-nosniff=false",
+		"--security-header-frame-options=",
+		"--security-header-referrer-policy=strict-origin",
+		"--security-header-hsts-max-age=1h",
+		"--security-header-hsts-include-subdomains=false",
+	)
+
+	handler := securityheaders.New(options).Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequestWithContext(t.Context(), http.MethodGet, path, nil))
+
+	require.Empty(t, w.Header().Values("X-Content-Type-Options"))
+	require.Empty(t, w.Header().Values("X-Frame-Options"))
+	require.Equal(t, "strict-origin", w.Header().Get("Referrer-Policy"))
+	require.Equal(t, "max-age=3600", w.Header().Get("Strict-Transport-Security"))
+}
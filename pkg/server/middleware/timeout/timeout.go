@@ -20,18 +20,61 @@ package timeout
 
 import (
 	"context"
+	"errors"
+	"io"
 	"net/http"
 	"time"
+
+	"github.com/felixge/httpsnoop"
+
+	servererrors "github.com/unikorn-cloud/core/pkg/server/errors"
+	utilcontext "github.com/unikorn-cloud/core/pkg/util/context"
 )
 
-// Middleware adds a timeout to requests.
+// Middleware adds a timeout to requests, this is typically the server options'
+// RequestTimeout.  Any saga run by the handler is given the same budget to run
+// compensations in should the request deadline expire mid-saga.  Should the
+// deadline expire, and the handler return without a response, then a gateway
+// timeout error is returned to the client.
 func Middleware(timeout time.Duration) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			ctx, cancel := context.WithTimeout(r.Context(), timeout)
+			ctx, cancel := context.WithTimeout(utilcontext.WithCompensationTimeout(r.Context(), timeout), timeout)
 			defer cancel()
 
-			next.ServeHTTP(w, r.Clone(ctx))
+			var written bool
+
+			wrapped := httpsnoop.Wrap(w, httpsnoop.Hooks{
+				WriteHeader: func(next httpsnoop.WriteHeaderFunc) httpsnoop.WriteHeaderFunc {
+					return func(code int) {
+						written = true
+
+						next(code)
+					}
+				},
+				Write: func(next httpsnoop.WriteFunc) httpsnoop.WriteFunc {
+					return func(p []byte) (int, error) {
+						written = true
+
+						return next(p)
+					}
+				},
+				ReadFrom: func(next httpsnoop.ReadFromFunc) httpsnoop.ReadFromFunc {
+					return func(src io.Reader) (int64, error) {
+						written = true
+
+						return next(src)
+					}
+				},
+			})
+
+			r = r.Clone(ctx)
+
+			next.ServeHTTP(wrapped, r)
+
+			if !written && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				servererrors.HandleError(w, r, servererrors.HTTPGatewayTimeout("the request timed out"))
+			}
 		})
 	}
 }
@@ -0,0 +1,89 @@
+/*
+Copyright 2026 Nscale.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package middleware_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/unikorn-cloud/core/pkg/openapi"
+	servererrors "github.com/unikorn-cloud/core/pkg/server/errors"
+	"github.com/unikorn-cloud/core/pkg/server/middleware/timeout"
+)
+
+// requireGatewayTimeout checks the response is a standard gateway timeout error.
+func requireGatewayTimeout(t *testing.T, w *httptest.ResponseRecorder) {
+	t.Helper()
+
+	require.Equal(t, http.StatusGatewayTimeout, w.Code)
+
+	var body openapi.Error
+
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	require.Equal(t, openapi.GatewayTimeout, body.Error)
+}
+
+// TestTimeout tests a slow handler that returns without a response yields
+// a gateway timeout.
+func TestTimeout(t *testing.T) {
+	t.Parallel()
+
+	handler := timeout.Middleware(10 * time.Millisecond)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequestWithContext(t.Context(), http.MethodGet, path, nil))
+
+	requireGatewayTimeout(t, w)
+}
+
+// TestTimeoutHandlerError tests a slow handler that reports the context error
+// yields a gateway timeout.
+func TestTimeoutHandlerError(t *testing.T) {
+	t.Parallel()
+
+	handler := timeout.Middleware(10 * time.Millisecond)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+
+		servererrors.HandleError(w, r, r.Context().Err())
+	}))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequestWithContext(t.Context(), http.MethodGet, path, nil))
+
+	requireGatewayTimeout(t, w)
+}
+
+// TestTimeoutNotExceeded tests a fast handler's response is untouched.
+func TestTimeoutNotExceeded(t *testing.T) {
+	t.Parallel()
+
+	handler := timeout.Middleware(time.Minute)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequestWithContext(t.Context(), http.MethodGet, path, nil))
+
+	require.Equal(t, http.StatusNoContent, w.Code)
+}
@@ -0,0 +1,199 @@
+/*
+Copyright 2026 Nscale.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	goerrors "errors"
+	"net/http"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/spf13/pflag"
+
+	"github.com/unikorn-cloud/core/pkg/openapi"
+	"github.com/unikorn-cloud/core/pkg/server/errors"
+	"github.com/unikorn-cloud/core/pkg/server/middleware"
+	"github.com/unikorn-cloud/core/pkg/server/middleware/routeresolver"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// NoBodyExtension marks a POST or PUT operation that accepts no request body,
+// so there is nothing to validate.
+const NoBodyExtension = "x-no-body"
+
+type Options struct {
+	// ValidateResponses checks responses conform to the OpenAPI schema, logging
+	// any that do not.  This is intended to catch drift between handlers and the
+	// schema in development and CI, and should not be enabled in production.
+	ValidateResponses bool
+}
+
+func (o *Options) AddFlags(f *pflag.FlagSet) {
+	f.BoolVar(&o.ValidateResponses, "openapi-validate-responses", false, "Log responses that do not conform to the OpenAPI schema, for development use only")
+}
+
+// Validator checks requests conform to the OpenAPI schema before they reach
+// a handler, and optionally that responses do too.
+type Validator struct {
+	options *Options
+}
+
+func New() *Validator {
+	return NewWithOptions(&Options{})
+}
+
+func NewWithOptions(options *Options) *Validator {
+	return &Validator{
+		options: options,
+	}
+}
+
+// requestBody returns the request body definition to validate against, or nil if
+// there is nothing to validate.
+func requestBody(info *routeresolver.RouteInfo) *openapi3.RequestBody {
+	operation := info.Route.Operation
+	if operation == nil || operation.RequestBody == nil {
+		return nil
+	}
+
+	if _, ok := operation.Extensions[NoBodyExtension]; ok {
+		return nil
+	}
+
+	return operation.RequestBody.Value
+}
+
+// fieldErrors extracts the individual schema violations from a validation error.
+func fieldErrors(err error) []openapi.FieldError {
+	var errs []error
+
+	var multiError openapi3.MultiError
+
+	if goerrors.As(err, &multiError) {
+		errs = multiError
+	} else {
+		errs = []error{err}
+	}
+
+	var out []openapi.FieldError
+
+	for _, err := range errs {
+		var schemaError *openapi3.SchemaError
+
+		if !goerrors.As(err, &schemaError) {
+			continue
+		}
+
+		out = append(out, openapi.FieldError{
+			Field:   strings.Join(schemaError.JSONPointer(), "."),
+			Message: schemaError.Reason,
+		})
+	}
+
+	return out
+}
+
+// validateRequest checks the request body against the operation's schema.  The
+// body is buffered and restored for the handler.
+func (m *Validator) validateRequest(r *http.Request, info *routeresolver.RouteInfo) error {
+	body := requestBody(info)
+	if body == nil {
+		return nil
+	}
+
+	input := &openapi3filter.RequestValidationInput{
+		Request:    r,
+		PathParams: info.Parameters,
+		Route:      info.Route,
+		Options: &openapi3filter.Options{
+			MultiError: true,
+			// Handlers see exactly what the client sent.
+			SkipSettingDefaults: true,
+		},
+	}
+
+	err := openapi3filter.ValidateRequestBody(r.Context(), input, body)
+	if err == nil {
+		return nil
+	}
+
+	// Body limits are enforced by the standard library and handled
+	// generically.
+	var maxBytesError *http.MaxBytesError
+
+	if goerrors.As(err, &maxBytesError) {
+		return err
+	}
+
+	return errors.HTTPUnprocessableContent("request body failed validation").WithError(err).WithFieldErrors(fieldErrors(err)...)
+}
+
+// validateResponse checks the captured response against the operation's schema.
+// The response has already been sent, so violations can only be reported.
+func (m *Validator) validateResponse(r *http.Request, header http.Header, capture *middleware.Capture, info *routeresolver.RouteInfo) {
+	if info.Route.Operation == nil {
+		return
+	}
+
+	input := &openapi3filter.ResponseValidationInput{
+		RequestValidationInput: &openapi3filter.RequestValidationInput{
+			Request:    r,
+			PathParams: info.Parameters,
+			Route:      info.Route,
+		},
+		Status: capture.StatusCode(),
+		Header: header,
+		Options: &openapi3filter.Options{
+			MultiError:            true,
+			IncludeResponseStatus: true,
+		},
+	}
+
+	input.SetBodyBytes(capture.Body().Bytes())
+
+	if err := openapi3filter.ValidateResponse(r.Context(), input); err != nil {
+		log.FromContext(r.Context()).Error(err, "response does not conform to the OpenAPI schema", "operationID", info.Route.Operation.OperationID, "status", capture.StatusCode(), "fields", fieldErrors(err))
+	}
+}
+
+// Middleware provides an adaptor into chi's routing stack.  It requires the route
+// to have been resolved, requests for unresolved routes are passed through.
+func (m *Validator) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		info, err := routeresolver.FromContext(r.Context())
+		if err != nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if err := m.validateRequest(r, info); err != nil {
+			errors.HandleError(w, r, err)
+			return
+		}
+
+		if !m.options.ValidateResponses {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		capture := middleware.CaptureResponse(w, r, next)
+
+		m.validateResponse(r, w.Header(), capture, info)
+	})
+}
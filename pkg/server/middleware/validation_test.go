@@ -0,0 +1,217 @@
+/*
+Copyright 2026 Nscale.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package middleware_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-logr/logr/funcr"
+	"github.com/stretchr/testify/require"
+
+	"github.com/unikorn-cloud/core/pkg/openapi"
+	"github.com/unikorn-cloud/core/pkg/server/middleware/routeresolver"
+	"github.com/unikorn-cloud/core/pkg/server/middleware/validation"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	widgetsPath = "/widgets"
+)
+
+// validationHandler echoes the request body so we can check it's preserved.
+func validationHandler(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+
+	_, _ = w.Write(body)
+}
+
+func getValidationHandler(t *testing.T) http.Handler {
+	t.Helper()
+
+	return getValidationHandlerWithOptions(t, &validation.Options{}, validationHandler)
+}
+
+func getValidationHandlerWithOptions(t *testing.T, options *validation.Options, handler http.HandlerFunc) http.Handler {
+	t.Helper()
+
+	r := chi.NewRouter()
+	r.Use(routeresolver.New(getSchema(t)).Middleware)
+	r.Use(validation.NewWithOptions(options).Middleware)
+	r.Post(widgetsPath, handler)
+	r.Put(widgetsPath, handler)
+
+	return r
+}
+
+func doValidationRequest(t *testing.T, handler http.Handler, method, body string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	return doValidationRequestWithContext(t, t.Context(), handler, method, body)
+}
+
+func doValidationRequestWithContext(t *testing.T, ctx context.Context, handler http.Handler, method, body string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	r := httptest.NewRequestWithContext(ctx, method, widgetsPath, strings.NewReader(body))
+	r.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+
+	handler.ServeHTTP(w, r)
+
+	return w
+}
+
+// responseHandler returns a handler that responds with the given body.
+func responseHandler(body string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+
+		_, _ = w.Write([]byte(body))
+	}
+}
+
+// invalidResponse returns the invalid response log line, if one was logged.
+func (l *logCapture) invalidResponse() string {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	for _, line := range l.lines {
+		if strings.Contains(line, `"msg"="response does not conform to the OpenAPI schema"`) {
+			return line
+		}
+	}
+
+	return ""
+}
+
+// doResponseValidationRequest performs a valid request against a handler that
+// responds with the given body.
+func doResponseValidationRequest(t *testing.T, options *validation.Options, body string) (*httptest.ResponseRecorder, *logCapture) {
+	t.Helper()
+
+	capture := &logCapture{}
+
+	ctx := log.IntoContext(t.Context(), funcr.New(capture.write, funcr.Options{}))
+
+	w := doValidationRequestWithContext(t, ctx, getValidationHandlerWithOptions(t, options, responseHandler(body)), http.MethodPost, `{"name":"foo"}`)
+
+	return w, capture
+}
+
+// TestValidateRequestBody tests a valid body is passed to the handler intact.
+func TestValidateRequestBody(t *testing.T) {
+	t.Parallel()
+
+	const body = `{"name":"foo","count":1}`
+
+	w := doValidationRequest(t, getValidationHandler(t), http.MethodPost, body)
+	require.Equal(t, http.StatusCreated, w.Code)
+	require.JSONEq(t, body, w.Body.String())
+}
+
+// TestValidateRequestBodyInvalid tests an invalid body is rejected with details
+// of each invalid field.
+func TestValidateRequestBodyInvalid(t *testing.T) {
+	t.Parallel()
+
+	w := doValidationRequest(t, getValidationHandler(t), http.MethodPost, `{"count":-1}`)
+	require.Equal(t, http.StatusUnprocessableEntity, w.Code)
+
+	var body openapi.Error
+
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	require.Equal(t, openapi.UnprocessableContent, body.Error)
+	require.NotNil(t, body.Details)
+
+	fields := make([]string, len(*body.Details))
+
+	for i, detail := range *body.Details {
+		fields[i] = detail.Field
+		require.NotEmpty(t, detail.Message)
+	}
+
+	require.ElementsMatch(t, []string{"name", "count"}, fields)
+}
+
+// TestValidateRequestBodyMissing tests a required body must be present.
+func TestValidateRequestBodyMissing(t *testing.T) {
+	t.Parallel()
+
+	w := doValidationRequest(t, getValidationHandler(t), http.MethodPost, "")
+	require.Equal(t, http.StatusUnprocessableEntity, w.Code)
+}
+
+// TestValidateRequestBodyNoBody tests operations marked as having no body are
+// not validated.
+func TestValidateRequestBodyNoBody(t *testing.T) {
+	t.Parallel()
+
+	w := doValidationRequest(t, getValidationHandler(t), http.MethodPut, `"not an object"`)
+	require.Equal(t, http.StatusCreated, w.Code)
+}
+
+// TestValidateResponse tests non-conforming responses are logged, but otherwise
+// left untouched.
+func TestValidateResponse(t *testing.T) {
+	t.Parallel()
+
+	const body = `{"name":"foo"}`
+
+	w, capture := doResponseValidationRequest(t, &validation.Options{ValidateResponses: true}, body)
+	require.Equal(t, http.StatusCreated, w.Code)
+	require.JSONEq(t, body, w.Body.String())
+
+	line := capture.invalidResponse()
+	require.NotEmpty(t, line)
+	require.Contains(t, line, `"operationID"="createWidget"`)
+	require.Contains(t, line, `"field"="id"`)
+}
+
+// TestValidateResponseConforming tests conforming responses are not logged.
+func TestValidateResponseConforming(t *testing.T) {
+	t.Parallel()
+
+	w, capture := doResponseValidationRequest(t, &validation.Options{ValidateResponses: true}, `{"id":"foo"}`)
+	require.Equal(t, http.StatusCreated, w.Code)
+	require.Empty(t, capture.invalidResponse())
+}
+
+// TestValidateResponseDisabled tests responses are not validated by default.
+func TestValidateResponseDisabled(t *testing.T) {
+	t.Parallel()
+
+	w, capture := doResponseValidationRequest(t, &validation.Options{}, `{"name":"foo"}`)
+	require.Equal(t, http.StatusCreated, w.Code)
+	require.Empty(t, capture.invalidResponse())
+}
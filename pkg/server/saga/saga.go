@@ -19,15 +19,140 @@ package saga
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	utilcontext "github.com/unikorn-cloud/core/pkg/util/context"
 
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
+var (
+	// ErrActionTimeout is returned when an action exceeds its timeout.
+	ErrActionTimeout = errors.New("saga action timed out")
+)
+
+const (
+	// DefaultCompensationTimeout bounds how long compensations may run for
+	// when not specified by the context.
+	DefaultCompensationTimeout = 30 * time.Second
+)
+
+type failedCompensationSinkKeyType int
+
+const (
+	failedCompensationSinkKey failedCompensationSinkKeyType = iota
+)
+
+// FailedCompensationSink is notified when a compensating action fails, so that
+// the failure can be persisted e.g. to a message queue or custom resource, and
+// retried asynchronously by a reconciler, rather than orphaning resources.
+type FailedCompensationSink interface {
+	// Record persists the failed compensation of the named action.
+	Record(ctx context.Context, action string, err error) error
+}
+
+// Option defines a set of runtime composable options.
+type Option func(ctx context.Context) context.Context
+
+// WithFailedCompensationSink records failed compensations with the sink.
+func WithFailedCompensationSink(sink FailedCompensationSink) Option {
+	return func(ctx context.Context) context.Context {
+		return context.WithValue(ctx, failedCompensationSinkKey, sink)
+	}
+}
+
+// reportedError marks a compensation error that has already been reported,
+// e.g. by a member of a parallel group, so it's not reported again by the group.
+type reportedError struct {
+	err error
+}
+
+func (e *reportedError) Error() string {
+	return e.err.Error()
+}
+
+func (e *reportedError) Unwrap() error {
+	return e.err
+}
+
+// compensationFailed logs a failed compensation, and records it with any sink.
+// You see this in your logs, you're going to have to do some manual unpicking!
+func compensationFailed(ctx context.Context, action string, err error) {
+	var reported *reportedError
+
+	if errors.As(err, &reported) {
+		return
+	}
+
+	log := log.FromContext(ctx)
+
+	log.Error(err, "compensating action failed", "name", action)
+
+	sink, ok := ctx.Value(failedCompensationSinkKey).(FailedCompensationSink)
+	if !ok {
+		return
+	}
+
+	if serr := sink.Record(ctx, action, err); serr != nil {
+		log.Error(serr, "failed to record failed compensation", "name", action)
+	}
+}
+
+// compensationContext returns a context for running compensations.  It retains
+// values such as the logger and trace context, but is decoupled from the
+// cancellation of the parent e.g. the request deadline expiring mid-saga,
+// otherwise actions would be performed but never compensated.
+func compensationContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	timeout, ok := utilcontext.CompensationTimeout(ctx)
+	if !ok {
+		timeout = DefaultCompensationTimeout
+	}
+
+	return context.WithTimeout(context.WithoutCancel(ctx), timeout)
+}
+
 // ActionFunc is a generic action/compensation function.
 // They will typically be bound receivers so that saga steps can
 // share state between themselves.
 type ActionFunc func(ctx context.Context) error
 
+// RetryPolicy defines how an action and its compensation are retried on
+// failure e.g. when calling flaky upstream APIs.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, values less than
+	// two disable retries.
+	MaxAttempts int
+	// BaseBackoff is the time to wait before the first retry, this is
+	// doubled for each subsequent retry.
+	BaseBackoff time.Duration
+	// MaxBackoff caps the time to wait between retries, if non-zero.
+	MaxBackoff time.Duration
+}
+
+// backoff returns the time to wait after the given attempt, counting from 1.
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	backoff := p.BaseBackoff
+
+	for range attempt - 1 {
+		backoff *= 2
+
+		if p.MaxBackoff > 0 && backoff >= p.MaxBackoff {
+			break
+		}
+	}
+
+	if p.MaxBackoff > 0 && backoff > p.MaxBackoff {
+		backoff = p.MaxBackoff
+	}
+
+	return backoff
+}
+
 // Action is a single step in a saga.
 type Action struct {
 	// name is used for logging so we can see what went wrong.
@@ -38,6 +163,11 @@ type Action struct {
 	// and can undo any state changes that need to be rewound.
 	// May be nil.
 	compensate ActionFunc
+	// retry defines how the action and compensation are retried.
+	retry RetryPolicy
+	// timeout, if non-zero, bounds how long the action, including any
+	// retries, may run for.
+	timeout time.Duration
 }
 
 // NewAction creates a new action.
@@ -49,37 +179,197 @@ func NewAction(name string, action, compensate ActionFunc) Action {
 	}
 }
 
+// NewActionWithRetry creates a new action that, along with its compensation,
+// is retried on failure.
+func NewActionWithRetry(name string, action, compensate ActionFunc, retry RetryPolicy) Action {
+	return Action{
+		name:       name,
+		action:     action,
+		compensate: compensate,
+		retry:      retry,
+	}
+}
+
+// WithTimeout returns a copy of the action that fails if it, including any
+// retries, does not complete within the timeout, e.g. because a dependency
+// is unresponsive.
+func (a Action) WithTimeout(timeout time.Duration) Action {
+	a.timeout = timeout
+
+	return a
+}
+
+// run performs the action, bounded by its timeout if one is set.  An action that
+// times out is reported by name, so hung dependencies can be diagnosed.
+func (a *Action) run(ctx context.Context) error {
+	if a.timeout == 0 {
+		return a.call(ctx, a.action)
+	}
+
+	tctx, cancel := context.WithTimeout(ctx, a.timeout)
+	defer cancel()
+
+	err := a.call(tctx, a.action)
+
+	// Only attribute the failure to the action if it was our deadline that
+	// expired, and not that of the caller.
+	if err != nil && ctx.Err() == nil && errors.Is(tctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("%w: action %q exceeded %v: %w", ErrActionTimeout, a.name, a.timeout, err)
+	}
+
+	return err
+}
+
+// call runs the function, retrying as defined by the action's retry policy.
+// Context errors are never retried, and cancellation is honoured between
+// attempts, in which case the last error from the function is returned.
+func (a *Action) call(ctx context.Context, f ActionFunc) error {
+	log := log.FromContext(ctx)
+
+	for attempt := 1; ; attempt++ {
+		err := f(ctx)
+		if err == nil {
+			return nil
+		}
+
+		if attempt >= a.retry.MaxAttempts || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			return err
+		}
+
+		backoff := a.retry.backoff(attempt)
+
+		log.V(1).Info("retrying saga action", "name", a.name, "attempt", attempt, "backoff", backoff.String(), "error", err.Error())
+
+		timer := time.NewTimer(backoff)
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+
+			return err
+		case <-timer.C:
+		}
+	}
+}
+
 // Handler implements a saga, a set of steps to achieve a desired outcome
 // and a set of steps to undo any state changes on failure of an action.
 type Handler interface {
 	Actions() []Action
 }
 
+// parallel is a group of independent actions that are run concurrently.
+type parallel struct {
+	actions []Action
+}
+
+// NewParallel creates an action that runs a group of independent actions
+// concurrently, within the ordered sequence of a saga.  Should any member
+// fail, those that succeeded are compensated, and the error of the first
+// failing member, in the order provided, is returned.  Should a subsequent
+// action fail, all members are compensated.
+func NewParallel(actions ...Action) Action {
+	names := make([]string, len(actions))
+
+	for i := range actions {
+		names[i] = actions[i].name
+	}
+
+	p := &parallel{
+		actions: actions,
+	}
+
+	return NewAction("parallel("+strings.Join(names, ", ")+")", p.action, p.compensate)
+}
+
+// action runs all members concurrently, compensating any that succeeded
+// on failure of any other.
+func (p *parallel) action(ctx context.Context) error {
+	errs := make([]error, len(p.actions))
+
+	var wg sync.WaitGroup
+
+	for i := range p.actions {
+		wg.Go(func() {
+			errs[i] = p.actions[i].run(ctx)
+		})
+	}
+
+	wg.Wait()
+
+	index := slices.IndexFunc(errs, func(err error) bool { return err != nil })
+	if index < 0 {
+		return nil
+	}
+
+	cctx, cancel := compensationContext(ctx)
+	defer cancel()
+
+	// Compensation errors are logged, but the action error is what is
+	// important to the caller.
+	_ = p.compensateMembers(cctx, func(i int) bool { return errs[i] == nil })
+
+	return errs[index]
+}
+
+// compensate undoes all members.
+func (p *parallel) compensate(ctx context.Context) error {
+	return p.compensateMembers(ctx, func(int) bool { return true })
+}
+
+// compensateMembers concurrently compensates the selected members, returning
+// the first error in the order provided.
+func (p *parallel) compensateMembers(ctx context.Context, selected func(int) bool) error {
+	errs := make([]error, len(p.actions))
+
+	var wg sync.WaitGroup
+
+	for i := range p.actions {
+		if !selected(i) || p.actions[i].compensate == nil {
+			continue
+		}
+
+		wg.Go(func() {
+			if err := p.actions[i].call(ctx, p.actions[i].compensate); err != nil {
+				compensationFailed(ctx, p.actions[i].name, err)
+
+				errs[i] = &reportedError{err: err}
+			}
+		})
+	}
+
+	wg.Wait()
+
+	if index := slices.IndexFunc(errs, func(err error) bool { return err != nil }); index >= 0 {
+		return errs[index]
+	}
+
+	return nil
+}
+
 // Run implements the saga algorithm.
-func Run(ctx context.Context, handler Handler) error {
-	log := log.FromContext(ctx)
+func Run(ctx context.Context, handler Handler, options ...Option) error {
+	for _, option := range options {
+		ctx = option(ctx)
+	}
 
 	actions := handler.Actions()
 
 	// Do each action in order...
 	for i := range actions {
-		if err := actions[i].action(ctx); err != nil {
+		if err := actions[i].run(ctx); err != nil {
 			// If something went wrong we need to undo all prior steps
 			// to compensate for any changed state e.g. quota allocations.
+			cctx, cancel := compensationContext(ctx)
+			defer cancel()
+
 			for j := i - 1; j >= 0; j-- {
 				if actions[j].compensate == nil {
 					continue
 				}
 
-				if cerr := actions[j].compensate(ctx); cerr != nil {
-					// You see this in your logs, you're going to have to
-					// do some manual unpicking!
-					// TODO: we could add a retry in here for transient errors
-					// (and the actual action itself), but we aware the client
-					// and server will have a response timeout, so perhaps
-					// adding the compensation action to a log for aysnchronous
-					// handling may be better in future.
-					log.Error(cerr, "compensating action failed", "name", actions[j].name)
+				if cerr := actions[j].call(cctx, actions[j].compensate); cerr != nil {
+					compensationFailed(cctx, actions[j].name, cerr)
 					return err
 				}
 			}
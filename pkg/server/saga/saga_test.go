@@ -20,10 +20,16 @@ package saga_test
 import (
 	"context"
 	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/unikorn-cloud/core/pkg/server/middleware/timeout"
 	"github.com/unikorn-cloud/core/pkg/server/saga"
 )
 
@@ -144,3 +150,386 @@ func TestSagaFailCompensation2(t *testing.T) {
 	require.False(t, h.compensate1Called)
 	require.True(t, h.compensate2Called)
 }
+
+// DeadlineHandler performs an action that blocks until the request deadline
+// expires.
+type DeadlineHandler struct {
+	compensateCalled      bool
+	compensateError       error
+	compensateHasDeadline bool
+}
+
+func (h *DeadlineHandler) action(ctx context.Context) error {
+	return nil
+}
+
+func (h *DeadlineHandler) compensate(ctx context.Context) error {
+	h.compensateCalled = true
+	h.compensateError = ctx.Err()
+	_, h.compensateHasDeadline = ctx.Deadline()
+
+	return nil
+}
+
+func (h *DeadlineHandler) block(ctx context.Context) error {
+	<-ctx.Done()
+
+	return ctx.Err()
+}
+
+func (h *DeadlineHandler) Actions() []saga.Action {
+	return []saga.Action{
+		saga.NewAction("action", h.action, h.compensate),
+		saga.NewAction("block", h.block, nil),
+	}
+}
+
+// TestSagaRequestTimeout tests compensations are run with a live, but bounded,
+// context when the request deadline expires mid-saga.
+func TestSagaRequestTimeout(t *testing.T) {
+	t.Parallel()
+
+	h := &DeadlineHandler{}
+
+	var err error
+
+	handler := timeout.Middleware(10 * time.Millisecond)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		err = saga.Run(r.Context(), h)
+	}))
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequestWithContext(t.Context(), http.MethodGet, "/", nil))
+
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.True(t, h.compensateCalled)
+	require.NoError(t, h.compensateError)
+	require.True(t, h.compensateHasDeadline)
+}
+
+var errTransient = errors.New("transient error")
+
+// FlakyHandler has actions that fail a number of times before succeeding.
+type FlakyHandler struct {
+	actionFailures     int
+	compensateFailures int
+	finalResult        error
+
+	actionAttempts     int
+	compensateAttempts int
+}
+
+// flaky returns a transient error for the given number of failures.
+func flaky(attempts *int, failures int) error {
+	*attempts++
+
+	if *attempts <= failures {
+		return errTransient
+	}
+
+	return nil
+}
+
+func (h *FlakyHandler) action(ctx context.Context) error {
+	return flaky(&h.actionAttempts, h.actionFailures)
+}
+
+func (h *FlakyHandler) compensate(ctx context.Context) error {
+	return flaky(&h.compensateAttempts, h.compensateFailures)
+}
+
+func (h *FlakyHandler) final(ctx context.Context) error {
+	return h.finalResult
+}
+
+func (h *FlakyHandler) Actions() []saga.Action {
+	retry := saga.RetryPolicy{
+		MaxAttempts: 3,
+		BaseBackoff: time.Millisecond,
+		MaxBackoff:  2 * time.Millisecond,
+	}
+
+	return []saga.Action{
+		saga.NewActionWithRetry("flaky", h.action, h.compensate, retry),
+		saga.NewAction("final", h.final, nil),
+	}
+}
+
+// TestSagaRetry tests actions are retried until they succeed.
+func TestSagaRetry(t *testing.T) {
+	t.Parallel()
+
+	h := &FlakyHandler{
+		actionFailures: 2,
+	}
+
+	require.NoError(t, saga.Run(t.Context(), h))
+	require.Equal(t, 3, h.actionAttempts)
+	require.Zero(t, h.compensateAttempts)
+}
+
+// TestSagaRetryExhausted tests the action error is returned once all attempts
+// have been made.
+func TestSagaRetryExhausted(t *testing.T) {
+	t.Parallel()
+
+	h := &FlakyHandler{
+		actionFailures: 3,
+	}
+
+	require.ErrorIs(t, saga.Run(t.Context(), h), errTransient)
+	require.Equal(t, 3, h.actionAttempts)
+}
+
+// TestSagaRetryCompensation tests compensations are retried until they succeed.
+func TestSagaRetryCompensation(t *testing.T) {
+	t.Parallel()
+
+	h := &FlakyHandler{
+		compensateFailures: 2,
+		finalResult:        errFailAction,
+	}
+
+	require.ErrorIs(t, saga.Run(t.Context(), h), errFailAction)
+	require.Equal(t, 1, h.actionAttempts)
+	require.Equal(t, 3, h.compensateAttempts)
+}
+
+// TestSagaRetryCancelled tests retries are abandoned when the context is cancelled.
+func TestSagaRetryCancelled(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+
+	h := &FlakyHandler{
+		actionFailures: 2,
+	}
+
+	require.ErrorIs(t, saga.Run(ctx, h), errTransient)
+	require.Equal(t, 1, h.actionAttempts)
+}
+
+// TimeoutHandler has an action that blocks until it times out.
+type TimeoutHandler struct {
+	compensateCalled bool
+}
+
+func (h *TimeoutHandler) action(ctx context.Context) error {
+	return nil
+}
+
+func (h *TimeoutHandler) compensate(ctx context.Context) error {
+	h.compensateCalled = true
+
+	return nil
+}
+
+func (h *TimeoutHandler) block(ctx context.Context) error {
+	<-ctx.Done()
+
+	return ctx.Err()
+}
+
+func (h *TimeoutHandler) Actions() []saga.Action {
+	return []saga.Action{
+		saga.NewAction("action", h.action, h.compensate),
+		saga.NewAction("block", h.block, nil).WithTimeout(10 * time.Millisecond),
+	}
+}
+
+// TestSagaActionTimeout tests an action exceeding its timeout fails, is identified
+// in the error, and prior actions are compensated.
+func TestSagaActionTimeout(t *testing.T) {
+	t.Parallel()
+
+	h := &TimeoutHandler{}
+
+	err := saga.Run(t.Context(), h)
+	require.ErrorIs(t, err, saga.ErrActionTimeout)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.ErrorContains(t, err, `"block"`)
+	require.True(t, h.compensateCalled)
+}
+
+var errOtherFailAction = errors.New("other fail action")
+
+// ParallelHandler has a group of actions that must run concurrently, as each
+// waits for all the others to start.
+type ParallelHandler struct {
+	barrier sync.WaitGroup
+
+	results     [3]error
+	finalResult error
+
+	compensated      [3]atomic.Bool
+	priorCompensated bool
+}
+
+func newParallelHandler() *ParallelHandler {
+	h := &ParallelHandler{}
+	h.barrier.Add(len(h.results))
+
+	return h
+}
+
+func (h *ParallelHandler) member(i int) (saga.ActionFunc, saga.ActionFunc) {
+	action := func(ctx context.Context) error {
+		h.barrier.Done()
+		h.barrier.Wait()
+
+		return h.results[i]
+	}
+
+	compensate := func(ctx context.Context) error {
+		h.compensated[i].Store(true)
+
+		return nil
+	}
+
+	return action, compensate
+}
+
+func (h *ParallelHandler) prior(ctx context.Context) error {
+	return nil
+}
+
+func (h *ParallelHandler) compensatePrior(ctx context.Context) error {
+	h.priorCompensated = true
+
+	return nil
+}
+
+func (h *ParallelHandler) final(ctx context.Context) error {
+	return h.finalResult
+}
+
+func (h *ParallelHandler) Actions() []saga.Action {
+	network, compensateNetwork := h.member(0)
+	storage, compensateStorage := h.member(1)
+	compute, compensateCompute := h.member(2)
+
+	return []saga.Action{
+		saga.NewAction("prior", h.prior, h.compensatePrior),
+		saga.NewParallel(
+			saga.NewAction("network", network, compensateNetwork),
+			saga.NewAction("storage", storage, compensateStorage),
+			saga.NewAction("compute", compute, compensateCompute),
+		),
+		saga.NewAction("final", h.final, nil),
+	}
+}
+
+func (h *ParallelHandler) requireCompensated(t *testing.T, expected ...bool) {
+	t.Helper()
+
+	for i := range expected {
+		require.Equal(t, expected[i], h.compensated[i].Load(), i)
+	}
+}
+
+// TestSagaParallel tests a parallel group runs concurrently.
+func TestSagaParallel(t *testing.T) {
+	t.Parallel()
+
+	h := newParallelHandler()
+
+	require.NoError(t, saga.Run(t.Context(), h))
+	h.requireCompensated(t, false, false, false)
+	require.False(t, h.priorCompensated)
+}
+
+// TestSagaParallelFail tests a failing member compensates the members that
+// succeeded and prior actions, and that the first error is returned.
+func TestSagaParallelFail(t *testing.T) {
+	t.Parallel()
+
+	h := newParallelHandler()
+	h.results[1] = errFailAction
+	h.results[2] = errOtherFailAction
+
+	require.ErrorIs(t, saga.Run(t.Context(), h), errFailAction)
+	h.requireCompensated(t, true, false, false)
+	require.True(t, h.priorCompensated)
+}
+
+// TestSagaParallelCompensation tests a subsequent failing action compensates
+// the whole group.
+func TestSagaParallelCompensation(t *testing.T) {
+	t.Parallel()
+
+	h := newParallelHandler()
+	h.finalResult = errFailAction
+
+	require.ErrorIs(t, saga.Run(t.Context(), h), errFailAction)
+	h.requireCompensated(t, true, true, true)
+	require.True(t, h.priorCompensated)
+}
+
+// failedCompensation is a failed compensation recorded by a sink.
+type failedCompensation struct {
+	action string
+	err    error
+}
+
+// Sink records failed compensations.
+type Sink struct {
+	lock     sync.Mutex
+	failures []failedCompensation
+}
+
+func (s *Sink) Record(ctx context.Context, action string, err error) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.failures = append(s.failures, failedCompensation{action: action, err: err})
+
+	return nil
+}
+
+// actionsFunc allows a function to be used as a saga handler.
+type actionsFunc func() []saga.Action
+
+func (f actionsFunc) Actions() []saga.Action {
+	return f()
+}
+
+// TestSagaFailedCompensationSink tests failed compensations are recorded with the sink.
+func TestSagaFailedCompensationSink(t *testing.T) {
+	t.Parallel()
+
+	h := &Handler{
+		action3Result:     errFailAction,
+		compensate2Result: errFailCompensate,
+	}
+
+	sink := &Sink{}
+
+	require.ErrorIs(t, saga.Run(t.Context(), h, saga.WithFailedCompensationSink(sink)), errFailAction)
+	require.Len(t, sink.failures, 1)
+	require.Equal(t, "action2", sink.failures[0].action)
+	require.ErrorIs(t, sink.failures[0].err, errFailCompensate)
+}
+
+// TestSagaFailedCompensationSinkParallel tests failed compensations of parallel
+// group members are recorded once, against the member.
+func TestSagaFailedCompensationSinkParallel(t *testing.T) {
+	t.Parallel()
+
+	h := &Handler{}
+
+	sink := &Sink{}
+
+	handler := actionsFunc(func() []saga.Action {
+		return []saga.Action{
+			saga.NewParallel(
+				saga.NewAction("action1", h.action1, h.compensate1),
+				saga.NewAction("action2", h.action2, func(context.Context) error { return errFailCompensate }),
+			),
+			saga.NewAction("action3", func(context.Context) error { return errFailAction }, nil),
+		}
+	})
+
+	require.ErrorIs(t, saga.Run(t.Context(), handler, saga.WithFailedCompensationSink(sink)), errFailAction)
+	require.True(t, h.compensate1Called)
+	require.Len(t, sink.failures, 1)
+	require.Equal(t, "action2", sink.failures[0].action)
+}
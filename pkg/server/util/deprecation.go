@@ -0,0 +1,120 @@
+/*
+Copyright 2026 Nscale.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// DeprecatedFields detects the use of deprecated request body fields, so we can
+// measure client migration and inform deprecation timelines.  Fields are
+// identified by dot separated paths e.g. "spec.flavorId", arrays are traversed
+// transparently so "spec.pools.flavorId" matches a field in any pool.
+type DeprecatedFields struct {
+	// paths are the deprecated field paths.
+	paths []string
+	// usage counts the use of each deprecated field.
+	usage *prometheus.CounterVec
+}
+
+// NewDeprecatedFields creates a new deprecated field detector, registering its
+// metrics with the provided registerer.  Multiple detectors may share the same
+// registerer.
+func NewDeprecatedFields(registerer prometheus.Registerer, paths ...string) (*DeprecatedFields, error) {
+	usage := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "unikorn_api_deprecated_field_total",
+		Help: "Total number of requests using a deprecated field.",
+	}, []string{"field"})
+
+	if err := registerer.Register(usage); err != nil {
+		var already prometheus.AlreadyRegisteredError
+
+		if !errors.As(err, &already) {
+			return nil, err
+		}
+
+		existing, ok := already.ExistingCollector.(*prometheus.CounterVec)
+		if !ok {
+			return nil, err
+		}
+
+		usage = existing
+	}
+
+	d := &DeprecatedFields{
+		paths: paths,
+		usage: usage,
+	}
+
+	return d, nil
+}
+
+// present returns true if the path exists in the generic JSON value.
+func present(value any, path []string) bool {
+	if len(path) == 0 {
+		return true
+	}
+
+	switch t := value.(type) {
+	case map[string]any:
+		child, ok := t[path[0]]
+		if !ok {
+			return false
+		}
+
+		return present(child, path[1:])
+	case []any:
+		for _, item := range t {
+			if present(item, path) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// Record takes a request body, decoded into a generic JSON value e.g. a
+// map[string]any, and records the usage of any deprecated fields by the
+// client.  The client is typically the caller's identity e.g. an organization
+// or service account, and is only logged to avoid unbounded metric cardinality.
+// The deprecated fields that were present are returned.
+func (d *DeprecatedFields) Record(ctx context.Context, client string, body any) []string {
+	log := log.FromContext(ctx)
+
+	var used []string
+
+	for _, path := range d.paths {
+		if !present(body, strings.Split(path, ".")) {
+			continue
+		}
+
+		used = append(used, path)
+
+		d.usage.WithLabelValues(path).Inc()
+
+		log.V(1).Info("deprecated field used", "field", path, "client", client)
+	}
+
+	return used
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2026 Nscale.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/unikorn-cloud/core/pkg/server/util"
+)
+
+const (
+	deprecatedName   = "spec.name"
+	deprecatedFlavor = "spec.pools.flavorId"
+	deprecatedImage  = "spec.imageId"
+)
+
+// TestDeprecatedFields tests present deprecated fields, including those in
+// arrays, are reported and counted, and absent ones are not.
+func TestDeprecatedFields(t *testing.T) {
+	t.Parallel()
+
+	registry := prometheus.NewRegistry()
+
+	d, err := util.NewDeprecatedFields(registry, deprecatedName, deprecatedFlavor, deprecatedImage)
+	require.NoError(t, err)
+
+	// Detectors can share a registry.
+	_, err = util.NewDeprecatedFields(registry, deprecatedName)
+	require.NoError(t, err)
+
+	var body any
+
+	require.NoError(t, json.Unmarshal([]byte(`{"spec":{"name":"foo","pools":[{"name":"a"},{"name":"b","flavorId":"c"}]}}`), &body))
+
+	require.Equal(t, []string{deprecatedName, deprecatedFlavor}, d.Record(t.Context(), "test-org", body))
+	require.Equal(t, []string{deprecatedName, deprecatedFlavor}, d.Record(t.Context(), "test-org", body))
+
+	require.Equal(t, 2, testutil.CollectAndCount(registry, "unikorn_api_deprecated_field_total"))
+
+	expected := map[string]float64{
+		deprecatedName:   2,
+		deprecatedFlavor: 2,
+	}
+
+	families, err := registry.Gather()
+	require.NoError(t, err)
+	require.Len(t, families, 1)
+
+	for _, metric := range families[0].GetMetric() {
+		require.Len(t, metric.GetLabel(), 1)
+		require.InDelta(t, expected[metric.GetLabel()[0].GetValue()], metric.GetCounter().GetValue(), 0)
+	}
+}
+
+// TestDeprecatedFieldsAbsent tests nothing is recorded without deprecated fields.
+func TestDeprecatedFieldsAbsent(t *testing.T) {
+	t.Parallel()
+
+	registry := prometheus.NewRegistry()
+
+	d, err := util.NewDeprecatedFields(registry, deprecatedName)
+	require.NoError(t, err)
+
+	var body any
+
+	require.NoError(t, json.Unmarshal([]byte(`{"metadata":{"name":"foo"}}`), &body))
+
+	require.Empty(t, d.Record(t.Context(), "test-org", body))
+	require.Equal(t, 0, testutil.CollectAndCount(registry, "unikorn_api_deprecated_field_total"))
+}
@@ -0,0 +1,159 @@
+/*
+Copyright 2026 Nscale.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/unikorn-cloud/core/pkg/constants"
+	"github.com/unikorn-cloud/core/pkg/errors"
+	servererrors "github.com/unikorn-cloud/core/pkg/server/errors"
+	"github.com/unikorn-cloud/core/pkg/server/middleware/routeresolver"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// OrganizationIDParameter is the conventional path parameter name for
+	// an organization ID.
+	OrganizationIDParameter = "organizationID"
+
+	// ProjectIDParameter is the conventional path parameter name for a
+	// project ID.
+	ProjectIDParameter = "projectID"
+)
+
+// Scope is the organization, and optionally project, a request is scoped to.
+type Scope struct {
+	// OrganizationID is always set.
+	OrganizationID string
+	// ProjectID is only set for project scoped routes.
+	ProjectID string
+}
+
+// Labels returns a label set that can be used to select resources in scope
+// e.g. with client.MatchingLabels.
+func (s *Scope) Labels() labels.Set {
+	set := labels.Set{
+		constants.OrganizationLabel: s.OrganizationID,
+	}
+
+	if s.ProjectID != "" {
+		set[constants.ProjectLabel] = s.ProjectID
+	}
+
+	return set
+}
+
+// Matches checks whether a resource is in scope, typically used to filter
+// resources that have been read from a cache.
+func (s *Scope) Matches(resource metav1.Object) bool {
+	return s.Labels().AsSelector().Matches(labels.Set(resource.GetLabels()))
+}
+
+// NamespaceLabels returns a label set that selects the namespace resources in
+// scope live in.  This is the project namespace for project scoped requests,
+// otherwise the organization namespace.  The kind is required as a project
+// namespace also carries the organization label.
+func (s *Scope) NamespaceLabels() labels.Set {
+	set := labels.Set{
+		constants.KindLabel:         constants.KindLabelValueOrganization,
+		constants.OrganizationLabel: s.OrganizationID,
+	}
+
+	if s.ProjectID != "" {
+		set[constants.KindLabel] = constants.KindLabelValueProject
+		set[constants.ProjectLabel] = s.ProjectID
+	}
+
+	return set
+}
+
+// Namespace looks up the namespace resources in scope live in.  If the namespace
+// does not exist then neither can the resource, so a HTTPNotFound is returned.
+func (s *Scope) Namespace(ctx context.Context, cli client.Client) (*corev1.Namespace, error) {
+	selector := s.NamespaceLabels()
+
+	namespaces := &corev1.NamespaceList{}
+
+	if err := cli.List(ctx, namespaces, client.MatchingLabels(selector)); err != nil {
+		return nil, err
+	}
+
+	switch len(namespaces.Items) {
+	case 0:
+		return nil, servererrors.HTTPNotFound().WithValues("namespaceLabels", selector)
+	case 1:
+		return &namespaces.Items[0], nil
+	}
+
+	return nil, fmt.Errorf("%w: multiple namespaces match labels %v", errors.ErrConsistency, selector)
+}
+
+// NamespacedName returns the name of a resource in scope, resolving the correct
+// namespace for the scope, as building these by hand is error prone.
+func (s *Scope) NamespacedName(ctx context.Context, cli client.Client, id string) (types.NamespacedName, error) {
+	namespace, err := s.Namespace(ctx, cli)
+	if err != nil {
+		return types.NamespacedName{}, err
+	}
+
+	name := types.NamespacedName{
+		Namespace: namespace.Name,
+		Name:      id,
+	}
+
+	return name, nil
+}
+
+// ScopeAuthorizer checks whether the actor is allowed to access the scope.
+// It should return a HTTPForbidden error if not, any other error is treated
+// as a failure to make a decision and is returned to the caller unchanged.
+type ScopeAuthorizer func(ctx context.Context, scope *Scope) error
+
+// ScopeFromRequest extracts the organization and project scope from the
+// resolved route's path parameters and checks the actor is authorized to
+// access it.  The route resolver middleware must have been run.
+func ScopeFromRequest(r *http.Request, authorizer ScopeAuthorizer) (*Scope, error) {
+	route, err := routeresolver.FromContext(r.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	organizationID, ok := route.Parameters[OrganizationIDParameter]
+	if !ok || organizationID == "" {
+		return nil, servererrors.OAuth2InvalidRequest("request path is not organization scoped")
+	}
+
+	scope := &Scope{
+		OrganizationID: organizationID,
+		ProjectID:      route.Parameters[ProjectIDParameter],
+	}
+
+	if err := authorizer(r.Context(), scope); err != nil {
+		return nil, err
+	}
+
+	return scope, nil
+}
@@ -0,0 +1,241 @@
+/*
+Copyright 2026 Nscale.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+
+	"github.com/unikorn-cloud/core/pkg/constants"
+	"github.com/unikorn-cloud/core/pkg/openapi/helpers"
+	servererrors "github.com/unikorn-cloud/core/pkg/server/errors"
+	"github.com/unikorn-cloud/core/pkg/server/middleware/routeresolver"
+	"github.com/unikorn-cloud/core/pkg/server/util"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const (
+	scopeSchema = `openapi: 3.0.3
+info:
+  title: Some test fixture code.
+  version: 1.0.0
+paths:
+  /api/v1/organizations/{organizationID}/projects/{projectID}/things:
+    get:
+      responses:
+        '200': {}
+`
+
+	organizationID = "foo"
+	projectID      = "bar"
+	scopePath      = "/api/v1/organizations/{organizationID}/projects/{projectID}/things"
+)
+
+var errScopeUnavailable = errors.New("authorizer unavailable")
+
+// scopeHandler calls the handler with a fully resolved route.
+func scopeHandler(t *testing.T, handler http.HandlerFunc) http.Handler {
+	t.Helper()
+
+	getter := func() (*openapi3.T, error) {
+		return openapi3.NewLoader().LoadFromData([]byte(scopeSchema))
+	}
+
+	schema, err := helpers.NewSchema(getter)
+	require.NoError(t, err)
+
+	r := chi.NewRouter()
+	r.Use(routeresolver.New(schema).Middleware)
+	r.Get(scopePath, handler)
+
+	return r
+}
+
+func scopeRequest(t *testing.T, organizationID string) *http.Request {
+	t.Helper()
+
+	return httptest.NewRequestWithContext(t.Context(), http.MethodGet, "/api/v1/organizations/"+organizationID+"/projects/"+projectID+"/things", nil)
+}
+
+// authorizeOrganization only allows access to the fixture organization.
+func authorizeOrganization(_ context.Context, scope *util.Scope) error {
+	if scope.OrganizationID != organizationID {
+		return servererrors.HTTPForbidden("access to the requested scope is denied")
+	}
+
+	return nil
+}
+
+// TestScopeFromRequest checks a valid, authorized scope is returned and can
+// be used to filter resources.
+func TestScopeFromRequest(t *testing.T) {
+	t.Parallel()
+
+	var scope *util.Scope
+
+	handler := scopeHandler(t, func(_ http.ResponseWriter, r *http.Request) {
+		s, err := util.ScopeFromRequest(r, authorizeOrganization)
+		require.NoError(t, err)
+
+		scope = s
+	})
+
+	handler.ServeHTTP(httptest.NewRecorder(), scopeRequest(t, organizationID))
+
+	require.NotNil(t, scope)
+	require.Equal(t, organizationID, scope.OrganizationID)
+	require.Equal(t, projectID, scope.ProjectID)
+
+	inScope := &metav1.ObjectMeta{
+		Labels: map[string]string{
+			constants.OrganizationLabel: organizationID,
+			constants.ProjectLabel:      projectID,
+		},
+	}
+
+	outOfScope := &metav1.ObjectMeta{
+		Labels: map[string]string{
+			constants.OrganizationLabel: organizationID,
+			constants.ProjectLabel:      "baz",
+		},
+	}
+
+	require.True(t, scope.Matches(inScope))
+	require.False(t, scope.Matches(outOfScope))
+}
+
+// TestScopeFromRequestForbidden checks a scope the actor cannot access is rejected.
+func TestScopeFromRequestForbidden(t *testing.T) {
+	t.Parallel()
+
+	var scopeErr error
+
+	handler := scopeHandler(t, func(_ http.ResponseWriter, r *http.Request) {
+		_, scopeErr = util.ScopeFromRequest(r, authorizeOrganization)
+	})
+
+	handler.ServeHTTP(httptest.NewRecorder(), scopeRequest(t, "baz"))
+
+	require.Error(t, scopeErr)
+	require.True(t, servererrors.IsForbidden(scopeErr))
+}
+
+// TestScopeFromRequestAuthorizerError checks authorizer failures that aren't
+// access decisions, e.g. an outage, are not reported as forbidden.
+func TestScopeFromRequestAuthorizerError(t *testing.T) {
+	t.Parallel()
+
+	var scopeErr error
+
+	authorizer := func(_ context.Context, _ *util.Scope) error {
+		return errScopeUnavailable
+	}
+
+	handler := scopeHandler(t, func(_ http.ResponseWriter, r *http.Request) {
+		_, scopeErr = util.ScopeFromRequest(r, authorizer)
+	})
+
+	handler.ServeHTTP(httptest.NewRecorder(), scopeRequest(t, organizationID))
+
+	require.ErrorIs(t, scopeErr, errScopeUnavailable)
+	require.False(t, servererrors.IsForbidden(scopeErr))
+}
+
+// namespaceClient returns a client with an organization namespace, and a project
+// namespace within that organization.
+func namespaceClient(t *testing.T) client.Client {
+	t.Helper()
+
+	organization := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "organization-ns",
+			Labels: map[string]string{
+				constants.KindLabel:         constants.KindLabelValueOrganization,
+				constants.OrganizationLabel: organizationID,
+			},
+		},
+	}
+
+	project := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "project-ns",
+			Labels: map[string]string{
+				constants.KindLabel:         constants.KindLabelValueProject,
+				constants.OrganizationLabel: organizationID,
+				constants.ProjectLabel:      projectID,
+			},
+		},
+	}
+
+	return fake.NewClientBuilder().WithObjects(organization, project).Build()
+}
+
+// TestScopeNamespacedNameOrganization checks organization scoped resources are
+// located in the organization namespace.
+func TestScopeNamespacedNameOrganization(t *testing.T) {
+	t.Parallel()
+
+	scope := &util.Scope{
+		OrganizationID: organizationID,
+	}
+
+	name, err := scope.NamespacedName(t.Context(), namespaceClient(t), "thing")
+	require.NoError(t, err)
+	require.Equal(t, types.NamespacedName{Namespace: "organization-ns", Name: "thing"}, name)
+}
+
+// TestScopeNamespacedNameProject checks project scoped resources are located in
+// the project namespace, not the organization namespace.
+func TestScopeNamespacedNameProject(t *testing.T) {
+	t.Parallel()
+
+	scope := &util.Scope{
+		OrganizationID: organizationID,
+		ProjectID:      projectID,
+	}
+
+	name, err := scope.NamespacedName(t.Context(), namespaceClient(t), "thing")
+	require.NoError(t, err)
+	require.Equal(t, types.NamespacedName{Namespace: "project-ns", Name: "thing"}, name)
+}
+
+// TestScopeNamespacedNameNotFound checks a missing namespace is reported as the
+// resource not being found.
+func TestScopeNamespacedNameNotFound(t *testing.T) {
+	t.Parallel()
+
+	scope := &util.Scope{
+		OrganizationID: organizationID,
+		ProjectID:      "baz",
+	}
+
+	_, err := scope.NamespacedName(t.Context(), namespaceClient(t), "thing")
+	require.True(t, servererrors.IsHTTPNotFound(err))
+}
@@ -18,11 +18,16 @@ limitations under the License.
 package client
 
 import (
+	"bytes"
 	"context"
+	"crypto/tls"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"slices"
 	"strings"
 	"time"
 )
@@ -30,6 +35,15 @@ import (
 var (
 	// ErrUnexpectedStatusCode indicates an unexpected HTTP status code was received.
 	ErrUnexpectedStatusCode = errors.New("unexpected status code")
+
+	// ErrTooManyPages indicates pagination exceeded the configured maximum pages.
+	ErrTooManyPages = errors.New("too many pages")
+)
+
+const (
+	// DefaultMaxPages is the default maximum number of pages followed by
+	// ListAllResources.
+	DefaultMaxPages = 100
 )
 
 // Logger defines the interface for logging in the API client.
@@ -38,12 +52,29 @@ type Logger interface {
 	Printf(format string, args ...interface{})
 }
 
+// RetryConfig defines how EventuallyGet polls eventually consistent APIs.
+type RetryConfig struct {
+	// Attempts is the total number of requests to make, values less than
+	// two mean a single request is made.
+	Attempts int
+	// Backoff is the time to wait between attempts.
+	Backoff time.Duration
+	// RetryOnStatus, if set, limits retries to these status codes, any other
+	// unexpected status code fails immediately.  Transport errors are always
+	// retried.
+	RetryOnStatus []int
+}
+
 // Config holds the base configuration for the API client.
 type Config struct {
 	BaseURL        string
 	RequestTimeout time.Duration
 	LogRequests    bool
 	LogResponses   bool
+	Retry          RetryConfig
+	// TLSConfig, if set, is used for HTTPS connections e.g. to provide a
+	// client certificate for mTLS, or a private CA.  See LoadTLSConfig.
+	TLSConfig *tls.Config
 }
 
 // APIClient provides a generic HTTP client for API testing with trace context support.
@@ -69,11 +100,26 @@ func NewAPIClient(baseURL, authToken string, timeout time.Duration, logger Logge
 
 // NewAPIClientWithConfig creates a new API client with the given configuration struct.
 func NewAPIClientWithConfig(config Config, authToken string, logger Logger) *APIClient {
+	client := &http.Client{
+		Timeout: config.RequestTimeout,
+	}
+
+	if config.TLSConfig != nil {
+		transport, ok := http.DefaultTransport.(*http.Transport)
+		if ok {
+			transport = transport.Clone()
+		} else {
+			transport = &http.Transport{}
+		}
+
+		transport.TLSClientConfig = config.TLSConfig
+
+		client.Transport = transport
+	}
+
 	return &APIClient{
-		baseURL: strings.TrimSuffix(config.BaseURL, "/"),
-		client: &http.Client{
-			Timeout: config.RequestTimeout,
-		},
+		baseURL:   strings.TrimSuffix(config.BaseURL, "/"),
+		client:    client,
 		authToken: authToken,
 		config:    config,
 		logger:    logger,
@@ -126,8 +172,28 @@ func (c *APIClient) logTraceContext(traceParent string) {
 	}
 }
 
+// RequestOption decorates an outgoing request e.g. with additional headers.
+type RequestOption func(req *http.Request)
+
+// WithHeader sets a request header, this may override the defaults set by the client.
+func WithHeader(key, value string) RequestOption {
+	return func(req *http.Request) {
+		req.Header.Set(key, value)
+	}
+}
+
+// WithQuery adds a query parameter to the request.
+func WithQuery(key, value string) RequestOption {
+	return func(req *http.Request) {
+		query := req.URL.Query()
+		query.Add(key, value)
+
+		req.URL.RawQuery = query.Encode()
+	}
+}
+
 // buildHTTPRequest creates an HTTP request with trace context and authentication headers.
-func (c *APIClient) buildHTTPRequest(ctx context.Context, method, fullURL string, body io.Reader) (*http.Request, string, error) {
+func (c *APIClient) buildHTTPRequest(ctx context.Context, method, fullURL string, body io.Reader, options ...RequestOption) (*http.Request, string, error) {
 	req, err := http.NewRequestWithContext(ctx, method, fullURL, body)
 	if err != nil {
 		return nil, "", fmt.Errorf("creating request: %w", err)
@@ -145,6 +211,10 @@ func (c *APIClient) buildHTTPRequest(ctx context.Context, method, fullURL string
 		req.Header.Set("Authorization", "Bearer "+c.authToken)
 	}
 
+	for _, option := range options {
+		option(req)
+	}
+
 	return req, traceParent, nil
 }
 
@@ -197,9 +267,15 @@ func (c *APIClient) validateStatusCode(method, path string, expectedStatus, actu
 // DoRequest performs an HTTP request with W3C trace context and returns the response.
 // If expectedStatus is > 0, the function will return an error if the response status doesn't match.
 func (c *APIClient) DoRequest(ctx context.Context, method, path string, body io.Reader, expectedStatus int) (*http.Response, []byte, error) {
+	return c.DoRequestWithOptions(ctx, method, path, body, expectedStatus)
+}
+
+// DoRequestWithOptions performs an HTTP request like DoRequest, but allows the
+// request to be decorated e.g. with additional headers and query parameters.
+func (c *APIClient) DoRequestWithOptions(ctx context.Context, method, path string, body io.Reader, expectedStatus int, options ...RequestOption) (*http.Response, []byte, error) {
 	fullURL := c.baseURL + path
 
-	req, traceParent, err := c.buildHTTPRequest(ctx, method, fullURL, body)
+	req, traceParent, err := c.buildHTTPRequest(ctx, method, fullURL, body, options...)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -224,6 +300,54 @@ func (c *APIClient) DoRequest(ctx context.Context, method, path string, body io.
 	return resp, respBody, nil
 }
 
+// shouldRetry determines whether a failed request should be retried.
+func (c *APIClient) shouldRetry(resp *http.Response) bool {
+	if resp == nil || len(c.config.Retry.RetryOnStatus) == 0 {
+		return true
+	}
+
+	return slices.Contains(c.config.Retry.RetryOnStatus, resp.StatusCode)
+}
+
+// logRetry logs a failed attempt that will be retried.
+func (c *APIClient) logRetry(method, path string, attempt int, resp *http.Response, err error) {
+	if c.logger == nil {
+		return
+	}
+
+	var (
+		status      int
+		traceParent string
+	)
+
+	if resp != nil {
+		status = resp.StatusCode
+		traceParent = resp.Request.Header.Get("Traceparent")
+	}
+
+	c.logger.Printf("[%s %s] RETRY attempt=%d/%d status=%d %s error=%v\n", method, path, attempt, c.config.Retry.Attempts, status, FormatTraceContext(traceParent), err)
+}
+
+// EventuallyGet polls a resource until the expected status is returned, as
+// defined by the client's retry configuration, for use with eventually consistent
+// APIs.  The last response and error are returned should all attempts fail.
+func (c *APIClient) EventuallyGet(ctx context.Context, path string, expectedStatus int, options ...RequestOption) (*http.Response, []byte, error) {
+	for attempt := 1; ; attempt++ {
+		resp, respBody, err := c.DoRequestWithOptions(ctx, http.MethodGet, path, nil, expectedStatus, options...)
+		if err == nil || attempt >= c.config.Retry.Attempts || !c.shouldRetry(resp) {
+			return resp, respBody, err
+		}
+
+		c.logRetry(http.MethodGet, path, attempt, resp, err)
+
+		select {
+		case <-ctx.Done():
+			return resp, respBody, err
+		case <-time.After(c.config.Retry.Backoff):
+		}
+	}
+}
+
 // ListResource is a type-safe generic helper for list operations.
 // Type parameter T should be the element type (e.g., openapi.Cluster).
 // Example usage: ListResource[openapi.Cluster](ctx, client, path, config).
@@ -237,3 +361,133 @@ func ListResource[T any](ctx context.Context, c *APIClient, path string, config
 
 	return HandleResourceListResponse[T](resp, respBody, config)
 }
+
+// doTypedRequest performs a request, marshaling any request body and unmarshaling
+// the response body on success.
+func doTypedRequest[U any](ctx context.Context, c *APIClient, method, path string, body any, expectedStatus int, options ...RequestOption) (*U, *http.Response, error) {
+	var reader io.Reader
+
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, nil, fmt.Errorf("marshaling request body: %w", err)
+		}
+
+		reader = bytes.NewReader(data)
+	}
+
+	//nolint:bodyclose // response body is closed in DoRequest
+	resp, respBody, err := c.DoRequestWithOptions(ctx, method, path, reader, expectedStatus, options...)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	var result U
+
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return nil, resp, fmt.Errorf("unmarshaling response body: %w", err)
+	}
+
+	return &result, resp, nil
+}
+
+// GetResource is a type-safe generic helper for read operations, expecting
+// a 200 response.
+// Example usage: GetResource[openapi.ClusterRead](ctx, client, path).
+func GetResource[T any](ctx context.Context, c *APIClient, path string, options ...RequestOption) (*T, *http.Response, error) {
+	return doTypedRequest[T](ctx, c, http.MethodGet, path, nil, http.StatusOK, options...)
+}
+
+// CreateResource is a type-safe generic helper for create operations, expecting
+// a 201 response.  Type parameter T is the request type and U the response type.
+// Example usage: CreateResource[openapi.ClusterWrite, openapi.ClusterRead](ctx, client, path, request).
+func CreateResource[T, U any](ctx context.Context, c *APIClient, path string, body T, options ...RequestOption) (*U, *http.Response, error) {
+	return doTypedRequest[U](ctx, c, http.MethodPost, path, body, http.StatusCreated, options...)
+}
+
+// nextLink returns the URL of the next page from a RFC8288 Link header, if any.
+func nextLink(header http.Header) string {
+	for _, value := range header.Values("Link") {
+		for link := range strings.SplitSeq(value, ",") {
+			parts := strings.Split(link, ";")
+
+			target := strings.TrimSpace(parts[0])
+			if !strings.HasPrefix(target, "<") || !strings.HasSuffix(target, ">") {
+				continue
+			}
+
+			for _, param := range parts[1:] {
+				key, rel, ok := strings.Cut(strings.TrimSpace(param), "=")
+				if !ok || !strings.EqualFold(key, "rel") {
+					continue
+				}
+
+				if slices.Contains(strings.Fields(strings.Trim(rel, `"`)), "next") {
+					return strings.TrimSuffix(strings.TrimPrefix(target, "<"), ">")
+				}
+			}
+		}
+	}
+
+	return ""
+}
+
+// relativePath converts a link to a path that can be used with the client.
+func (c *APIClient) relativePath(link string) (string, error) {
+	if strings.HasPrefix(link, c.baseURL) {
+		return strings.TrimPrefix(link, c.baseURL), nil
+	}
+
+	u, err := url.Parse(link)
+	if err != nil {
+		return "", fmt.Errorf("parsing next page link: %w", err)
+	}
+
+	if u.IsAbs() {
+		return u.RequestURI(), nil
+	}
+
+	return link, nil
+}
+
+// ListAllResources is a type-safe generic helper for paginated list operations.
+// It follows the "next" relation of the Link header until there are no more pages,
+// concatenating the results.
+// Example usage: ListAllResources[openapi.Cluster](ctx, client, path, config).
+func ListAllResources[T any](ctx context.Context, c *APIClient, path string, config ResponseHandlerConfig) ([]T, error) {
+	maxPages := config.MaxPages
+	if maxPages <= 0 {
+		maxPages = DefaultMaxPages
+	}
+
+	var result []T
+
+	for page := 0; path != ""; page++ {
+		if page >= maxPages {
+			return nil, fmt.Errorf("listing %s exceeded %d pages: %w", config.ResourceType, maxPages, ErrTooManyPages)
+		}
+
+		//nolint:bodyclose // response body is closed in DoRequest
+		resp, respBody, err := c.DoRequest(ctx, http.MethodGet, path, nil, 0)
+		if err != nil {
+			return nil, fmt.Errorf("listing %s: %w", config.ResourceType, err)
+		}
+
+		resources, err := HandleResourceListResponse[T](resp, respBody, config)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, resources...)
+
+		path = ""
+
+		if next := nextLink(resp.Header); next != "" {
+			if path, err = c.relativePath(next); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return result, nil
+}
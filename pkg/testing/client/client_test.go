@@ -0,0 +1,194 @@
+/*
+Copyright 2026 Nscale.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client_test
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/unikorn-cloud/core/pkg/testing/client"
+)
+
+// newServer returns a test server that records the last request it received.
+func newServer(t *testing.T) (*httptest.Server, *http.Request) {
+	t.Helper()
+
+	received := &http.Request{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*received = *r.Clone(t.Context())
+
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	t.Cleanup(server.Close)
+
+	return server, received
+}
+
+// TestRequestOptions tests request options reach the outgoing request.
+func TestRequestOptions(t *testing.T) {
+	t.Parallel()
+
+	server, received := newServer(t)
+
+	c := client.NewAPIClient(server.URL, "token", time.Second, nil)
+
+	//nolint:bodyclose // response body is closed in DoRequest
+	_, _, err := c.DoRequestWithOptions(t.Context(), http.MethodGet, "/api?existing=true", nil, http.StatusOK,
+		client.WithHeader("Idempotency-Key", "cat"),
+		client.WithHeader("X-Tenant-ID", "dog"),
+		client.WithQuery("limit", "10"),
+		client.WithQuery("limit", "20"),
+	)
+	require.NoError(t, err)
+
+	require.Equal(t, "cat", received.Header.Get("Idempotency-Key"))
+	require.Equal(t, "dog", received.Header.Get("X-Tenant-ID"))
+	require.Equal(t, "Bearer token", received.Header.Get("Authorization"))
+	require.NotEmpty(t, received.Header.Get("Traceparent"))
+	require.Equal(t, "true", received.URL.Query().Get("existing"))
+	require.Equal(t, []string{"10", "20"}, received.URL.Query()["limit"])
+}
+
+// TestRequestOptionsOverride tests request options override client defaults.
+func TestRequestOptionsOverride(t *testing.T) {
+	t.Parallel()
+
+	server, received := newServer(t)
+
+	c := client.NewAPIClient(server.URL, "token", time.Second, nil)
+
+	//nolint:bodyclose // response body is closed in DoRequest
+	_, _, err := c.DoRequestWithOptions(t.Context(), http.MethodGet, "/api", nil, http.StatusOK,
+		client.WithHeader("Authorization", "Bearer other"),
+	)
+	require.NoError(t, err)
+
+	require.Equal(t, "Bearer other", received.Header.Get("Authorization"))
+}
+
+// TestTLS tests a custom TLS configuration is used for HTTPS connections.
+func TestTLS(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	t.Cleanup(server.Close)
+
+	caFile := filepath.Join(t.TempDir(), "ca.crt")
+
+	ca := pem.EncodeToMemory(&pem.Block{
+		Type:  "CERTIFICATE",
+		Bytes: server.Certificate().Raw,
+	})
+
+	require.NoError(t, os.WriteFile(caFile, ca, 0o600))
+
+	// The server's certificate is self-signed, so is rejected by default.
+	c := client.NewAPIClient(server.URL, "", time.Second, nil)
+
+	//nolint:bodyclose // response body is closed in DoRequest
+	_, _, err := c.DoRequest(t.Context(), http.MethodGet, "/api", nil, http.StatusOK)
+	require.Error(t, err)
+
+	tlsConfig, err := client.LoadTLSConfig("", "", caFile)
+	require.NoError(t, err)
+
+	config := client.Config{
+		BaseURL:        server.URL,
+		RequestTimeout: time.Second,
+		TLSConfig:      tlsConfig,
+	}
+
+	c = client.NewAPIClientWithConfig(config, "", nil)
+
+	//nolint:bodyclose // response body is closed in DoRequest
+	_, _, err = c.DoRequest(t.Context(), http.MethodGet, "/api", nil, http.StatusOK)
+	require.NoError(t, err)
+}
+
+// resource is a fake API resource.
+type resource struct {
+	Name string `json:"name"`
+}
+
+// newPaginatedServer returns a server with two pages of resources, the first
+// linking to the second.
+func newPaginatedServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	var server *httptest.Server
+
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Query().Get("page") {
+		case "":
+			w.Header().Set("Link", `<`+server.URL+`/api/resources?page=2>; rel="next", <`+server.URL+`/api/resources>; rel="first"`)
+
+			_, _ = w.Write([]byte(`[{"name":"foo"},{"name":"bar"}]`))
+		case "2":
+			_, _ = w.Write([]byte(`[{"name":"baz"}]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+// TestListAllResources tests pagination is followed until exhausted.
+func TestListAllResources(t *testing.T) {
+	t.Parallel()
+
+	server := newPaginatedServer(t)
+
+	c := client.NewAPIClient(server.URL, "", time.Second, nil)
+
+	resources, err := client.ListAllResources[resource](t.Context(), c, "/api/resources", client.ResponseHandlerConfig{ResourceType: "resources"})
+	require.NoError(t, err)
+	require.Equal(t, []resource{{Name: "foo"}, {Name: "bar"}, {Name: "baz"}}, resources)
+}
+
+// TestListAllResourcesMaxPages tests pagination is capped.
+func TestListAllResourcesMaxPages(t *testing.T) {
+	t.Parallel()
+
+	server := newPaginatedServer(t)
+
+	c := client.NewAPIClient(server.URL, "", time.Second, nil)
+
+	config := client.ResponseHandlerConfig{
+		ResourceType: "resources",
+		MaxPages:     1,
+	}
+
+	_, err := client.ListAllResources[resource](t.Context(), c, "/api/resources", config)
+	require.ErrorIs(t, err, client.ErrTooManyPages)
+}
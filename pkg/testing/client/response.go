@@ -42,6 +42,9 @@ type ResponseHandlerConfig struct {
 	ResourceIDType string
 	AllowForbidden bool
 	AllowNotFound  bool
+	// MaxPages caps the number of pages ListAllResources will follow, as a
+	// safety net against pagination loops.  Defaults to DefaultMaxPages.
+	MaxPages int
 }
 
 // HandleResourceListResponse handles common response patterns for resource listing endpoints using type-safe generics.
@@ -0,0 +1,95 @@
+/*
+Copyright 2026 Nscale.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// PrometheusRecorder is an observer that records cache refreshes as Prometheus
+// metrics.  It is itself a collector, so must be registered to be exported.
+type PrometheusRecorder struct {
+	// duration records the wall clock time of refreshes.
+	duration prometheus.Histogram
+	// items records the number of items in the cache.
+	items prometheus.Gauge
+	// errors counts failed refreshes.
+	errors prometheus.Counter
+}
+
+// Ensure the interfaces are implemented.
+var (
+	_ Observer             = &PrometheusRecorder{}
+	_ prometheus.Collector = &PrometheusRecorder{}
+)
+
+// NewPrometheusRecorder creates a new Prometheus recorder, labelled with the
+// cache name so multiple caches can be distinguished.
+func NewPrometheusRecorder(name string) *PrometheusRecorder {
+	labels := prometheus.Labels{"cache": name}
+
+	return &PrometheusRecorder{
+		duration: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Name:        "unikorn_cache_refresh_duration_seconds",
+			Help:        "Wall clock time of cache refreshes.",
+			ConstLabels: labels,
+			Buckets:     prometheus.ExponentialBuckets(0.01, 2, 14),
+		}),
+		items: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name:        "unikorn_cache_items",
+			Help:        "Number of items in the cache.",
+			ConstLabels: labels,
+		}),
+		errors: prometheus.NewCounter(prometheus.CounterOpts{
+			Name:        "unikorn_cache_refresh_errors_total",
+			Help:        "Total number of failed cache refreshes.",
+			ConstLabels: labels,
+		}),
+	}
+}
+
+// OnRefreshStart implements the Observer interface.
+func (r *PrometheusRecorder) OnRefreshStart() {
+}
+
+// OnRefreshComplete implements the Observer interface.
+func (r *PrometheusRecorder) OnRefreshComplete(stats *RefreshStats) {
+	r.duration.Observe(stats.Duration.Seconds())
+	r.items.Set(float64(stats.Items))
+
+	if stats.Err != nil {
+		r.errors.Inc()
+	}
+}
+
+// OnInvalidate implements the Observer interface.
+func (r *PrometheusRecorder) OnInvalidate() {
+}
+
+// Describe implements the prometheus.Collector interface.
+func (r *PrometheusRecorder) Describe(ch chan<- *prometheus.Desc) {
+	r.duration.Describe(ch)
+	r.items.Describe(ch)
+	r.errors.Describe(ch)
+}
+
+// Collect implements the prometheus.Collector interface.
+func (r *PrometheusRecorder) Collect(ch chan<- prometheus.Metric) {
+	r.duration.Collect(ch)
+	r.items.Collect(ch)
+	r.errors.Collect(ch)
+}
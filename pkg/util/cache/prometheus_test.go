@@ -0,0 +1,65 @@
+/*
+Copyright 2026 Nscale.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/require"
+
+	"github.com/unikorn-cloud/core/pkg/util/cache"
+)
+
+// TestPrometheusRecorder tests refreshes are recorded as Prometheus metrics
+// labelled with the cache name.
+func TestPrometheusRecorder(t *testing.T) {
+	t.Parallel()
+
+	recorder := cache.NewPrometheusRecorder("test")
+
+	registry := prometheus.NewRegistry()
+	require.NoError(t, registry.Register(recorder))
+
+	recorder.OnRefreshComplete(&cache.RefreshStats{Duration: time.Second, Items: 3})
+	recorder.OnRefreshComplete(&cache.RefreshStats{Duration: time.Second, Items: 3, Err: errors.ErrUnsupported})
+
+	families, err := registry.Gather()
+	require.NoError(t, err)
+
+	metrics := map[string]*dto.Metric{}
+
+	for _, family := range families {
+		require.Len(t, family.GetMetric(), 1)
+
+		metric := family.GetMetric()[0]
+
+		require.Len(t, metric.GetLabel(), 1)
+		require.Equal(t, "cache", metric.GetLabel()[0].GetName())
+		require.Equal(t, "test", metric.GetLabel()[0].GetValue())
+
+		metrics[family.GetName()] = metric
+	}
+
+	require.Len(t, metrics, 3)
+	require.Equal(t, uint64(2), metrics["unikorn_cache_refresh_duration_seconds"].GetHistogram().GetSampleCount())
+	require.InDelta(t, 3, metrics["unikorn_cache_items"].GetGauge().GetValue(), 0)
+	require.InDelta(t, 1, metrics["unikorn_cache_refresh_errors_total"].GetCounter().GetValue(), 0)
+}
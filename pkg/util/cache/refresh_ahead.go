@@ -17,10 +17,15 @@ limitations under the License.
 package cache
 
 import (
+	"cmp"
 	"context"
 	"errors"
 	"fmt"
+	"iter"
 	"maps"
+	"math/rand/v2"
+	"net/http"
+	"slices"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -99,15 +104,70 @@ type ListSnapshot[T any] struct {
 // occurs during a refresh to hide the cost.
 type RefreshFunc[T any, TP CacheablePointer[T]] func(ctx context.Context) ([]TP, error)
 
+// ItemRefreshFunc provides the client a way to define how to load a single
+// item of cache data.  If the item no longer exists it must return an error
+// wrapping ErrNotFound.
+type ItemRefreshFunc[T any, TP CacheablePointer[T]] func(ctx context.Context, index string) (TP, error)
+
 // IndexFunc provides the client a way to define how indexes are generated
 // from cache resources.  The index must be unique across all resources.
 type IndexFunc[T any, TP CacheablePointer[T]] func(t TP) string
 
+// Observer allows clients to monitor cache behaviour e.g. via metrics,
+// without the cache depending on any particular metrics implementation.
+// Callbacks are never called with any cache locks held, but should still
+// return promptly as they are called inline with the refresh process.
+type Observer interface {
+	// OnRefreshStart is called when a refresh begins.
+	OnRefreshStart()
+	// OnRefreshComplete is called when a refresh ends, reporting how long it
+	// took, the number of visible items, whether the visible cache data changed,
+	// and any error.
+	OnRefreshComplete(stats *RefreshStats)
+	// OnInvalidate is called when a client explicitly invalidates the cache.
+	OnInvalidate()
+}
+
+// RefreshStats summarizes a refresh for observers.
+type RefreshStats struct {
+	// Duration is how long the refresh took.
+	Duration time.Duration
+	// Items is the number of items visible in the cache after the refresh.
+	Items int
+	// Changed is whether the visible cache data changed.
+	Changed bool
+	// Err is any error that occurred during the refresh.
+	Err error
+}
+
 // RefreshAheadCacheOptions allows the cache to be configured in various
 // ways.
 type RefreshAheadCacheOptions struct {
 	// RefreshPeriod controls how often to refresh data.
 	RefreshPeriod time.Duration
+	// RefreshJitter randomizes each refresh period by up to +/- this amount,
+	// so a fleet of caches started at the same time does not refresh in
+	// lock step and overwhelm the data source.  It is capped at half the
+	// refresh period.  Invalidation is unaffected.
+	RefreshJitter time.Duration
+	// InvalidateDebounce, if set, delays invalidation driven refreshes until
+	// no further invalidations have been made for this period, collapsing a
+	// burst of invalidations into a single refresh.  Callers still block until
+	// their data is visible, at the expense of added latency.  To bound that
+	// latency, the refresh will happen after at most ten periods.
+	InvalidateDebounce time.Duration
+	// Observer, if set, is notified of cache refresh events.
+	Observer Observer
+	// StaleWhileError allows the cache to start even if the initial refresh
+	// fails, retrying in the background until it succeeds.  Any data that
+	// has been successfully loaded continues to be served when refreshes fail.
+	// Use LastError to surface degraded operation e.g. in a readiness probe.
+	StaleWhileError bool
+	// PreserveOrder lists items in the order they were returned by the
+	// refresh function, rather than an undefined order, for when the data
+	// source is already sorted.  Items added locally are listed after
+	// refreshed items in the order they were written.
+	PreserveOrder bool
 }
 
 const (
@@ -116,6 +176,14 @@ const (
 	// done with an explicit invalidation.  Changes to the underlying
 	// data are assumed to be relatively infrequent.
 	defaultRefreshPeriod = time.Hour
+
+	// defaultRetryPeriod is used to retry the initial refresh when it has
+	// failed and StaleWhileError is set, the cache is unusable until then.
+	defaultRetryPeriod = 10 * time.Second
+
+	// maxDebouncePeriods bounds how long a continuous stream of invalidations
+	// can delay a refresh.
+	maxDebouncePeriods = 10
 )
 
 // cacheMap is the underlying cache implementation.
@@ -143,7 +211,8 @@ func (m cacheMap[T, TP]) Equal(o cacheMap[T, TP]) bool {
 }
 
 // overlayEntry records a local mutation that must remain visible until a later
-// refresh that started after the mutation has completed.
+// refresh that started after the mutation has completed.  A nil item records
+// a deletion.
 type overlayEntry[T any, TP CacheablePointer[T]] struct {
 	item  TP
 	epoch Epoch
@@ -157,6 +226,8 @@ type overlayMap[T any, TP CacheablePointer[T]] map[string]overlayEntry[T, TP]
 type invalidationRequest struct {
 	// done is closed by the refresh process to indicate completion.
 	done chan any
+	// joined is signalled when another client coalesces onto the request.
+	joined chan any
 	// err return the refresh error status to the client.
 	err error
 }
@@ -178,7 +249,8 @@ type invalidationRequest struct {
 // Either the entire cache can be refreshed, which facilitates addition of
 // resources out-of-band, or synchronization of individual resources for
 // example on creation or update to avoid having to perform a potentially
-// costly rebuild.
+// costly rebuild.  Individual resources are synchronized with InvalidateItem
+// and require an ItemRefreshFunc.
 //
 // Local writes are applied through a write-through overlay. A local mutation
 // is immediately visible in the effective cache view. If a refresh is already
@@ -217,7 +289,8 @@ type invalidationRequest struct {
 //
 // The cache features pre-population so it will be ready to use, and this
 // synchronization status can be fed directly into Kubernetes readiness
-// probes to facilitate a seemless rolling upgrade experience.
+// probes, via Ready or ReadinessHandler, to facilitate a seemless rolling
+// upgrade experience.
 //
 // # Read Safety
 //
@@ -243,6 +316,9 @@ type invalidationRequest struct {
 // cached resurces and further improve performance.  An example of this
 // is JSON encoding which uses runtime type reflection and is relatively
 // costly.
+//
+// Clients that need to react to changes, rather than poll, can Subscribe
+// to epoch transitions.
 type RefreshAheadCache[T any, TP CacheablePointer[T]] struct {
 	// options provide cache configuration.
 	options *RefreshAheadCacheOptions
@@ -252,14 +328,21 @@ type RefreshAheadCache[T any, TP CacheablePointer[T]] struct {
 	epoch Epoch
 	// refresh is used to refresh the entire cache in the background.
 	refresh RefreshFunc[T, TP]
+	// itemRefresh is optionally used to refresh individual items.
+	itemRefresh ItemRefreshFunc[T, TP]
 	// cache records the effective user-visible data after applying any pending
 	// overlay mutations.
 	cache cacheMap[T, TP]
+	// order records the indexes of the effective cache in list order, only
+	// maintained if the order is preserved.
+	order []string
 	// overlay records local mutations that must survive any refresh already in
 	// flight when they were written.
 	overlay overlayMap[T, TP]
 	// lock controls concurrent accesses.
 	lock sync.RWMutex
+	// lastError is the error reported by the last refresh, if any.
+	lastError error
 	// invalidations is a channel that allows a client to synchronously
 	// perform a refresh, useful for situations where you need a value
 	// to be visible in the cache before continuation.
@@ -270,6 +353,13 @@ type RefreshAheadCache[T any, TP CacheablePointer[T]] struct {
 	// pending is the in-flight invalidation request, if any.  Concurrent
 	// callers coalesce onto this rather than each queuing a separate refresh.
 	pending *invalidationRequest
+
+	// subscribersLock guards subscribers and notified.
+	subscribersLock sync.Mutex
+	// subscribers are notified of epoch transitions.
+	subscribers map[chan Epoch]struct{}
+	// notified is the last epoch subscribers were notified of.
+	notified Epoch
 }
 
 // NewRefreshAheadCache constructs a new refresh ahead cache.
@@ -280,6 +370,14 @@ func NewRefreshAheadCache[T any, TP CacheablePointer[T]](refresh RefreshFunc[T,
 	}
 }
 
+// WithItemRefresh allows individual items to be synchronized with
+// InvalidateItem rather than having to refresh the entire cache.
+func (c *RefreshAheadCache[T, TP]) WithItemRefresh(refresh ItemRefreshFunc[T, TP]) *RefreshAheadCache[T, TP] {
+	c.itemRefresh = refresh
+
+	return c
+}
+
 // newEpoch allocates a new epoch local to this cache instance.
 func (c *RefreshAheadCache[T, TP]) newEpoch() Epoch {
 	return Epoch{
@@ -296,6 +394,8 @@ func (c *RefreshAheadCache[T, TP]) newEpoch() Epoch {
 // authoritative until a later refresh that started after the insert replaces
 // it with backend state.
 func (c *RefreshAheadCache[T, TP]) InsertIfAbsent(item TP) error {
+	defer c.notify()
+
 	c.lock.Lock()
 	defer c.lock.Unlock()
 
@@ -311,18 +411,7 @@ func (c *RefreshAheadCache[T, TP]) InsertIfAbsent(item TP) error {
 		return nil
 	}
 
-	if c.overlay == nil {
-		c.overlay = make(overlayMap[T, TP])
-	}
-
-	writeEpoch := c.newEpoch()
-
-	c.overlay[index] = overlayEntry[T, TP]{
-		item:  item,
-		epoch: writeEpoch,
-	}
-	c.cache[index] = item
-	c.epoch = writeEpoch
+	c.writeOverlayLocked(index, item)
 
 	return nil
 }
@@ -338,6 +427,8 @@ func (c *RefreshAheadCache[T, TP]) InsertIfAbsent(item TP) error {
 // written value remains authoritative until a later refresh that started after
 // the write replaces it with backend state.
 func (c *RefreshAheadCache[T, TP]) Upsert(item TP) error {
+	defer c.notify()
+
 	c.lock.Lock()
 	defer c.lock.Unlock()
 
@@ -345,21 +436,43 @@ func (c *RefreshAheadCache[T, TP]) Upsert(item TP) error {
 		return ErrInvalid
 	}
 
+	c.writeOverlayLocked(item.Index(), item)
+
+	return nil
+}
+
+// writeOverlayLocked records a local mutation in both the overlay and the
+// effective cache view.  A nil item deletes the key.
+func (c *RefreshAheadCache[T, TP]) writeOverlayLocked(index string, item TP) {
 	if c.overlay == nil {
 		c.overlay = make(overlayMap[T, TP])
 	}
 
-	index := item.Index()
 	writeEpoch := c.newEpoch()
 
 	c.overlay[index] = overlayEntry[T, TP]{
 		item:  item,
 		epoch: writeEpoch,
 	}
-	c.cache[index] = item
-	c.epoch = writeEpoch
 
-	return nil
+	if c.options.PreserveOrder {
+		_, ok := c.cache[index]
+
+		switch {
+		case item == nil && ok:
+			c.order = slices.DeleteFunc(c.order, func(i string) bool { return i == index })
+		case item != nil && !ok:
+			c.order = append(c.order, index)
+		}
+	}
+
+	if item == nil {
+		delete(c.cache, index)
+	} else {
+		c.cache[index] = item
+	}
+
+	c.epoch = writeEpoch
 }
 
 // mergeAndPruneOverlayLocked rebuilds the effective user-visible cache view
@@ -394,6 +507,12 @@ func (c *RefreshAheadCache[T, TP]) mergeAndPruneOverlayLocked(cache cacheMap[T,
 		}
 
 		overlay[index] = entry
+
+		if entry.item == nil {
+			delete(effective, index)
+			continue
+		}
+
 		effective[index] = entry.item
 	}
 
@@ -406,24 +525,50 @@ func (c *RefreshAheadCache[T, TP]) mergeAndPruneOverlayLocked(cache cacheMap[T,
 	return effective
 }
 
+// mergeOrderLocked returns the list order of the effective cache view: items
+// from the refreshed backend snapshot in source order, followed by items that
+// only exist in the overlay in the order they were written.  This must be
+// called after the overlay has been merged and pruned.
+func (c *RefreshAheadCache[T, TP]) mergeOrderLocked(order []string, cache, effective cacheMap[T, TP]) []string {
+	merged := make([]string, 0, len(effective))
+
+	for _, index := range order {
+		if _, ok := effective[index]; ok {
+			merged = append(merged, index)
+		}
+	}
+
+	var inserted []string
+
+	for index, entry := range c.overlay {
+		if _, ok := cache[index]; !ok && entry.item != nil {
+			inserted = append(inserted, index)
+		}
+	}
+
+	slices.SortFunc(inserted, func(a, b string) int {
+		return cmp.Compare(c.overlay[a].epoch.epoch, c.overlay[b].epoch.epoch)
+	})
+
+	return append(merged, inserted...)
+}
+
 // Run performs a synchronous refresh to pre load cache data and
 // starts the background refresher.
 func (c *RefreshAheadCache[T, TP]) Run(ctx context.Context) error {
 	if err := c.doRefresh(ctx); err != nil {
-		return err
+		if !c.options.StaleWhileError {
+			return err
+		}
+
+		log.Log.Error(err, "failed to load cache data, retrying in the background")
 	}
 
 	c.invalidations = make(chan *invalidationRequest)
 
 	refresher := func() {
-		refreshPeriod := defaultRefreshPeriod
-
-		if c.options.RefreshPeriod != 0 {
-			refreshPeriod = c.options.RefreshPeriod
-		}
-
-		ticker := time.NewTicker(refreshPeriod)
-		defer ticker.Stop()
+		timer := time.NewTimer(c.refreshInterval())
+		defer timer.Stop()
 
 		for {
 			select {
@@ -431,6 +576,8 @@ func (c *RefreshAheadCache[T, TP]) Run(ctx context.Context) error {
 				close(c.invalidations)
 				return
 			case request := <-c.invalidations:
+				c.debounce(ctx, request)
+
 				// This request is about to be attempted. Clear the pending field so that the next
 				// caller of Invalidate will create their own pending request, and not glom
 				// onto this one while it's in flight.
@@ -440,11 +587,15 @@ func (c *RefreshAheadCache[T, TP]) Run(ctx context.Context) error {
 
 				request.err = c.doRefresh(ctx)
 				close(request.done)
-			case <-ticker.C:
+			case <-timer.C:
 				if err := c.doRefresh(ctx); err != nil {
 					log.Log.Error(err, "failed to refresh cache data")
 				}
 			}
+
+			// The period will change once an initially failing cache loads,
+			// and jitter is recalculated every cycle.
+			timer.Reset(c.refreshInterval())
 		}
 	}
 
@@ -453,10 +604,112 @@ func (c *RefreshAheadCache[T, TP]) Run(ctx context.Context) error {
 	return nil
 }
 
+// debounce waits for invalidations to settle before a request is serviced.
+// While we wait, the request remains pending, so any other clients invalidating
+// in the meantime coalesce onto it.  As the refresh has yet to start, their data
+// will be visible once it completes.
+func (c *RefreshAheadCache[T, TP]) debounce(ctx context.Context, request *invalidationRequest) {
+	period := c.options.InvalidateDebounce
+	if period <= 0 {
+		return
+	}
+
+	timer := time.NewTimer(period)
+	defer timer.Stop()
+
+	deadline := time.After(maxDebouncePeriods * period)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-deadline:
+			return
+		case <-timer.C:
+			return
+		case <-request.joined:
+			timer.Reset(period)
+		}
+	}
+}
+
+// refreshPeriod returns the period between background refreshes.
+func (c *RefreshAheadCache[T, TP]) refreshPeriod() time.Duration {
+	refreshPeriod := defaultRefreshPeriod
+
+	if c.options.RefreshPeriod != 0 {
+		refreshPeriod = c.options.RefreshPeriod
+	}
+
+	// If we have never loaded anything, then retry more aggressively.
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	if c.cache == nil {
+		return min(refreshPeriod, defaultRetryPeriod)
+	}
+
+	return refreshPeriod
+}
+
+// refreshInterval returns the time until the next background refresh, that
+// is the refresh period with any jitter applied.
+func (c *RefreshAheadCache[T, TP]) refreshInterval() time.Duration {
+	refreshPeriod := c.refreshPeriod()
+
+	jitter := min(c.options.RefreshJitter, refreshPeriod/2)
+	if jitter <= 0 {
+		return refreshPeriod
+	}
+
+	//nolint:gosec // jitter need not be cryptographically secure
+	return refreshPeriod - jitter + rand.N(2*jitter+1)
+}
+
+// Ready returns true once the cache has been successfully populated.
+func (c *RefreshAheadCache[T, TP]) Ready() bool {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	return c.cache != nil
+}
+
+// Readier is anything that can report its readiness.
+type Readier interface {
+	Ready() bool
+}
+
+// ReadinessHandler returns an HTTP handler that can be used as a readiness
+// probe e.g. on /readyz, so a service doesn't receive traffic until its cache
+// is warm.
+func ReadinessHandler(r Readier) http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		if !r.Ready() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+// LastError returns the error from the most recent refresh, or nil if it
+// succeeded.
+func (c *RefreshAheadCache[T, TP]) LastError() error {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	return c.lastError
+}
+
 // Invalidate performs a synchronous invalidation of the cache and only
 // returns control to the client when the refresh has completed, guaranteeing
 // on success that the cache will contain any new values.
 func (c *RefreshAheadCache[T, TP]) Invalidate() error {
+	if c.options.Observer != nil {
+		c.options.Observer.OnInvalidate()
+	}
+
 	c.pendingLock.Lock()
 
 	// Concurrent callers coalesce: if a refresh is already waiting, the caller will
@@ -466,6 +719,12 @@ func (c *RefreshAheadCache[T, TP]) Invalidate() error {
 		req := c.pending
 		c.pendingLock.Unlock()
 
+		// Let the refresher know there's still invalidation activity.
+		select {
+		case req.joined <- nil:
+		default:
+		}
+
 		<-req.done
 
 		return req.err
@@ -473,7 +732,8 @@ func (c *RefreshAheadCache[T, TP]) Invalidate() error {
 
 	// We are the designated sender for this round.
 	request := &invalidationRequest{
-		done: make(chan any),
+		done:   make(chan any),
+		joined: make(chan any, 1),
 	}
 
 	c.pending = request
@@ -491,6 +751,119 @@ func (c *RefreshAheadCache[T, TP]) Invalidate() error {
 	return request.err
 }
 
+// InvalidateItem performs a synchronous refresh of a single item and only
+// returns control to the client when the item is visible in the cache.  If
+// the item no longer exists it is removed from the cache and ErrNotFound is
+// returned.  The epoch is only updated if the visible item actually changes.
+// Unlike Invalidate, item refreshes are performed by the caller so concurrent
+// refreshes of different items do not block one another.
+func (c *RefreshAheadCache[T, TP]) InvalidateItem(ctx context.Context, index string) error {
+	if c.itemRefresh == nil {
+		return fmt.Errorf("%w: item refresh function not defined", errors.ErrUnsupported)
+	}
+
+	c.lock.RLock()
+	valid := c.cache != nil
+	c.lock.RUnlock()
+
+	if !valid {
+		return ErrInvalid
+	}
+
+	item, err := c.itemRefresh(ctx, index)
+	if err != nil {
+		if !errors.Is(err, ErrNotFound) {
+			return err
+		}
+
+		item = nil
+	}
+
+	defer c.notify()
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	current, ok := c.cache[index]
+
+	// Like any other local write, changes must survive any full refresh that
+	// is already in flight, so are recorded in the overlay.  Nothing is written
+	// if the item is unchanged, preserving the epoch so any memoized client
+	// data remains valid.
+	if item == nil {
+		if ok {
+			c.writeOverlayLocked(index, nil)
+		}
+
+		return fmt.Errorf("%w: requested index %s", ErrNotFound, index)
+	}
+
+	if !ok || !item.Equal(current) {
+		c.writeOverlayLocked(index, item)
+	}
+
+	return nil
+}
+
+// Subscribe returns a channel that receives the new epoch whenever the visible
+// cache data changes, and a function to unsubscribe, which closes the channel.
+// Notifications are coalesced, so a slow subscriber only sees the latest epoch
+// and can never stall the cache.
+func (c *RefreshAheadCache[T, TP]) Subscribe() (<-chan Epoch, func()) {
+	ch := make(chan Epoch, 1)
+
+	c.subscribersLock.Lock()
+	defer c.subscribersLock.Unlock()
+
+	if c.subscribers == nil {
+		c.subscribers = map[chan Epoch]struct{}{}
+	}
+
+	c.subscribers[ch] = struct{}{}
+
+	var once sync.Once
+
+	unsubscribe := func() {
+		once.Do(func() {
+			c.subscribersLock.Lock()
+			defer c.subscribersLock.Unlock()
+
+			delete(c.subscribers, ch)
+			close(ch)
+		})
+	}
+
+	return ch, unsubscribe
+}
+
+// notify sends the current epoch to all subscribers if it has changed since
+// they were last notified.  This must be called without the cache lock held.
+func (c *RefreshAheadCache[T, TP]) notify() {
+	c.lock.RLock()
+	epoch := c.epoch
+	c.lock.RUnlock()
+
+	c.subscribersLock.Lock()
+	defer c.subscribersLock.Unlock()
+
+	if !epoch.after(c.notified) {
+		return
+	}
+
+	c.notified = epoch
+
+	for ch := range c.subscribers {
+		// Replace any unread epoch with the latest one.  As we are the only
+		// sender, the subsequent send can never block.
+		select {
+		case <-ch:
+		default:
+		}
+
+		ch <- epoch
+	}
+}
+
 // sendInvalidation sends request to the refresh goroutine.  If the channel
 // has been closed (cache shutdown) the resulting panic is recovered, pending
 // is cleared, and any goroutines already waiting on request.done are
@@ -516,6 +889,21 @@ func (c *RefreshAheadCache[T, TP]) sendInvalidation(request *invalidationRequest
 	return nil
 }
 
+// valuesLocked iterates over the effective cache view in list order.
+func (c *RefreshAheadCache[T, TP]) valuesLocked() iter.Seq[TP] {
+	if !c.options.PreserveOrder {
+		return maps.Values(c.cache)
+	}
+
+	return func(yield func(TP) bool) {
+		for _, index := range c.order {
+			if !yield(c.cache[index]) {
+				return
+			}
+		}
+	}
+}
+
 // Get does a zero copy read of a specified item.
 func (c *RefreshAheadCache[T, TP]) Get(index string) (*GetSnapshot[T], error) {
 	c.lock.RLock()
@@ -551,7 +939,7 @@ func (c *RefreshAheadCache[T, TP]) List() (*ListSnapshot[T], error) {
 
 	i := 0
 
-	for item := range maps.Values(c.cache) {
+	for item := range c.valuesLocked() {
 		items[i] = item
 		i++
 	}
@@ -564,8 +952,95 @@ func (c *RefreshAheadCache[T, TP]) List() (*ListSnapshot[T], error) {
 	return result, nil
 }
 
-// doRefresh does a refresh of all cache data.
+// ListSorted does a zero copy read of all items, ordered by the comparison
+// function, for when callers need a deterministic order.  Only the returned
+// list of pointers is sorted, the cache itself is unaffected.
+func (c *RefreshAheadCache[T, TP]) ListSorted(less func(a, b *T) bool) (*ListSnapshot[T], error) {
+	result, err := c.List()
+	if err != nil {
+		return nil, err
+	}
+
+	// The list is private to the caller, so can be sorted without the lock.
+	slices.SortFunc(result.Items, func(a, b *T) int {
+		switch {
+		case less(a, b):
+			return -1
+		case less(b, a):
+			return 1
+		}
+
+		return 0
+	})
+
+	return result, nil
+}
+
+// ListFunc does a zero copy read of all items that match the predicate.
+// This avoids allocating space for every item when only a small subset are
+// required e.g. those in a specific organization.
+func (c *RefreshAheadCache[T, TP]) ListFunc(predicate func(*T) bool) (*ListSnapshot[T], error) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	if c.cache == nil {
+		return nil, ErrInvalid
+	}
+
+	var items []*T
+
+	for item := range c.valuesLocked() {
+		if predicate(item) {
+			items = append(items, item)
+		}
+	}
+
+	result := &ListSnapshot[T]{
+		Epoch: c.epoch,
+		Items: items,
+	}
+
+	return result, nil
+}
+
+// doRefresh does a refresh of all cache data, notifying any observer.
 func (c *RefreshAheadCache[T, TP]) doRefresh(ctx context.Context) error {
+	observer := c.options.Observer
+
+	if observer != nil {
+		observer.OnRefreshStart()
+	}
+
+	start := time.Now()
+
+	changed, err := c.refreshData(ctx)
+
+	duration := time.Since(start)
+
+	c.lock.Lock()
+	c.lastError = err
+	items := len(c.cache)
+	c.lock.Unlock()
+
+	if changed {
+		c.notify()
+	}
+
+	if observer != nil {
+		observer.OnRefreshComplete(&RefreshStats{
+			Duration: duration,
+			Items:    items,
+			Changed:  changed,
+			Err:      err,
+		})
+	}
+
+	return err
+}
+
+// refreshData does a refresh of all cache data, returning whether the
+// visible cache data changed.
+func (c *RefreshAheadCache[T, TP]) refreshData(ctx context.Context) (bool, error) {
 	// Ensure the refresh routine cannot ever crash.
 	defer func() {
 		if x := recover(); x != nil {
@@ -581,19 +1056,29 @@ func (c *RefreshAheadCache[T, TP]) doRefresh(ctx context.Context) error {
 	// Collect the refreshed data.
 	data, err := c.refresh(ctx)
 	if err != nil {
-		return err
+		return false, err
 	}
 
 	cache := make(cacheMap[T, TP], len(data))
 
+	var order []string
+
+	if c.options.PreserveOrder {
+		order = make([]string, len(data))
+	}
+
 	for i := range data {
 		index := data[i].Index()
 
 		if _, ok := cache[index]; ok {
-			return fmt.Errorf("%w: offending key %s", ErrConflict, index)
+			return false, fmt.Errorf("%w: offending key %s", ErrConflict, index)
 		}
 
 		cache[index] = data[i]
+
+		if order != nil {
+			order[i] = index
+		}
 	}
 
 	c.lock.Lock()
@@ -601,7 +1086,18 @@ func (c *RefreshAheadCache[T, TP]) doRefresh(ctx context.Context) error {
 
 	effective := c.mergeAndPruneOverlayLocked(cache, refreshEpoch)
 
-	if effective.Equal(c.cache) {
+	// When the order is preserved, a reordering of the same data is also a
+	// visible change.
+	var reordered bool
+
+	if c.options.PreserveOrder {
+		order = c.mergeOrderLocked(order, cache, effective)
+		reordered = !slices.Equal(order, c.order)
+
+		c.order = order
+	}
+
+	if !reordered && effective.Equal(c.cache) {
 		// Epochs represent the identity of the visible cache snapshot, not the
 		// provenance of how it was assembled. If a refresh catches up to the
 		// current effective view exactly, then callers are still looking at the
@@ -611,7 +1107,7 @@ func (c *RefreshAheadCache[T, TP]) doRefresh(ctx context.Context) error {
 		// snapshot identity is unchanged.
 		c.cache = effective
 
-		return nil
+		return false, nil
 	}
 
 	// We only reach this branch when the visible effective view has changed.
@@ -629,5 +1125,5 @@ func (c *RefreshAheadCache[T, TP]) doRefresh(ctx context.Context) error {
 
 	c.cache = effective
 
-	return nil
+	return true, nil
 }
@@ -29,6 +29,9 @@ package cache_test
 
 import (
 	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
 	"slices"
 	"strconv"
 	"sync"
@@ -41,6 +44,8 @@ import (
 	"github.com/unikorn-cloud/core/pkg/util/cache"
 )
 
+var errRefresh = errors.New("refresh failed")
+
 // myType is a fake struct.  Irrespective of the size of this it should
 // only every be referred to by reference, so should make zero difference
 // in performance.
@@ -240,6 +245,140 @@ func TestListImmutability(t *testing.T) {
 	}
 }
 
+// TestListFunc ensures only matching items are returned, and the epoch is
+// that of the cache.
+func TestListFunc(t *testing.T) {
+	t.Parallel()
+
+	generator := staticGenerator{size: 1024}
+
+	options := defaultOptions()
+
+	c := cache.NewRefreshAheadCache[myType](generator.refresh, options)
+	require.NoError(t, c.Run(t.Context()))
+
+	snapshot1, err := c.List()
+	require.NoError(t, err)
+
+	snapshot2, err := c.ListFunc(func(item *myType) bool {
+		return item.id%4 == 0
+	})
+	require.NoError(t, err)
+	require.Len(t, snapshot2.Items, 256)
+	require.True(t, snapshot1.Epoch.Valid(snapshot2.Epoch))
+
+	for _, item := range snapshot2.Items {
+		require.Zero(t, item.id%4)
+	}
+}
+
+// TestListSorted tests items are sorted, and items are shared with the cache.
+func TestListSorted(t *testing.T) {
+	t.Parallel()
+
+	generator := staticGenerator{size: 1024}
+
+	options := defaultOptions()
+
+	c := cache.NewRefreshAheadCache[myType](generator.refresh, options)
+	require.NoError(t, c.Run(t.Context()))
+
+	snapshot1, err := c.List()
+	require.NoError(t, err)
+
+	snapshot2, err := c.ListSorted(func(a, b *myType) bool {
+		return a.id > b.id
+	})
+	require.NoError(t, err)
+	require.Len(t, snapshot2.Items, 1024)
+	require.True(t, snapshot1.Epoch.Valid(snapshot2.Epoch))
+
+	for i, item := range snapshot2.Items {
+		require.Equal(t, 1023-i, item.id)
+
+		cached, err := c.Get(strconv.Itoa(item.id))
+		require.NoError(t, err)
+		require.Same(t, cached.Item, item)
+	}
+}
+
+// TestPreserveOrder tests that List returns items in the order they were
+// returned by the refresh, followed by local inserts in write order, and that a
+// reordering is a visible change.
+func TestPreserveOrder(t *testing.T) {
+	t.Parallel()
+
+	ids := []int{5, 3, 9, 1, 7, 0, 8, 2, 6, 4}
+
+	var reversed atomic.Bool
+
+	refresh := func(_ context.Context) ([]*myType, error) {
+		order := slices.Clone(ids)
+
+		if reversed.Load() {
+			slices.Reverse(order)
+		}
+
+		items := make([]*myType, len(order))
+
+		for i, id := range order {
+			items[i] = &myType{
+				id: id,
+			}
+		}
+
+		return items, nil
+	}
+
+	listIDs := func(snapshot *cache.ListSnapshot[myType]) []int {
+		out := make([]int, len(snapshot.Items))
+
+		for i, item := range snapshot.Items {
+			out[i] = item.id
+		}
+
+		return out
+	}
+
+	options := &cache.RefreshAheadCacheOptions{
+		RefreshPeriod: time.Minute,
+		PreserveOrder: true,
+	}
+
+	c := cache.NewRefreshAheadCache[myType](refresh, options)
+	require.NoError(t, c.Run(t.Context()))
+
+	snapshot1, err := c.List()
+	require.NoError(t, err)
+	require.Equal(t, ids, listIDs(snapshot1))
+
+	require.NoError(t, c.Upsert(&myType{id: 20}))
+	require.NoError(t, c.Upsert(&myType{id: 10}))
+	require.NoError(t, c.Upsert(&myType{id: 5}))
+
+	snapshot2, err := c.List()
+	require.NoError(t, err)
+	require.Equal(t, append(slices.Clone(ids), 20, 10), listIDs(snapshot2))
+
+	filtered, err := c.ListFunc(func(item *myType) bool {
+		return item.id%2 == 1
+	})
+	require.NoError(t, err)
+	require.Equal(t, []int{5, 3, 9, 1, 7}, listIDs(filtered))
+
+	reversed.Store(true)
+
+	require.NoError(t, c.Invalidate())
+
+	expected := slices.Clone(ids)
+	slices.Reverse(expected)
+
+	snapshot3, err := c.List()
+	require.NoError(t, err)
+	require.Equal(t, expected, listIDs(snapshot3))
+	require.False(t, snapshot2.Epoch.Valid(snapshot3.Epoch))
+}
+
 // TestInvalidation tests that a client can invalidate the cache and that
 // the client is blocked until completion.
 func TestInvalidation(t *testing.T) {
@@ -893,6 +1032,547 @@ func TestRefreshWithSurvivingOverlayKeepsEpochWhenVisibleViewIsUnchanged(t *test
 	require.True(t, after.Epoch.Valid(before.Epoch))
 }
 
+// itemRefresh returns a single item from the generator.
+func (g *overlayGenerator) itemRefresh(_ context.Context, index string) (*overlayType, error) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	for _, item := range g.items {
+		if item.id == index {
+			return item, nil
+		}
+	}
+
+	return nil, cache.ErrNotFound
+}
+
+// TestInvalidateItem tests a single item can be added, updated and removed
+// without a full refresh, and that the epoch only changes when the item does.
+func TestInvalidateItem(t *testing.T) {
+	t.Parallel()
+
+	var refreshes atomic.Int32
+
+	generator := &overlayGenerator{}
+	generator.set(&overlayType{id: "a", status: "ok"})
+
+	refresh := func(ctx context.Context) ([]*overlayType, error) {
+		refreshes.Add(1)
+
+		return generator.refresh(ctx)
+	}
+
+	options := &cache.RefreshAheadCacheOptions{
+		RefreshPeriod: time.Minute,
+	}
+
+	c := cache.NewRefreshAheadCache[overlayType](refresh, options).WithItemRefresh(generator.itemRefresh)
+	require.NoError(t, c.Run(t.Context()))
+
+	initial, err := c.List()
+	require.NoError(t, err)
+
+	// Unchanged items preserve the epoch.
+	require.NoError(t, c.InvalidateItem(t.Context(), "a"))
+
+	unchanged, err := c.List()
+	require.NoError(t, err)
+	require.True(t, initial.Epoch.Valid(unchanged.Epoch))
+
+	// New items are spliced in.
+	generator.set(&overlayType{id: "a", status: "ok"}, &overlayType{id: "b", status: "ok"})
+	require.NoError(t, c.InvalidateItem(t.Context(), "b"))
+
+	added, err := c.Get("b")
+	require.NoError(t, err)
+	require.Equal(t, "ok", added.Item.status)
+	require.False(t, initial.Epoch.Valid(added.Epoch))
+
+	// Updated items are replaced.
+	generator.set(&overlayType{id: "a", status: "ok"}, &overlayType{id: "b", status: "degraded"})
+	require.NoError(t, c.InvalidateItem(t.Context(), "b"))
+
+	updated, err := c.Get("b")
+	require.NoError(t, err)
+	require.Equal(t, "degraded", updated.Item.status)
+	require.False(t, added.Epoch.Valid(updated.Epoch))
+
+	// Deleted items are removed.
+	generator.set(&overlayType{id: "a", status: "ok"})
+	require.ErrorIs(t, c.InvalidateItem(t.Context(), "b"), cache.ErrNotFound)
+
+	_, err = c.Get("b")
+	require.ErrorIs(t, err, cache.ErrNotFound)
+
+	list, err := c.List()
+	require.NoError(t, err)
+	require.Len(t, list.Items, 1)
+
+	// Only the initial refresh should have been required.
+	require.Equal(t, int32(1), refreshes.Load())
+}
+
+// TestInvalidateItemConcurrent tests item refreshes for different indexes
+// don't block one another.
+func TestInvalidateItemConcurrent(t *testing.T) {
+	t.Parallel()
+
+	generator := &overlayGenerator{}
+	generator.set(&overlayType{id: "a", status: "ok"}, &overlayType{id: "b", status: "ok"})
+
+	started := make(chan struct{})
+	proceed := make(chan struct{})
+
+	itemRefresh := func(ctx context.Context, index string) (*overlayType, error) {
+		if index == "a" {
+			close(started)
+			<-proceed
+		}
+
+		return generator.itemRefresh(ctx, index)
+	}
+
+	options := &cache.RefreshAheadCacheOptions{
+		RefreshPeriod: time.Minute,
+	}
+
+	c := cache.NewRefreshAheadCache[overlayType](generator.refresh, options).WithItemRefresh(itemRefresh)
+	require.NoError(t, c.Run(t.Context()))
+
+	done := make(chan error, 1)
+
+	go func() {
+		done <- c.InvalidateItem(t.Context(), "a")
+	}()
+
+	<-started
+
+	require.NoError(t, c.InvalidateItem(t.Context(), "b"))
+
+	close(proceed)
+	require.NoError(t, <-done)
+}
+
+// TestInvalidateItemUnsupported tests an error is returned when no item refresh
+// function is defined.
+func TestInvalidateItemUnsupported(t *testing.T) {
+	t.Parallel()
+
+	generator := &overlayGenerator{}
+
+	c := cache.NewRefreshAheadCache[overlayType](generator.refresh, defaultOptions())
+	require.NoError(t, c.Run(t.Context()))
+
+	require.ErrorIs(t, c.InvalidateItem(t.Context(), "a"), errors.ErrUnsupported)
+}
+
+// failingGenerator wraps a generator with controllable failures.
+type failingGenerator struct {
+	overlayGenerator
+
+	fail atomic.Bool
+}
+
+func (g *failingGenerator) refresh(ctx context.Context) ([]*overlayType, error) {
+	if g.fail.Load() {
+		return nil, errRefresh
+	}
+
+	return g.overlayGenerator.refresh(ctx)
+}
+
+// TestStaleWhileErrorNeverLoaded tests the cache starts, and reports an error
+// when the initial refresh fails, then recovers.
+func TestStaleWhileErrorNeverLoaded(t *testing.T) {
+	t.Parallel()
+
+	generator := &failingGenerator{}
+	generator.set(&overlayType{id: "a", status: "ok"})
+	generator.fail.Store(true)
+
+	options := &cache.RefreshAheadCacheOptions{
+		RefreshPeriod:   time.Minute,
+		StaleWhileError: true,
+	}
+
+	c := cache.NewRefreshAheadCache[overlayType](generator.refresh, options)
+	require.NoError(t, c.Run(t.Context()))
+	require.ErrorIs(t, c.LastError(), errRefresh)
+
+	_, err := c.List()
+	require.ErrorIs(t, err, cache.ErrInvalid)
+
+	generator.fail.Store(false)
+
+	require.NoError(t, c.Invalidate())
+	require.NoError(t, c.LastError())
+
+	snapshot, err := c.List()
+	require.NoError(t, err)
+	require.Len(t, snapshot.Items, 1)
+}
+
+// TestStaleWhileErrorLoaded tests the cache continues to serve old data once
+// refreshes start failing.
+func TestStaleWhileErrorLoaded(t *testing.T) {
+	t.Parallel()
+
+	generator := &failingGenerator{}
+	generator.set(&overlayType{id: "a", status: "ok"})
+
+	options := &cache.RefreshAheadCacheOptions{
+		RefreshPeriod:   time.Minute,
+		StaleWhileError: true,
+	}
+
+	c := cache.NewRefreshAheadCache[overlayType](generator.refresh, options)
+	require.NoError(t, c.Run(t.Context()))
+	require.NoError(t, c.LastError())
+
+	generator.set(&overlayType{id: "a", status: "degraded"})
+	generator.fail.Store(true)
+
+	require.ErrorIs(t, c.Invalidate(), errRefresh)
+	require.ErrorIs(t, c.LastError(), errRefresh)
+
+	item, err := c.Get("a")
+	require.NoError(t, err)
+	require.Equal(t, "ok", item.Item.status)
+}
+
+// TestRunErrorWithoutStaleWhileError tests the initial refresh error is fatal
+// by default.
+func TestRunErrorWithoutStaleWhileError(t *testing.T) {
+	t.Parallel()
+
+	generator := &failingGenerator{}
+	generator.fail.Store(true)
+
+	c := cache.NewRefreshAheadCache[overlayType](generator.refresh, defaultOptions())
+	require.ErrorIs(t, c.Run(t.Context()), errRefresh)
+}
+
+// recordingObserver records cache events.
+type recordingObserver struct {
+	lock        sync.Mutex
+	starts      int
+	invalidates int
+	stats       []cache.RefreshStats
+}
+
+func (o *recordingObserver) OnRefreshStart() {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	o.starts++
+}
+
+func (o *recordingObserver) OnRefreshComplete(stats *cache.RefreshStats) {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	o.stats = append(o.stats, *stats)
+}
+
+func (o *recordingObserver) OnInvalidate() {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	o.invalidates++
+}
+
+// TestObserver tests an observer is notified of refreshes and invalidations,
+// and whether they changed the cache contents.
+func TestObserver(t *testing.T) {
+	t.Parallel()
+
+	generator := &overlayGenerator{}
+	generator.set(&overlayType{id: "a", status: "ok"})
+
+	observer := &recordingObserver{}
+
+	options := &cache.RefreshAheadCacheOptions{
+		RefreshPeriod: time.Minute,
+		Observer:      observer,
+	}
+
+	c := cache.NewRefreshAheadCache[overlayType](generator.refresh, options)
+	require.NoError(t, c.Run(t.Context()))
+
+	// No change.
+	require.NoError(t, c.Invalidate())
+
+	// Change.
+	generator.set(&overlayType{id: "a", status: "degraded"})
+	require.NoError(t, c.Invalidate())
+
+	observer.lock.Lock()
+	defer observer.lock.Unlock()
+
+	require.Equal(t, 3, observer.starts)
+	require.Len(t, observer.stats, 3)
+	require.Equal(t, 2, observer.invalidates)
+
+	for i, changed := range []bool{true, false, true} {
+		require.Equal(t, changed, observer.stats[i].Changed)
+		require.NoError(t, observer.stats[i].Err)
+	}
+}
+
+// TestObserverStats tests an observer receives the duration, item count and
+// error of each refresh.
+func TestObserverStats(t *testing.T) {
+	t.Parallel()
+
+	generator := &failingGenerator{}
+	generator.set(&overlayType{id: "a", status: "ok"}, &overlayType{id: "b", status: "ok"})
+
+	observer := &recordingObserver{}
+
+	options := &cache.RefreshAheadCacheOptions{
+		RefreshPeriod: time.Minute,
+		Observer:      observer,
+	}
+
+	c := cache.NewRefreshAheadCache[overlayType](generator.refresh, options)
+	require.NoError(t, c.Run(t.Context()))
+
+	generator.fail.Store(true)
+
+	require.ErrorIs(t, c.Invalidate(), errRefresh)
+
+	observer.lock.Lock()
+	defer observer.lock.Unlock()
+
+	require.Len(t, observer.stats, 2)
+	require.Positive(t, observer.stats[0].Duration)
+	require.Equal(t, 2, observer.stats[0].Items)
+	require.True(t, observer.stats[0].Changed)
+	require.NoError(t, observer.stats[0].Err)
+	require.Equal(t, 2, observer.stats[1].Items)
+	require.False(t, observer.stats[1].Changed)
+	require.ErrorIs(t, observer.stats[1].Err, errRefresh)
+}
+
+// TestReadiness tests the cache only reports it is ready once populated.
+func TestReadiness(t *testing.T) {
+	t.Parallel()
+
+	generator := &failingGenerator{}
+	generator.set(&overlayType{id: "a", status: "ok"})
+	generator.fail.Store(true)
+
+	options := &cache.RefreshAheadCacheOptions{
+		RefreshPeriod:   time.Minute,
+		StaleWhileError: true,
+	}
+
+	c := cache.NewRefreshAheadCache[overlayType](generator.refresh, options)
+	require.False(t, c.Ready())
+
+	handler := cache.ReadinessHandler(c)
+
+	probe := func() int {
+		w := httptest.NewRecorder()
+		handler(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+		return w.Code
+	}
+
+	require.Equal(t, http.StatusServiceUnavailable, probe())
+
+	require.NoError(t, c.Run(t.Context()))
+	require.False(t, c.Ready())
+	require.Equal(t, http.StatusServiceUnavailable, probe())
+
+	generator.fail.Store(false)
+
+	require.NoError(t, c.Invalidate())
+	require.True(t, c.Ready())
+	require.Equal(t, http.StatusOK, probe())
+
+	// Stale data is still served, so remain ready.
+	generator.fail.Store(true)
+
+	require.ErrorIs(t, c.Invalidate(), errRefresh)
+	require.True(t, c.Ready())
+	require.Equal(t, http.StatusOK, probe())
+}
+
+// TestSubscribe tests subscribers are notified of epoch transitions only.
+func TestSubscribe(t *testing.T) {
+	t.Parallel()
+
+	generator := &overlayGenerator{}
+	generator.set(&overlayType{id: "a", status: "ok"})
+
+	options := &cache.RefreshAheadCacheOptions{
+		RefreshPeriod: time.Minute,
+	}
+
+	c := cache.NewRefreshAheadCache[overlayType](generator.refresh, options)
+
+	epochs, unsubscribe := c.Subscribe()
+
+	latest := func() cache.Epoch {
+		snapshot, err := c.List()
+		require.NoError(t, err)
+
+		return snapshot.Epoch
+	}
+
+	// Initial load.
+	require.NoError(t, c.Run(t.Context()))
+	require.Equal(t, latest(), <-epochs)
+
+	// No change, no notification.
+	require.NoError(t, c.Invalidate())
+	require.Empty(t, epochs)
+
+	// Local writes notify.
+	require.NoError(t, c.Upsert(&overlayType{id: "b", status: "ok"}))
+	require.Equal(t, latest(), <-epochs)
+
+	// Unread notifications are coalesced.
+	generator.set(&overlayType{id: "a", status: "degraded"})
+	require.NoError(t, c.Invalidate())
+	require.NoError(t, c.Upsert(&overlayType{id: "c", status: "ok"}))
+	require.Equal(t, latest(), <-epochs)
+	require.Empty(t, epochs)
+
+	unsubscribe()
+	unsubscribe()
+
+	_, ok := <-epochs
+	require.False(t, ok)
+
+	// Unsubscribed channels are no longer notified.
+	require.NoError(t, c.Upsert(&overlayType{id: "d", status: "ok"}))
+}
+
+// TestInvalidateDebounce tests a burst of invalidations results in fewer
+// refreshes, and that every caller sees their data.
+func TestInvalidateDebounce(t *testing.T) {
+	t.Parallel()
+
+	generator := &overlayGenerator{}
+
+	observer := &recordingObserver{}
+
+	options := &cache.RefreshAheadCacheOptions{
+		RefreshPeriod:      time.Minute,
+		InvalidateDebounce: 50 * time.Millisecond,
+		Observer:           observer,
+	}
+
+	c := cache.NewRefreshAheadCache[overlayType](generator.refresh, options)
+	require.NoError(t, c.Run(t.Context()))
+
+	const n = 10
+
+	results := make([]error, n)
+
+	var wg sync.WaitGroup
+
+	for i := range n {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			// Stagger the invalidations, so they would each get their
+			// own refresh were it not for debouncing.
+			time.Sleep(time.Duration(i) * 5 * time.Millisecond)
+
+			id := strconv.Itoa(i)
+
+			generator.lock.Lock()
+			generator.items = append(generator.items, &overlayType{id: id, status: "ok"})
+			generator.lock.Unlock()
+
+			if err := c.Invalidate(); err != nil {
+				results[i] = err
+				return
+			}
+
+			_, results[i] = c.Get(id)
+		}()
+	}
+
+	wg.Wait()
+
+	for _, err := range results {
+		require.NoError(t, err)
+	}
+
+	observer.lock.Lock()
+	defer observer.lock.Unlock()
+
+	require.Equal(t, n, observer.invalidates)
+	require.Less(t, observer.starts-1, n/2)
+}
+
+// timingObserver records when refreshes start.
+type timingObserver struct {
+	starts chan time.Time
+}
+
+func (o *timingObserver) OnRefreshStart() {
+	o.starts <- time.Now()
+}
+
+func (o *timingObserver) OnRefreshComplete(*cache.RefreshStats) {
+}
+
+func (o *timingObserver) OnInvalidate() {
+}
+
+// TestRefreshJitter tests background refreshes happen within the jittered
+// refresh period.
+func TestRefreshJitter(t *testing.T) {
+	t.Parallel()
+
+	const (
+		period = 100 * time.Millisecond
+		jitter = 40 * time.Millisecond
+		// slack allows for scheduling latency.
+		slack = 50 * time.Millisecond
+		// cycles is the number of intervals to measure.
+		cycles = 5
+	)
+
+	generator := &overlayGenerator{}
+	generator.set(&overlayType{id: "a", status: "ok"})
+
+	observer := &timingObserver{
+		starts: make(chan time.Time, cycles+1),
+	}
+
+	options := &cache.RefreshAheadCacheOptions{
+		RefreshPeriod: period,
+		RefreshJitter: jitter,
+		Observer:      observer,
+	}
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	c := cache.NewRefreshAheadCache[overlayType](generator.refresh, options)
+	require.NoError(t, c.Run(ctx))
+
+	previous := <-observer.starts
+
+	for range cycles {
+		start := <-observer.starts
+
+		interval := start.Sub(previous)
+		require.GreaterOrEqual(t, interval, period-jitter)
+		require.LessOrEqual(t, interval, period+jitter+slack)
+
+		previous = start
+	}
+}
+
 // BenchmarkRefreshAheadCacheGet tests single item retrieival performance.
 // Expect ~150ns.
 func BenchmarkRefreshAheadCacheGet(b *testing.B) {
@@ -955,6 +1635,27 @@ func BenchmarkRefreshAheadCacheList(b *testing.B) {
 	}
 }
 
+// BenchmarkRefreshAheadCacheListFunc tests selective item retrieval performance.
+func BenchmarkRefreshAheadCacheListFunc(b *testing.B) {
+	b.StopTimer()
+
+	generator := incrementingGenerator{size: 1024}
+
+	options := defaultOptions()
+
+	c := cache.NewRefreshAheadCache[myType](generator.refresh, options)
+	require.NoError(b, c.Run(b.Context()))
+
+	b.StartTimer()
+
+	for range b.N {
+		_, err := c.ListFunc(func(item *myType) bool {
+			return item.id%64 == 0
+		})
+		require.NoError(b, err)
+	}
+}
+
 // BenchmarkRefreshAheadCacheListConcurrent testes all item retrieval performance
 // with concurrency. Expect ~11000ns.
 func BenchmarkRefreshAheadCacheListConcurrent(b *testing.B) {
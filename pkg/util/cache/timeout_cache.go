@@ -18,14 +18,22 @@ limitations under the License.
 package cache
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"sync"
 	"time"
+
+	"golang.org/x/sync/singleflight"
 )
 
 type clock interface {
 	Now() time.Time
 }
 
+// LoadFunc loads a value on a cache miss.
+type LoadFunc[V any] func(ctx context.Context) (V, error)
+
 // TimeoutCache provides a cache with timeout.
 type TimeoutCache[V any] struct {
 	value   V
@@ -34,6 +42,11 @@ type TimeoutCache[V any] struct {
 
 	invalid time.Time
 	rwlock  sync.RWMutex
+
+	// loader is optionally used to populate the cache on a miss.
+	loader LoadFunc[V]
+	// group deduplicates concurrent loads.
+	group singleflight.Group
 }
 
 // New gets a new cache.
@@ -44,6 +57,15 @@ func New[V any](refresh time.Duration) *TimeoutCache[V] {
 	}
 }
 
+// NewWithLoader gets a new cache that is populated on demand by GetOrLoad.
+func NewWithLoader[V any](refresh time.Duration, loader LoadFunc[V]) *TimeoutCache[V] {
+	return &TimeoutCache[V]{
+		refresh: refresh,
+		clock:   wallclock{},
+		loader:  loader,
+	}
+}
+
 type wallclock struct{}
 
 func (wallclock) Now() time.Time {
@@ -73,6 +95,21 @@ func (m *TimeoutCache[V]) Get() (value V, ok bool) {
 	return m.value, true
 }
 
+// TimeLeft returns the duration until the cached value times out, and whether
+// a value is present.  This allows a value to be proactively refreshed before it
+// times out.
+func (m *TimeoutCache[V]) TimeLeft() (time.Duration, bool) {
+	m.rwlock.RLock()
+	defer m.rwlock.RUnlock()
+
+	left := m.invalid.Sub(m.clock.Now())
+	if left <= 0 {
+		return 0, false
+	}
+
+	return left, true
+}
+
 // Set remembers the value and resets the invalid time based
 // on when the cache was set.
 func (m *TimeoutCache[V]) Set(value V) {
@@ -85,3 +122,44 @@ func (m *TimeoutCache[V]) Set(value V) {
 func (m *TimeoutCache[V]) Invalidate() {
 	m.invalid = time.Time{}
 }
+
+// GetOrLoad returns the cached value if set and it hasn't timed out,
+// otherwise it calls the loader and caches the result.  Concurrent callers
+// share a single load, which is performed with the first caller's context.
+// Errors are returned to all waiting callers and are not cached.
+func (m *TimeoutCache[V]) GetOrLoad(ctx context.Context) (V, error) {
+	if value, ok := m.Get(); ok {
+		return value, nil
+	}
+
+	if m.loader == nil {
+		var zero V
+
+		return zero, fmt.Errorf("%w: loader not defined", errors.ErrUnsupported)
+	}
+
+	result, err, _ := m.group.Do("", func() (any, error) {
+		// The value may have been loaded while we were waiting.
+		if value, ok := m.Get(); ok {
+			return value, nil
+		}
+
+		value, err := m.loader(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		m.Set(value)
+
+		return value, nil
+	})
+	if err != nil {
+		var zero V
+
+		return zero, err
+	}
+
+	value, _ := result.(V)
+
+	return value, nil
+}
@@ -18,6 +18,10 @@ limitations under the License.
 package cache_test
 
 import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -107,3 +111,150 @@ func TestTimeoutCache_SetResetsTimeout(t *testing.T) {
 	timeoutCache.Set(expected)
 	testPresent(t, timeoutCache, expected)
 }
+
+func TestTimeoutCache_TimeLeft(t *testing.T) {
+	t.Parallel()
+
+	c := newStaticClock()
+
+	timeoutCache := cache.NewWithClock[int](time.Hour, c)
+
+	_, ok := timeoutCache.TimeLeft()
+	require.False(t, ok)
+
+	timeoutCache.Set(1)
+
+	left, ok := timeoutCache.TimeLeft()
+	require.True(t, ok)
+	require.Equal(t, time.Hour, left)
+
+	c.advance(45 * time.Minute)
+
+	left, ok = timeoutCache.TimeLeft()
+	require.True(t, ok)
+	require.Equal(t, 15*time.Minute, left)
+
+	c.advance(15 * time.Minute)
+
+	_, ok = timeoutCache.TimeLeft()
+	require.False(t, ok)
+
+	timeoutCache.Set(1)
+	timeoutCache.Invalidate()
+
+	_, ok = timeoutCache.TimeLeft()
+	require.False(t, ok)
+}
+
+func TestTimeoutCache_GetOrLoad(t *testing.T) {
+	t.Parallel()
+
+	var loads atomic.Int32
+
+	loader := func(_ context.Context) (int, error) {
+		return int(loads.Add(1)), nil
+	}
+
+	timeoutCache := cache.NewWithLoader[int](time.Hour, loader)
+	testAbsent(t, timeoutCache)
+
+	value, err := timeoutCache.GetOrLoad(t.Context())
+	require.NoError(t, err)
+	require.Equal(t, 1, value)
+	testPresent(t, timeoutCache, 1)
+
+	// Hits don't call the loader.
+	value, err = timeoutCache.GetOrLoad(t.Context())
+	require.NoError(t, err)
+	require.Equal(t, 1, value)
+
+	// Invalidation causes a reload.
+	timeoutCache.Invalidate()
+
+	value, err = timeoutCache.GetOrLoad(t.Context())
+	require.NoError(t, err)
+	require.Equal(t, 2, value)
+}
+
+func TestTimeoutCache_GetOrLoadConcurrent(t *testing.T) {
+	t.Parallel()
+
+	var loads atomic.Int32
+
+	proceed := make(chan struct{})
+
+	loader := func(_ context.Context) (int, error) {
+		<-proceed
+
+		return int(loads.Add(1)), nil
+	}
+
+	timeoutCache := cache.NewWithLoader[int](time.Hour, loader)
+
+	const n = 10
+
+	values := make([]int, n)
+	errs := make([]error, n)
+
+	var wg sync.WaitGroup
+
+	for i := range n {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			values[i], errs[i] = timeoutCache.GetOrLoad(t.Context())
+		}()
+	}
+
+	// Give everyone a chance to block on the load.
+	time.Sleep(10 * time.Millisecond)
+	close(proceed)
+
+	wg.Wait()
+
+	for i := range n {
+		require.NoError(t, errs[i])
+		require.Equal(t, 1, values[i])
+	}
+
+	require.Equal(t, int32(1), loads.Load())
+}
+
+func TestTimeoutCache_GetOrLoadError(t *testing.T) {
+	t.Parallel()
+
+	errLoad := errors.New("load failed")
+
+	fail := true
+
+	loader := func(_ context.Context) (int, error) {
+		if fail {
+			return 0, errLoad
+		}
+
+		return 42, nil
+	}
+
+	timeoutCache := cache.NewWithLoader[int](time.Hour, loader)
+
+	_, err := timeoutCache.GetOrLoad(t.Context())
+	require.ErrorIs(t, err, errLoad)
+	testAbsent(t, timeoutCache)
+
+	fail = false
+
+	value, err := timeoutCache.GetOrLoad(t.Context())
+	require.NoError(t, err)
+	require.Equal(t, 42, value)
+}
+
+func TestTimeoutCache_GetOrLoadNoLoader(t *testing.T) {
+	t.Parallel()
+
+	timeoutCache := cache.New[int](time.Hour)
+
+	_, err := timeoutCache.GetOrLoad(t.Context())
+	require.ErrorIs(t, err, errors.ErrUnsupported)
+}
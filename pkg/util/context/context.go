@@ -0,0 +1,94 @@
+/*
+Copyright 2026 Nscale.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package context provides helpers for running background work spawned by
+// request handlers, and for work that must outlive the request.
+package context
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var (
+	// ErrPanic is used to report background work panics.
+	ErrPanic = errors.New("background work panic")
+)
+
+type compensationTimeoutKeyType int
+
+const (
+	compensationTimeoutKey compensationTimeoutKeyType = iota
+)
+
+// WithCompensationTimeout sets the time compensating work, e.g. a saga's
+// compensations, is allowed to run for once the parent has been cancelled.
+// This is typically set by the request timeout middleware, so that
+// compensations get the same budget as the request did.
+func WithCompensationTimeout(ctx context.Context, timeout time.Duration) context.Context {
+	return context.WithValue(ctx, compensationTimeoutKey, timeout)
+}
+
+// CompensationTimeout returns the time compensating work is allowed to run
+// for, if set.
+func CompensationTimeout(ctx context.Context) (time.Duration, bool) {
+	timeout, ok := ctx.Value(compensationTimeoutKey).(time.Duration)
+
+	return timeout, ok
+}
+
+// Detach returns a context that preserves all values of the parent e.g. the
+// trace context, logger and principal, but is not cancelled when the parent is,
+// and has no deadline.  This must be used for background work spawned by a
+// request handler, as the request context is cancelled when the request ends.
+func Detach(ctx context.Context) context.Context {
+	return context.WithoutCancel(ctx)
+}
+
+// Go runs the function in the background with a detached context.  The work is
+// traced as a child of the span in the parent context, so it can be correlated
+// with the request that spawned it.  Any panic is recovered and logged rather
+// than crashing the process.
+func Go(ctx context.Context, fn func(ctx context.Context)) {
+	ctx = Detach(ctx)
+
+	go func() {
+		tracer := otel.GetTracerProvider().Tracer("background")
+
+		ctx, span := tracer.Start(ctx, "background work")
+		defer span.End()
+
+		defer func() {
+			if x := recover(); x != nil {
+				err := fmt.Errorf("%w: %v", ErrPanic, x)
+
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+
+				log.FromContext(ctx).Error(err, "caught unhandled exception")
+			}
+		}()
+
+		fn(ctx)
+	}()
+}
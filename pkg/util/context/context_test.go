@@ -0,0 +1,116 @@
+/*
+Copyright 2026 Nscale.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package context_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+
+	utilcontext "github.com/unikorn-cloud/core/pkg/util/context"
+)
+
+type key int
+
+const (
+	valueKey key = iota
+)
+
+// traceContext returns a context with a valid remote span.
+func traceContext(ctx context.Context) context.Context {
+	spanContext := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x01},
+		SpanID:     trace.SpanID{0x01},
+		TraceFlags: trace.FlagsSampled,
+	})
+
+	return trace.ContextWithRemoteSpanContext(ctx, spanContext)
+}
+
+// TestDetach tests a detached context keeps values, but survives cancellation
+// of, and has no deadline from, the parent.
+func TestDetach(t *testing.T) {
+	t.Parallel()
+
+	parent, cancel := context.WithTimeout(context.WithValue(traceContext(t.Context()), valueKey, "foo"), time.Minute)
+
+	ctx := utilcontext.Detach(parent)
+
+	cancel()
+
+	require.ErrorIs(t, parent.Err(), context.Canceled)
+	require.NoError(t, ctx.Err())
+
+	_, ok := ctx.Deadline()
+	require.False(t, ok)
+
+	require.Equal(t, "foo", ctx.Value(valueKey))
+	require.Equal(t, trace.SpanContextFromContext(parent).TraceID(), trace.SpanContextFromContext(ctx).TraceID())
+}
+
+// TestCompensationTimeout tests the compensation timeout is only reported
+// when set.
+func TestCompensationTimeout(t *testing.T) {
+	t.Parallel()
+
+	_, ok := utilcontext.CompensationTimeout(t.Context())
+	require.False(t, ok)
+
+	timeout, ok := utilcontext.CompensationTimeout(utilcontext.WithCompensationTimeout(t.Context(), time.Minute))
+	require.True(t, ok)
+	require.Equal(t, time.Minute, timeout)
+}
+
+// TestGo tests background work runs after the parent is cancelled, with the
+// parent's values.
+func TestGo(t *testing.T) {
+	t.Parallel()
+
+	parent, cancel := context.WithCancel(context.WithValue(t.Context(), valueKey, "foo"))
+	cancel()
+
+	result := make(chan any, 1)
+
+	utilcontext.Go(parent, func(ctx context.Context) {
+		if ctx.Err() != nil {
+			result <- ctx.Err()
+			return
+		}
+
+		result <- ctx.Value(valueKey)
+	})
+
+	require.Equal(t, "foo", <-result)
+}
+
+// TestGoPanic tests background work panics are recovered.
+func TestGoPanic(t *testing.T) {
+	t.Parallel()
+
+	done := make(chan struct{})
+
+	utilcontext.Go(t.Context(), func(ctx context.Context) {
+		defer close(done)
+
+		panic("boom")
+	})
+
+	<-done
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2026 Nscale.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package featureflag provides a consistent way to gate behaviour behind
+// feature flags in handlers and controllers.
+package featureflag
+
+import (
+	"context"
+)
+
+// Flag is the name of a feature flag.
+type Flag string
+
+// Evaluator decides whether a feature flag is enabled.  Implementations
+// may be backed by static configuration, or by a remote flag service.
+type Evaluator interface {
+	// Enabled returns whether the flag is enabled for the subject.  The
+	// subject is an opaque identifier chosen by the caller e.g. an
+	// organization ID, and may be empty when there is no subject.
+	Enabled(ctx context.Context, flag Flag, subject string) bool
+}
+
+// disabled is the default evaluator when none is configured.
+type disabled struct{}
+
+func (disabled) Enabled(context.Context, Flag, string) bool {
+	return false
+}
+
+type key int
+
+//nolint:gochecknoglobals
+var evaluatorKey key
+
+// NewContext returns a new context with the evaluator attached.
+func NewContext(ctx context.Context, evaluator Evaluator) context.Context {
+	return context.WithValue(ctx, evaluatorKey, evaluator)
+}
+
+// FromContext returns the evaluator attached to the context.  If there is
+// none, then an evaluator that disables all flags is returned, so new
+// behaviour is never accidentally exposed.
+func FromContext(ctx context.Context) Evaluator {
+	if evaluator, ok := ctx.Value(evaluatorKey).(Evaluator); ok {
+		return evaluator
+	}
+
+	return disabled{}
+}
+
+// Enabled is a shorthand for evaluating a flag with the evaluator
+// attached to the context.
+func Enabled(ctx context.Context, flag Flag, subject string) bool {
+	return FromContext(ctx).Enabled(ctx, flag, subject)
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2026 Nscale.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package featureflag_test
+
+import (
+	"testing"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/require"
+
+	"github.com/unikorn-cloud/core/pkg/util/featureflag"
+)
+
+const (
+	flagFoo featureflag.Flag = "foo"
+	flagBar featureflag.Flag = "bar"
+)
+
+func mustNewStatic(t *testing.T, args ...string) *featureflag.Static {
+	t.Helper()
+
+	options := &featureflag.Options{}
+
+	flags := pflag.NewFlagSet("", pflag.ContinueOnError)
+	options.AddFlags(flags)
+
+	require.NoError(t, flags.Parse(args))
+
+	evaluator, err := featureflag.NewStatic(options)
+	require.NoError(t, err)
+
+	return evaluator
+}
+
+// TestStaticEnabled tests flags enabled globally are enabled for all subjects.
+func TestStaticEnabled(t *testing.T) {
+	t.Parallel()
+
+	evaluator := mustNewStatic(t, "--feature-flags=foo")
+
+	require.True(t, evaluator.Enabled(t.Context(), flagFoo, ""))
+	require.True(t, evaluator.Enabled(t.Context(), flagFoo, "org-1"))
+	require.False(t, evaluator.Enabled(t.Context(), flagBar, ""))
+	require.False(t, evaluator.Enabled(t.Context(), flagBar, "org-1"))
+}
+
+// TestStaticSubjects tests flags can be enabled for specific subjects.
+func TestStaticSubjects(t *testing.T) {
+	t.Parallel()
+
+	evaluator := mustNewStatic(t, "--feature-flag-subject=foo=org-1", "--feature-flag-subject=foo=org-2")
+
+	require.True(t, evaluator.Enabled(t.Context(), flagFoo, "org-1"))
+	require.True(t, evaluator.Enabled(t.Context(), flagFoo, "org-2"))
+	require.False(t, evaluator.Enabled(t.Context(), flagFoo, "org-3"))
+	require.False(t, evaluator.Enabled(t.Context(), flagFoo, ""))
+	require.False(t, evaluator.Enabled(t.Context(), flagBar, "org-1"))
+}
+
+// TestStaticSubjectFormat tests malformed subject flags are rejected.
+func TestStaticSubjectFormat(t *testing.T) {
+	t.Parallel()
+
+	for _, subject := range []string{"foo", "=org-1", "foo="} {
+		options := &featureflag.Options{
+			Subjects: []string{subject},
+		}
+
+		_, err := featureflag.NewStatic(options)
+		require.ErrorIs(t, err, featureflag.ErrFormat)
+	}
+}
+
+// TestContext tests evaluators are propagated via the context, and flags
+// are disabled by default.
+func TestContext(t *testing.T) {
+	t.Parallel()
+
+	require.False(t, featureflag.Enabled(t.Context(), flagFoo, "org-1"))
+
+	ctx := featureflag.NewContext(t.Context(), mustNewStatic(t, "--feature-flags=foo"))
+
+	require.True(t, featureflag.Enabled(ctx, flagFoo, "org-1"))
+	require.False(t, featureflag.Enabled(ctx, flagBar, "org-1"))
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2026 Nscale.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package featureflag
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/pflag"
+)
+
+var (
+	// ErrFormat is raised when a subject flag is not in the correct format.
+	ErrFormat = errors.New("format error")
+)
+
+// Options configures static feature flags.
+type Options struct {
+	// Enabled is a set of flags that are enabled for everyone.
+	Enabled []string
+	// Subjects is a set of flag=subject pairs that enable a flag for
+	// a specific subject only.
+	Subjects []string
+}
+
+// AddFlags registers feature flag options.
+func (o *Options) AddFlags(f *pflag.FlagSet) {
+	f.StringSliceVar(&o.Enabled, "feature-flags", nil, "Feature flags to enable for all subjects.")
+	f.StringArrayVar(&o.Subjects, "feature-flag-subject", nil, "Feature flag to enable for a specific subject, in the form flag=subject. May be specified more than once.")
+}
+
+// Static evaluates feature flags from static configuration.
+type Static struct {
+	// enabled flags are on for all subjects.
+	enabled map[Flag]bool
+	// subjects maps from flag to the subjects the flag is enabled for.
+	subjects map[Flag]map[string]bool
+}
+
+var _ Evaluator = &Static{}
+
+// NewStatic creates a new static evaluator.
+func NewStatic(options *Options) (*Static, error) {
+	s := &Static{
+		enabled:  map[Flag]bool{},
+		subjects: map[Flag]map[string]bool{},
+	}
+
+	for _, flag := range options.Enabled {
+		s.enabled[Flag(flag)] = true
+	}
+
+	for _, pair := range options.Subjects {
+		flag, subject, ok := strings.Cut(pair, "=")
+		if !ok || flag == "" || subject == "" {
+			return nil, fmt.Errorf("%w: feature flag subject %q must be in the form flag=subject", ErrFormat, pair)
+		}
+
+		if _, ok := s.subjects[Flag(flag)]; !ok {
+			s.subjects[Flag(flag)] = map[string]bool{}
+		}
+
+		s.subjects[Flag(flag)][subject] = true
+	}
+
+	return s, nil
+}
+
+// Enabled returns whether the flag is enabled for everyone, or for the
+// specific subject.
+func (s *Static) Enabled(_ context.Context, flag Flag, subject string) bool {
+	if s.enabled[flag] {
+		return true
+	}
+
+	return subject != "" && s.subjects[flag][subject]
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2026 Nscale.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package readiness
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+var (
+	// ErrNotReady is raised when one or more subsystems are not ready.
+	ErrNotReady = errors.New("not ready")
+)
+
+// Checker is anything that can report its readiness e.g. a cache.
+type Checker interface {
+	Ready() bool
+}
+
+// CheckerFunc allows a function to be used as a Checker.
+type CheckerFunc func() bool
+
+// Ready implements the Checker interface.
+func (f CheckerFunc) Ready() bool {
+	return f()
+}
+
+// Elector is anything that signals when it has been elected leader e.g. a
+// controller-runtime manager.
+type Elector interface {
+	Elected() <-chan struct{}
+}
+
+// Elected returns a checker that is ready once the elector has been elected
+// leader, and thus its controllers and queues are running.
+func Elected(elector Elector) Checker {
+	return CheckerFunc(func() bool {
+		select {
+		case <-elector.Elected():
+			return true
+		default:
+			return false
+		}
+	})
+}
+
+// subsystem is a named checker.
+type subsystem struct {
+	name    string
+	checker Checker
+}
+
+// Coordinator aggregates the readiness of all subsystems in a service, e.g. its
+// caches, message queues and controller managers, so traffic only flows when
+// every one is ready.
+type Coordinator struct {
+	subsystems []subsystem
+	lock       sync.RWMutex
+}
+
+// New creates a new readiness coordinator.
+func New() *Coordinator {
+	return &Coordinator{}
+}
+
+// Register adds a named subsystem to the readiness checks.
+func (c *Coordinator) Register(name string, checker Checker) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.subsystems = append(c.subsystems, subsystem{
+		name:    name,
+		checker: checker,
+	})
+}
+
+// NotReady returns the names of any subsystems that are not ready, in
+// registration order.
+func (c *Coordinator) NotReady() []string {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	var names []string
+
+	for _, s := range c.subsystems {
+		if !s.checker.Ready() {
+			names = append(names, s.name)
+		}
+	}
+
+	return names
+}
+
+// Ready returns true if all subsystems are ready.
+func (c *Coordinator) Ready() bool {
+	return len(c.NotReady()) == 0
+}
+
+// Check implements a controller-runtime healthz.Checker so it can be added to a
+// manager's readiness probe.
+func (c *Coordinator) Check(_ *http.Request) error {
+	if names := c.NotReady(); len(names) > 0 {
+		return fmt.Errorf("%w: %s", ErrNotReady, strings.Join(names, ", "))
+	}
+
+	return nil
+}
+
+// Handler returns an HTTP handler that can be used as a readiness probe e.g.
+// on /readyz.
+func (c *Coordinator) Handler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := c.Check(r); err != nil {
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}
+}
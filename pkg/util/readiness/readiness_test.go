@@ -0,0 +1,92 @@
+/*
+Copyright 2026 Nscale.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package readiness_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/unikorn-cloud/core/pkg/util/cache"
+	"github.com/unikorn-cloud/core/pkg/util/readiness"
+)
+
+type item struct {
+	id string
+}
+
+func (i *item) Index() string {
+	return i.id
+}
+
+func (i *item) Equal(o *item) bool {
+	return *i == *o
+}
+
+func refresh(_ context.Context) ([]*item, error) {
+	return []*item{{id: "foo"}}, nil
+}
+
+// elector signals election when closed.
+type elector chan struct{}
+
+func (e elector) Elected() <-chan struct{} {
+	return e
+}
+
+// TestCoordinator tests a not ready cache makes the service not ready, even
+// when the queue is ready, and that all subsystems being ready does too.
+func TestCoordinator(t *testing.T) {
+	t.Parallel()
+
+	c := cache.NewRefreshAheadCache[item](refresh, &cache.RefreshAheadCacheOptions{})
+
+	manager := make(elector)
+
+	coordinator := readiness.New()
+	coordinator.Register("cache", c)
+	coordinator.Register("queue", readiness.CheckerFunc(func() bool { return true }))
+	coordinator.Register("manager", readiness.Elected(manager))
+
+	handler := coordinator.Handler()
+
+	probe := func() int {
+		w := httptest.NewRecorder()
+		handler(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+		return w.Code
+	}
+
+	require.False(t, coordinator.Ready())
+	require.Equal(t, []string{"cache", "manager"}, coordinator.NotReady())
+	require.ErrorIs(t, coordinator.Check(nil), readiness.ErrNotReady)
+	require.Equal(t, http.StatusServiceUnavailable, probe())
+
+	close(manager)
+
+	require.Equal(t, []string{"cache"}, coordinator.NotReady())
+	require.Equal(t, http.StatusServiceUnavailable, probe())
+
+	require.NoError(t, c.Run(t.Context()))
+
+	require.True(t, coordinator.Ready())
+	require.NoError(t, coordinator.Check(nil))
+	require.Equal(t, http.StatusOK, probe())
+}
@@ -17,6 +17,10 @@ limitations under the License.
 
 package util
 
+import (
+	"fmt"
+)
+
 // ServiceDescriptor is used to define a common format for application
 // information.  This is typically used to initialize logging and IPC
 // so we can see who instigated a request.
@@ -28,3 +32,9 @@ type ServiceDescriptor struct {
 	// Revision is the revision of the service (typically a Git SHA).
 	Revision string
 }
+
+// VersionString returns a canonical version string for the service, for
+// example in a User-Agent or Server header.
+func (s ServiceDescriptor) VersionString() string {
+	return fmt.Sprintf("%s/%s (revision/%s)", s.Name, s.Version, s.Revision)
+}